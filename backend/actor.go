@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// actorHeader carries the identity of whoever is making a change. REST
+// callers send it as the X-Actor HTTP header.
+const actorHeader = "x-actor"
+
+// actorKey holds the subject of the request's verified bearer token
+type actorKey struct{}
+
+// actorFromContext returns the identity of the caller. With tenant tokens
+// configured it is the subject of the verified token and X-Actor is
+// ignored; without them it is the X-Actor header.
+func actorFromContext(ctx context.Context) string {
+	if subject, ok := ctx.Value(actorKey{}).(string); ok {
+		if subject != "" {
+			return subject
+		}
+		return "anonymous"
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(actorHeader); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	return "anonymous"
+}
+
+// gatewayHeaderMatcher forwards X-Actor, X-Tenant-ID and Last-Event-ID to
+// gRPC on top of the default headers
+func gatewayHeaderMatcher(key string) (string, bool) {
+	for _, h := range []string{actorHeader, tenantHeader, lastEventIDHeader} {
+		if strings.EqualFold(key, h) {
+			return h, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Build metadata, set with -ldflags "-X main.version=... -X main.gitCommit=..."
+var (
+	version   = "dev"
+	gitCommit = ""
+)
+
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+	cfg       Config
+	startedAt time.Time
+}
+
+func NewAdminServer(cfg Config, startedAt time.Time) pb.AdminServiceServer {
+	return &adminServer{cfg: cfg, startedAt: startedAt}
+}
+
+// GetServerInfo
+func (s *adminServer) GetServerInfo(ctx context.Context, req *pb.Empty) (*pb.ServerInfo, error) {
+	log.Println("GetServerInfo RPC called")
+
+	return &pb.ServerInfo{
+		Version:        version,
+		GitCommit:      buildCommit(),
+		StartedAt:      timestamppb.New(s.startedAt),
+		Uptime:         durationpb.New(time.Since(s.startedAt).Truncate(time.Second)),
+		StorageBackend: "mongodb",
+		Database:       s.cfg.DatabaseName,
+		Features:       s.cfg.Features(),
+	}, nil
+}
+
+// buildCommit falls back to the VCS revision stamped by the go toolchain
+// when the commit was not passed in through ldflags.
+func buildCommit() string {
+	if gitCommit != "" {
+		return gitCommit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
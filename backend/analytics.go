@@ -0,0 +1,563 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Period lengths as years, months and days to add
+var analyticsIntervals = map[string][3]int{
+	"day":     {0, 0, 1},
+	"week":    {0, 0, 7},
+	"month":   {0, 1, 0},
+	"quarter": {0, 3, 0},
+	"year":    {1, 0, 0},
+}
+
+// maxAnalyticsPeriods caps the periods one request can cover
+const maxAnalyticsPeriods = 1000
+
+type analyticsServer struct {
+	pb.UnimplementedAnalyticsServiceServer
+	employeesCollection *mongo.Collection
+	versionsCollection  *mongo.Collection
+	// Definitions of the custom fields queries can use
+	customFieldsCollection *mongo.Collection
+}
+
+func NewAnalyticsServer(employees, versions, customFields *mongo.Collection) pb.AnalyticsServiceServer {
+	return &analyticsServer{employeesCollection: employees, versionsCollection: versions, customFieldsCollection: customFields}
+}
+
+// groupExpression builds the $group key for the dimensions in groupBy, nil
+// if there are none. Dimensions are the groupable query fields, with dates
+// grouped by a period as in hire_date:year.
+func (s *analyticsServer) groupExpression(ctx context.Context, groupBy []string) (interface{}, error) {
+	if len(groupBy) == 0 {
+		return nil, nil
+	}
+	fields, err := loadQueryFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	group := bson.M{}
+	for _, entry := range groupBy {
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			column, _, expr, err := fields.groupField(name)
+			if err != nil {
+				return nil, err
+			}
+			// Names in a $group key cannot contain dots, as in custom.badge_number
+			group[strings.ReplaceAll(column, ".", "/")] = expr
+		}
+	}
+	if len(group) == 0 {
+		return nil, nil
+	}
+	return group, nil
+}
+
+// dimensionValues turns a decoded group key into dimension values, with
+// dates as RFC 3339
+func dimensionValues(key bson.M) map[string]string {
+	if key == nil {
+		return nil
+	}
+	values := make(map[string]string, len(key))
+	for k, v := range key {
+		values[strings.ReplaceAll(k, "/", ".")] = cellText(queryCell(v))
+	}
+	return values
+}
+
+// dimensionsKey orders groups with equal counts
+func dimensionsKey(dims map[string]string) string {
+	keys := make([]string, 0, len(dims))
+	for k := range dims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(dims[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func sortHeadcountGroups(groups []*pb.HeadcountGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return dimensionsKey(groups[i].Dimensions) < dimensionsKey(groups[j].Dimensions)
+	})
+}
+
+// periodStart truncates t to the start of its period, in UTC. Weeks start
+// on Monday, like $dateTrunc with startOfWeek monday.
+func periodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	y, m, d := t.Date()
+	switch interval {
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	case "week":
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "quarter":
+		return time.Date(y, (m-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// analyticsPeriods returns the interval and the starts of the periods from
+// start to end, plus the end of the last one
+func analyticsPeriods(start, end *timestamppb.Timestamp, interval string) (string, []time.Time, error) {
+	if interval == "" {
+		interval = "month"
+	}
+	step, ok := analyticsIntervals[interval]
+	if !ok {
+		return "", nil, status.Errorf(codes.InvalidArgument, "Unknown interval: %s", interval)
+	}
+
+	to := time.Now().UTC()
+	if end != nil {
+		if err := end.CheckValid(); err != nil {
+			return "", nil, status.Errorf(codes.InvalidArgument, "Invalid end: %v", err)
+		}
+		to = end.AsTime()
+	}
+	from := to.AddDate(-1, 0, 0)
+	if start != nil {
+		if err := start.CheckValid(); err != nil {
+			return "", nil, status.Errorf(codes.InvalidArgument, "Invalid start: %v", err)
+		}
+		from = start.AsTime()
+	}
+	if from.After(to) {
+		return "", nil, status.Errorf(codes.InvalidArgument, "start must not be after end")
+	}
+
+	var periods []time.Time
+	for t := periodStart(from, interval); ; t = t.AddDate(step[0], step[1], step[2]) {
+		periods = append(periods, t)
+		if t.After(to) {
+			break
+		}
+		if len(periods) > maxAnalyticsPeriods {
+			return "", nil, status.Errorf(codes.InvalidArgument, "More than %d periods, use a longer interval", maxAnalyticsPeriods)
+		}
+	}
+	return interval, periods, nil
+}
+
+// GetHeadcount
+func (s *analyticsServer) GetHeadcount(ctx context.Context, req *pb.HeadcountRequest) (*pb.HeadcountReport, error) {
+	log.Println("GetHeadcount RPC called")
+
+	group, err := s.groupExpression(ctx, req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+
+	collection := s.employeesCollection
+	var pipeline mongo.Pipeline
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+		}
+		collection = s.versionsCollection
+		pipeline = asOfStages(req.GetAsOf().AsTime())
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: notTerminated}},
+		bson.D{{Key: "$group", Value: bson.M{"_id": group, "count": bson.M{"$sum": 1}}}},
+	)
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+	}
+	var rows []struct {
+		ID    bson.M `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode headcount: %v", err)
+	}
+
+	report := &pb.HeadcountReport{}
+	for _, row := range rows {
+		report.Total += row.Count
+		if group != nil {
+			report.Groups = append(report.Groups, &pb.HeadcountGroup{Dimensions: dimensionValues(row.ID), Count: row.Count})
+		}
+	}
+	sortHeadcountGroups(report.Groups)
+	return report, nil
+}
+
+// GetHeadcountTrend
+func (s *analyticsServer) GetHeadcountTrend(ctx context.Context, req *pb.HeadcountTrendRequest) (*pb.HeadcountTrend, error) {
+	log.Println("GetHeadcountTrend RPC called")
+
+	group, err := s.groupExpression(ctx, req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	_, periods, err := analyticsPeriods(req.GetStart(), req.GetEnd(), req.GetInterval())
+	if err != nil {
+		return nil, err
+	}
+	// The last entry only ends the last period
+	points := periods[:len(periods)-1]
+	times := make(bson.A, len(points))
+	for i, t := range points {
+		times[i] = t
+	}
+
+	// Each version counts at the points it covers
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"effective_from": bson.M{"$lte": points[len(points)-1]},
+			"$or": bson.A{
+				bson.M{"effective_to": bson.M{"$exists": false}},
+				bson.M{"effective_to": bson.M{"$gt": points[0]}},
+			},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"employee": 1,
+			"points": bson.M{"$filter": bson.M{
+				"input": times,
+				"as":    "t",
+				"cond": bson.M{"$and": bson.A{
+					bson.M{"$lte": bson.A{"$effective_from", "$$t"}},
+					bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$effective_to", nil}}, nil}},
+						bson.M{"$gt": bson.A{"$effective_to", "$$t"}},
+					}},
+				}},
+			}},
+		}}},
+		{{Key: "$unwind", Value: "$points"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{
+			"$mergeObjects": bson.A{"$employee", bson.M{"_point": "$points"}},
+		}}}},
+		{{Key: "$match", Value: notTerminated}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"point": "$_point", "group": group},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := s.versionsCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+	}
+	var rows []struct {
+		ID struct {
+			Point time.Time `bson:"point"`
+			Group bson.M    `bson:"group"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode headcount: %v", err)
+	}
+
+	trend := &pb.HeadcountTrend{}
+	byTime := make(map[time.Time]*pb.HeadcountPoint, len(points))
+	for _, t := range points {
+		point := &pb.HeadcountPoint{Time: timestamppb.New(t)}
+		trend.Points = append(trend.Points, point)
+		byTime[t] = point
+	}
+	for _, row := range rows {
+		point, ok := byTime[row.ID.Point.UTC()]
+		if !ok {
+			continue
+		}
+		point.Total += row.Count
+		if group != nil {
+			point.Groups = append(point.Groups, &pb.HeadcountGroup{Dimensions: dimensionValues(row.ID.Group), Count: row.Count})
+		}
+	}
+	for _, point := range trend.Points {
+		sortHeadcountGroups(point.Groups)
+	}
+	return trend, nil
+}
+
+// GetHiresAndTerminations
+func (s *analyticsServer) GetHiresAndTerminations(ctx context.Context, req *pb.HiresAndTerminationsRequest) (*pb.HiresAndTerminations, error) {
+	log.Println("GetHiresAndTerminations RPC called")
+
+	group, err := s.groupExpression(ctx, req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	interval, periods, err := analyticsPeriods(req.GetStart(), req.GetEnd(), req.GetInterval())
+	if err != nil {
+		return nil, err
+	}
+
+	history := bson.M{"$ifNull": bson.A{"$status_history", bson.A{}}}
+	dates := func(cond bson.M) bson.M {
+		return bson.M{"$map": bson.M{
+			"input": bson.M{"$filter": bson.M{"input": history, "as": "e", "cond": cond}},
+			"as":    "e",
+			"in":    "$$e.effective_date",
+		}}
+	}
+	// Hires and rehires move a candidate or former employee into employment
+	hires := dates(bson.M{"$and": bson.A{
+		bson.M{"$in": bson.A{"$$e.from", bson.A{statusCandidate, statusTerminated}}},
+		bson.M{"$in": bson.A{"$$e.to", bson.A{statusPreBoarding, statusActive}}},
+	}})
+	// Employees created as active have only a hire date, and those created as
+	// pre-boarding only their start
+	starts := dates(bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{"$$e.from", statusPreBoarding}},
+		bson.M{"$eq": bson.A{"$$e.to", statusActive}},
+	}})
+	firstHire := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$hire_date", nil}},
+		bson.A{"$hire_date"},
+		bson.M{"$slice": bson.A{starts, 1}},
+	}}
+	terminations := dates(bson.M{"$eq": bson.A{"$$e.to", statusTerminated}})
+	movement := func(kind string, dates interface{}) bson.M {
+		return bson.M{"$map": bson.M{"input": dates, "as": "d", "in": bson.M{"kind": kind, "date": "$$d"}}}
+	}
+
+	count := func(kind string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$_movement.kind", kind}}, 1, 0}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"_hires": hires}}},
+		{{Key: "$set", Value: bson.M{"_movement": bson.M{"$concatArrays": bson.A{
+			movement("hire", bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{bson.M{"$size": "$_hires"}, 0}}, "$_hires", firstHire}}),
+			movement("termination", terminations),
+		}}}}},
+		{{Key: "$unwind", Value: "$_movement"}},
+		{{Key: "$match", Value: bson.M{"_movement.date": bson.M{"$gte": periods[0], "$lt": periods[len(periods)-1]}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"period": bson.M{"$dateTrunc": bson.M{"date": "$_movement.date", "unit": interval, "startOfWeek": "monday", "timezone": "UTC"}},
+				"group":  group,
+			},
+			"hires":        count("hire"),
+			"terminations": count("termination"),
+		}}},
+	}
+
+	cursor, err := s.employeesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count hires and terminations: %v", err)
+	}
+	var rows []struct {
+		ID struct {
+			Period time.Time `bson:"period"`
+			Group  bson.M    `bson:"group"`
+		} `bson:"_id"`
+		Hires        int64 `bson:"hires"`
+		Terminations int64 `bson:"terminations"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode hires and terminations: %v", err)
+	}
+
+	resp := &pb.HiresAndTerminations{}
+	byStart := make(map[time.Time]*pb.MovementPeriod, len(periods))
+	for _, t := range periods[:len(periods)-1] {
+		period := &pb.MovementPeriod{PeriodStart: timestamppb.New(t)}
+		resp.Periods = append(resp.Periods, period)
+		byStart[t] = period
+	}
+	for _, row := range rows {
+		period, ok := byStart[row.ID.Period.UTC()]
+		if !ok {
+			continue
+		}
+		period.Hires += row.Hires
+		period.Terminations += row.Terminations
+		if group != nil {
+			period.Groups = append(period.Groups, &pb.MovementGroup{
+				Dimensions:   dimensionValues(row.ID.Group),
+				Hires:        row.Hires,
+				Terminations: row.Terminations,
+			})
+		}
+	}
+	for _, period := range resp.Periods {
+		sort.Slice(period.Groups, func(i, j int) bool {
+			return dimensionsKey(period.Groups[i].Dimensions) < dimensionsKey(period.Groups[j].Dimensions)
+		})
+	}
+	return resp, nil
+}
+
+// GetSpanOfControl
+func (s *analyticsServer) GetSpanOfControl(ctx context.Context, req *pb.SpanOfControlRequest) (*pb.SpanOfControl, error) {
+	log.Println("GetSpanOfControl RPC called")
+
+	group, err := s.groupExpression(ctx, req.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	top := int(req.GetTop())
+	if top <= 0 {
+		top = 10
+	}
+	if top > 100 {
+		top = 100
+	}
+
+	// Count the reports of each manager, then describe the managers
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"manager_id": bson.M{"$exists": true}, "status": bson.M{"$ne": statusTerminated}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$manager_id", "reports": bson.M{"$sum": 1}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         s.employeesCollection.Name(),
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "manager",
+		}}},
+		{{Key: "$unwind", Value: "$manager"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{
+			"$mergeObjects": bson.A{"$manager", bson.M{"_reports": "$reports"}},
+		}}}},
+		{{Key: "$facet", Value: bson.M{
+			"distribution": bson.A{
+				bson.M{"$group": bson.M{"_id": "$_reports", "managers": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"groups": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":      group,
+					"managers": bson.M{"$sum": 1},
+					"mean":     bson.M{"$avg": "$_reports"},
+					"max":      bson.M{"$max": "$_reports"},
+				}},
+			},
+			"top": bson.A{
+				bson.M{"$sort": bson.D{{Key: "_reports", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": top},
+				bson.M{"$project": bson.M{"first_name": 1, "last_name": 1, "_reports": 1}},
+			},
+		}}},
+	}
+
+	cursor, err := s.employeesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count direct reports: %v", err)
+	}
+	var facets []struct {
+		Distribution []struct {
+			Reports  int32 `bson:"_id"`
+			Managers int64 `bson:"managers"`
+		} `bson:"distribution"`
+		Groups []struct {
+			ID       bson.M  `bson:"_id"`
+			Managers int64   `bson:"managers"`
+			Mean     float64 `bson:"mean"`
+			Max      int32   `bson:"max"`
+		} `bson:"groups"`
+		Top []struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			FirstName string             `bson:"first_name"`
+			LastName  string             `bson:"last_name"`
+			Reports   int32              `bson:"_reports"`
+		} `bson:"top"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode span of control: %v", err)
+	}
+	employees, err := s.employeesCollection.CountDocuments(ctx, notTerminated)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+	}
+
+	resp := &pb.SpanOfControl{}
+	if len(facets) == 0 {
+		resp.IndividualContributors = employees
+		return resp, nil
+	}
+	f := facets[0]
+
+	var reports int64
+	for _, b := range f.Distribution {
+		resp.Distribution = append(resp.Distribution, &pb.SpanBucket{DirectReports: b.Reports, Managers: b.Managers})
+		resp.Managers += b.Managers
+		reports += int64(b.Reports) * b.Managers
+		resp.MaxDirectReports = max(resp.MaxDirectReports, b.Reports)
+	}
+	resp.IndividualContributors = max(employees-resp.Managers, 0)
+	if resp.Managers > 0 {
+		resp.MeanDirectReports = roundTo(float64(reports)/float64(resp.Managers), 2)
+		resp.MedianDirectReports = (spanAt(resp.Distribution, (resp.Managers-1)/2) + spanAt(resp.Distribution, resp.Managers/2)) / 2
+	}
+
+	if group != nil {
+		for _, g := range f.Groups {
+			resp.Groups = append(resp.Groups, &pb.SpanGroup{
+				Dimensions:        dimensionValues(g.ID),
+				Managers:          g.Managers,
+				MeanDirectReports: roundTo(g.Mean, 2),
+				MaxDirectReports:  g.Max,
+			})
+		}
+		sort.Slice(resp.Groups, func(i, j int) bool {
+			a, b := resp.Groups[i], resp.Groups[j]
+			if a.Managers != b.Managers {
+				return a.Managers > b.Managers
+			}
+			return dimensionsKey(a.Dimensions) < dimensionsKey(b.Dimensions)
+		})
+	}
+	for _, m := range f.Top {
+		resp.TopManagers = append(resp.TopManagers, &pb.ManagerSpan{
+			EmployeeId:    m.ID.Hex(),
+			Name:          strings.TrimSpace(m.FirstName + " " + m.LastName),
+			DirectReports: m.Reports,
+		})
+	}
+	return resp, nil
+}
+
+// spanAt is the number of direct reports of the i-th manager in order of
+// span, from the distribution
+func spanAt(distribution []*pb.SpanBucket, i int64) float64 {
+	for _, b := range distribution {
+		if i < b.Managers {
+			return float64(b.DirectReports)
+		}
+		i -= b.Managers
+	}
+	return 0
+}
+
+func roundTo(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditedServices are the gRPC services whose calls are written to the
+// audit log
+var auditedServices = []string{"/employee.EmployeeService/", "/employee.JobService/", "/employee.TenantService/", "/employee.ScimService/"}
+
+// unauditedMethods are left out of the audit log all the same. Suggestions
+// come with every keystroke of a people picker and only return directory
+// details.
+var unauditedMethods = []string{"/employee.EmployeeService/SuggestEmployees"}
+
+// AuditEntry is one line of the append-only audit log. Each entry stores the
+// hash of the one before it, so editing or removing an entry breaks the chain
+// from that point on. Hashes are keyed with a secret held by the server, so
+// the chain cannot be rebuilt by someone who can only write the database.
+type AuditEntry struct {
+	Sequence  int64     `bson:"sequence" json:"sequence"`
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Actor     string    `bson:"actor" json:"actor"`
+	Peer      string    `bson:"peer" json:"peer"`
+	Method    string    `bson:"method" json:"method"`
+	TargetIDs []string  `bson:"target_ids" json:"target_ids"`
+	Outcome   string    `bson:"outcome" json:"outcome"`
+	Error     string    `bson:"error" json:"error"`
+	PrevHash  string    `bson:"prev_hash" json:"prev_hash"`
+	Hash      string    `bson:"hash" json:"-"`
+}
+
+// computeHash computes the HMAC-SHA256 of every field except Hash itself.
+// Timestamps are stored with millisecond precision, so they are hashed that
+// way too.
+func (e AuditEntry) computeHash(key []byte) string {
+	e.Timestamp = e.Timestamp.UTC().Truncate(time.Millisecond)
+	if e.TargetIDs == nil {
+		e.TargetIDs = []string{}
+	}
+	data, _ := json.Marshal(e)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (e AuditEntry) toProto() *pb.AuditEntry {
+	return &pb.AuditEntry{
+		Sequence:  e.Sequence,
+		Timestamp: timestamppb.New(e.Timestamp),
+		Actor:     e.Actor,
+		Peer:      e.Peer,
+		Method:    e.Method,
+		TargetIds: e.TargetIDs,
+		Outcome:   e.Outcome,
+		Error:     e.Error,
+		PrevHash:  e.PrevHash,
+		Hash:      e.Hash,
+	}
+}
+
+type auditLog struct {
+	collection *mongo.Collection
+	key        []byte
+	// Serializes appends from this server; other servers still race for the
+	// tail and retry
+	mu sync.Mutex
+}
+
+func newAuditLog(collection *mongo.Collection, key string) *auditLog {
+	return &auditLog{collection: collection, key: []byte(key)}
+}
+
+func (a *auditLog) audited(method string) bool {
+	for _, m := range unauditedMethods {
+		if method == m {
+			return false
+		}
+	}
+	for _, prefix := range auditedServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryInterceptor records unary calls once the handler has returned
+func (a *auditLog) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if a.audited(info.FullMethod) {
+		if auditErr := a.record(ctx, info.FullMethod, auditTargets(req, resp), err); auditErr != nil {
+			return nil, auditErr
+		}
+	}
+	return resp, err
+}
+
+// StreamInterceptor records streaming calls once the stream has finished
+func (a *auditLog) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if a.audited(info.FullMethod) {
+		if auditErr := a.record(ss.Context(), info.FullMethod, nil, err); auditErr != nil {
+			return auditErr
+		}
+	}
+	return err
+}
+
+// record appends an entry for a finished call. A call that cannot be audited
+// fails with Internal, even though its change may have been made, so no
+// change goes unaudited without the client knowing.
+func (a *auditLog) record(ctx context.Context, method string, targets []string, callErr error) error {
+	st := status.Convert(callErr)
+	entry := AuditEntry{
+		Timestamp: time.Now().UTC().Truncate(time.Millisecond),
+		Actor:     actorFromContext(ctx),
+		Peer:      peerFromContext(ctx),
+		Method:    method,
+		TargetIDs: targets,
+		Outcome:   st.Code().String(),
+	}
+	if callErr != nil {
+		entry.Error = st.Message()
+	}
+
+	// The call context may already be cancelled
+	writeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.append(writeCtx, entry); err != nil {
+		log.Printf("Failed to write audit entry for %s: %v", method, err)
+		return status.Errorf(codes.Internal, "Failed to write audit entry, the call may have taken effect: %v", err)
+	}
+	return nil
+}
+
+// append links entry to the end of the chain. Sequence numbers are unique,
+// so concurrent writers that pick the same one retry against the new tail
+// until ctx is done.
+func (a *auditLog) append(ctx context.Context, entry AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for ctx.Err() == nil {
+		var last AuditEntry
+		err := a.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"sequence": -1})).Decode(&last)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		entry.Sequence = last.Sequence + 1
+		entry.PrevHash = last.Hash
+		entry.Hash = entry.computeHash(a.key)
+
+		_, err = a.collection.InsertOne(ctx, entry)
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("too much contention on the audit log: %w", ctx.Err())
+}
+
+// verify walks the whole chain in order and checks every link. The result
+// carries the head of the chain; given a head exported earlier, the chain
+// must still contain that entry unchanged.
+func (a *auditLog) verify(ctx context.Context, headSequence int64, headHash string) (*pb.AuditVerification, error) {
+	cursor, err := a.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"sequence": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := &pb.AuditVerification{Valid: true}
+	fail := func(seq int64, format string, args ...interface{}) {
+		result.Valid = false
+		result.FirstInvalidSequence = seq
+		result.Error = fmt.Sprintf(format, args...)
+	}
+
+	var prev AuditEntry
+	for cursor.Next(ctx) {
+		var entry AuditEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		result.EntriesChecked++
+
+		switch {
+		case entry.Sequence != prev.Sequence+1:
+			fail(entry.Sequence, "expected sequence %d, found %d", prev.Sequence+1, entry.Sequence)
+		case entry.PrevHash != prev.Hash:
+			fail(entry.Sequence, "entry %d does not link to the hash of entry %d", entry.Sequence, prev.Sequence)
+		case entry.computeHash(a.key) != entry.Hash:
+			fail(entry.Sequence, "entry %d has been modified", entry.Sequence)
+		case entry.Sequence == headSequence && entry.Hash != headHash:
+			fail(entry.Sequence, "entry %d does not match the expected head", entry.Sequence)
+		}
+		if !result.Valid {
+			return result, nil
+		}
+		prev = entry
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	result.HeadSequence = prev.Sequence
+	result.HeadHash = prev.Hash
+	if headSequence > prev.Sequence {
+		fail(prev.Sequence+1, "the log ends at entry %d, before the expected head %d", prev.Sequence, headSequence)
+	}
+	return result, nil
+}
+
+// auditTargets collects the IDs a call was about: every non-empty "id" or
+// "*_id" string field of the request, plus the ID of a created resource.
+func auditTargets(req, resp interface{}) []string {
+	var ids []string
+	seen := map[string]bool{}
+	collect := func(v interface{}) {
+		msg, ok := v.(proto.Message)
+		if !ok || msg == nil {
+			return
+		}
+		m := msg.ProtoReflect()
+		if !m.IsValid() {
+			return
+		}
+		m.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+			name := string(fd.Name())
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && (name == "id" || strings.HasSuffix(name, "_id")) {
+				if id := val.String(); id != "" && !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+			return true
+		})
+	}
+
+	collect(req)
+	if len(ids) == 0 {
+		collect(resp)
+	}
+	return ids
+}
+
+// peerFromContext returns the client address, looking through the gateway
+func peerFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-forwarded-for"); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+type auditServer struct {
+	pb.UnimplementedAuditServiceServer
+	log *auditLog
+}
+
+func NewAuditServer(a *auditLog) pb.AuditServiceServer {
+	return &auditServer{log: a}
+}
+
+// ListAuditEntries
+func (s *auditServer) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.AuditEntryList, error) {
+	log.Println("ListAuditEntries RPC called")
+
+	filter := bson.M{}
+	if req.GetActor() != "" {
+		filter["actor"] = req.GetActor()
+	}
+	if req.GetMethod() != "" {
+		filter["method"] = req.GetMethod()
+	}
+	if req.GetTargetId() != "" {
+		filter["target_ids"] = req.GetTargetId()
+	}
+	if req.GetOutcome() != "" {
+		filter["outcome"] = req.GetOutcome()
+	}
+
+	timeRange := bson.M{}
+	if req.GetStartTime() != nil {
+		if err := req.GetStartTime().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid start_time: %v", err)
+		}
+		timeRange["$gte"] = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		if err := req.GetEndTime().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid end_time: %v", err)
+		}
+		timeRange["$lt"] = req.GetEndTime().AsTime()
+	}
+	if len(timeRange) > 0 {
+		filter["timestamp"] = timeRange
+	}
+
+	if req.GetPageToken() != "" {
+		before, err := strconv.ParseInt(req.GetPageToken(), 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+		filter["sequence"] = bson.M{"$lt": before}
+	}
+
+	pageSize := int64(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if pageSize > 1000 {
+		pageSize = 1000
+	}
+
+	cursor, err := s.log.collection.Find(ctx, filter,
+		options.Find().SetSort(bson.M{"sequence": -1}).SetLimit(pageSize+1),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve audit entries: %v", err)
+	}
+
+	var entries []AuditEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode audit entry: %v", err)
+	}
+
+	list := &pb.AuditEntryList{}
+	if int64(len(entries)) > pageSize {
+		entries = entries[:pageSize]
+		list.NextPageToken = strconv.FormatInt(entries[len(entries)-1].Sequence, 10)
+	}
+	for _, e := range entries {
+		list.Entries = append(list.Entries, e.toProto())
+	}
+	return list, nil
+}
+
+// VerifyAuditLog
+func (s *auditServer) VerifyAuditLog(ctx context.Context, req *pb.VerifyAuditLogRequest) (*pb.AuditVerification, error) {
+	log.Println("VerifyAuditLog RPC called")
+
+	if req.GetHeadSequence() < 0 || (req.GetHeadSequence() > 0) != (req.GetHeadHash() != "") {
+		return nil, status.Errorf(codes.InvalidArgument, "Head sequence and head hash must be given together")
+	}
+
+	result, err := s.log.verify(ctx, req.GetHeadSequence(), req.GetHeadHash())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to read audit log: %v", err)
+	}
+	return result, nil
+}
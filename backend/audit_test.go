@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAuditEntriesInvalidTimes(t *testing.T) {
+	s := NewAuditServer(newAuditLog(nil, "key"))
+	tests := []struct {
+		name string
+		req  *pb.ListAuditEntriesRequest
+	}{
+		{"nanos out of range", &pb.ListAuditEntriesRequest{StartTime: &timestamppb.Timestamp{Seconds: 1, Nanos: -1}}},
+		{"before year 1", &pb.ListAuditEntriesRequest{StartTime: &timestamppb.Timestamp{Seconds: -62135596801}}},
+		{"after year 9999", &pb.ListAuditEntriesRequest{EndTime: &timestamppb.Timestamp{Seconds: 253402300800}}},
+		{"valid start, invalid end", &pb.ListAuditEntriesRequest{StartTime: timestamppb.Now(), EndTime: &timestamppb.Timestamp{Nanos: 1e9}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ListAuditEntries(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ListAuditEntries = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
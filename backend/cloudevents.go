@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// CloudEvents 1.0 encodings of the domain events
+const (
+	// Structured mode with the data as JSON
+	eventFormatJSON = "json"
+	// The data as binary protobuf: ce-* headers over HTTP, data_base64 in
+	// the JSON event format elsewhere
+	eventFormatProtobuf = "protobuf"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsJSONType    = "application/cloudevents+json"
+	protobufContentType    = "application/protobuf"
+)
+
+// cloudEvent is a domain event with its CloudEvents context attributes
+type cloudEvent struct {
+	ID      string
+	Source  string
+	Type    string
+	Subject string
+	Time    time.Time
+	// Data is the event message as binary protobuf
+	Data []byte
+}
+
+// newCloudEvent wraps an outbox event. The type is the full name of the
+// event message.
+func newCloudEvent(source string, e OutboxEvent) cloudEvent {
+	return cloudEvent{
+		ID:      e.ID.Hex(),
+		Source:  source,
+		Type:    e.DataType,
+		Subject: e.Subject.Hex(),
+		Time:    e.CreatedAt,
+		Data:    e.Data,
+	}
+}
+
+// message decodes the data back into its event message
+func (e cloudEvent) message() (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(e.Type))
+	if err != nil {
+		return nil, fmt.Errorf("unknown event type %s: %w", e.Type, err)
+	}
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(e.Data, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", e.Type, err)
+	}
+	return msg, nil
+}
+
+// structured encodes the event in the CloudEvents JSON format
+func (e cloudEvent) structured(format string) ([]byte, error) {
+	doc := map[string]interface{}{
+		"specversion": cloudEventsSpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+		"subject":     e.Subject,
+		"time":        e.Time.UTC().Format(time.RFC3339Nano),
+	}
+
+	if format == eventFormatProtobuf {
+		doc["datacontenttype"] = protobufContentType
+		doc["data_base64"] = e.Data
+	} else {
+		msg, err := e.message()
+		if err != nil {
+			return nil, err
+		}
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		doc["datacontenttype"] = "application/json"
+		doc["data"] = json.RawMessage(data)
+	}
+	return json.Marshal(doc)
+}
+
+// eventSink is somewhere domain events are published to. Send must succeed
+// before the event counts as published; failed events are sent again, so
+// sinks see every event at least once.
+type eventSink interface {
+	Send(ctx context.Context, e cloudEvent) error
+}
+
+// newEventSinks builds the sinks listed in spec, a comma-separated list of
+// file:<path> and http(s):// URLs. HTTP sinks post through client.
+func newEventSinks(spec, format string, client *http.Client) ([]eventSink, error) {
+	if format != eventFormatJSON && format != eventFormatProtobuf {
+		return nil, fmt.Errorf("unknown event format %q, expected %s or %s", format, eventFormatJSON, eventFormatProtobuf)
+	}
+
+	var sinks []eventSink
+	for _, target := range strings.Split(spec, ",") {
+		target = strings.TrimSpace(target)
+		switch {
+		case target == "":
+			continue
+		case strings.HasPrefix(target, "file:"):
+			sinks = append(sinks, &fileSink{path: strings.TrimPrefix(target, "file:"), format: format})
+		case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+			sinks = append(sinks, &httpSink{url: target, format: format, client: client})
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", target)
+		}
+	}
+	return sinks, nil
+}
+
+// fileSink appends events to a local file, one JSON event per line
+type fileSink struct {
+	path   string
+	format string
+	mu     sync.Mutex
+}
+
+func (s *fileSink) Send(ctx context.Context, e cloudEvent) error {
+	line, err := e.structured(s.format)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// httpSink posts each event to a URL, in structured mode for JSON and in
+// binary mode for protobuf
+type httpSink struct {
+	url    string
+	format string
+	client *http.Client
+}
+
+func (s *httpSink) Send(ctx context.Context, e cloudEvent) error {
+	var req *http.Request
+	var err error
+	if s.format == eventFormatProtobuf {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(e.Data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", protobufContentType)
+		req.Header.Set("ce-specversion", cloudEventsSpecVersion)
+		req.Header.Set("ce-id", e.ID)
+		req.Header.Set("ce-source", e.Source)
+		req.Header.Set("ce-type", e.Type)
+		req.Header.Set("ce-subject", e.Subject)
+		req.Header.Set("ce-time", e.Time.UTC().Format(time.RFC3339Nano))
+	} else {
+		body, err := e.structured(s.format)
+		if err != nil {
+			return err
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", cloudEventsJSONType+"; charset=utf-8")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
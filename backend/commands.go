@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// runCommand runs a one-off maintenance command instead of the servers,
+// e.g. "server migrate-departments -dry-run". Commands run against the
+// default tenant's database unless -tenant names another tenant.
+func runCommand(ctx context.Context, cfg Config, client *mongo.Client, args []string) error {
+	switch args[0] {
+	case "migrate-departments":
+		fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+		tenant := fs.String("tenant", defaultTenantID, "ID of the tenant whose database to use")
+		dryRun := fs.Bool("dry-run", false, "log the plan without writing anything")
+		foldPrefixes := fs.Bool("fold-prefixes", false, "merge departments into the one longer name they abbreviate")
+		aliases := aliasFlag{}
+		fs.Var(aliases, "alias", "extra spelling of a department, as Spelling=Department (repeatable)")
+		fs.Parse(args[1:])
+		db, err := tenantCommandDatabase(ctx, cfg, client, *tenant)
+		if err != nil {
+			return err
+		}
+
+		return migrateDepartments(ctx, newDatabaseServer(db), aliases, *foldPrefixes, *dryRun)
+	case "migrate-positions":
+		fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+		tenant := fs.String("tenant", defaultTenantID, "ID of the tenant whose database to use")
+		dryRun := fs.Bool("dry-run", false, "log the plan without writing anything")
+		jobFamily := fs.String("job-family", "Unassigned", "job family of the positions created for unmatched titles")
+		fs.Parse(args[1:])
+		db, err := tenantCommandDatabase(ctx, cfg, client, *tenant)
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(*jobFamily) == "" {
+			return fmt.Errorf("-job-family must not be empty")
+		}
+		return migratePositions(ctx, newDatabaseServer(db), strings.TrimSpace(*jobFamily), *dryRun)
+	case "verify-audit":
+		fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+		tenant := fs.String("tenant", defaultTenantID, "ID of the tenant whose database to use")
+		headSequence := fs.Int64("head-sequence", 0, "sequence of a head printed by an earlier run, which must still be in the log")
+		headHash := fs.String("head-hash", "", "hash of that head")
+		fs.Parse(args[1:])
+		db, err := tenantCommandDatabase(ctx, cfg, client, *tenant)
+		if err != nil {
+			return err
+		}
+
+		if (*headSequence > 0) != (*headHash != "") {
+			return fmt.Errorf("-head-sequence and -head-hash must be given together")
+		}
+		result, err := newAuditLog(db.Collection("audit_log"), cfg.AuditHMACKey).verify(ctx, *headSequence, *headHash)
+		if err != nil {
+			return err
+		}
+		if !result.GetValid() {
+			return fmt.Errorf("audit log is invalid at entry %d: %s", result.GetFirstInvalidSequence(), result.GetError())
+		}
+		log.Printf("Audit log is intact, %d entries checked, head %d %s", result.GetEntriesChecked(), result.GetHeadSequence(), result.GetHeadHash())
+		return nil
+	case "bench-suggest":
+		fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+		tenant := fs.String("tenant", defaultTenantID, "ID of the tenant whose database to use")
+		synthetic := fs.Int("synthetic", 0, "index this many made-up employees instead of the stored ones")
+		queries := fs.Int("queries", 20000, "prefixes to look up")
+		limit := fs.Int("limit", 10, "suggestions per prefix")
+		target := fs.Duration("p99", time.Millisecond, "fail if the 99th percentile latency is over this")
+		fs.Parse(args[1:])
+		db, err := tenantCommandDatabase(ctx, cfg, client, *tenant)
+		if err != nil {
+			return err
+		}
+
+		return benchSuggest(ctx, db.Collection("employees"), *synthetic, *queries, *limit, *target)
+	default:
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
+
+// tenantCommandDatabase is the database of the tenant a command runs on
+func tenantCommandDatabase(ctx context.Context, cfg Config, client *mongo.Client, id string) (*mongo.Database, error) {
+	registry := &tenantRegistry{cfg: cfg, client: client, tenantsCollection: client.Database(cfg.DatabaseName).Collection("tenants")}
+	t, err := registry.findTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("unknown tenant: %s", id)
+	}
+	log.Printf("Running on tenant %s, database %s", t.ID, t.Database)
+	return client.Database(t.Database), nil
+}
+
+// aliasFlag collects repeated -alias From=To flags
+type aliasFlag map[string]string
+
+func (a aliasFlag) String() string {
+	pairs := make([]string, 0, len(a))
+	for from, to := range a {
+		pairs = append(pairs, from+"="+to)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (a aliasFlag) Set(v string) error {
+	from, to, ok := strings.Cut(v, "=")
+	if !ok || from == "" || to == "" {
+		return fmt.Errorf("expected Spelling=Department, got %q", v)
+	}
+	a[from] = to
+	return nil
+}
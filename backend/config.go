@@ -0,0 +1,167 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the runtime settings read from the environment
+type Config struct {
+	MongoURI         string
+	DatabaseName     string
+	GRPCAddr         string
+	HTTPAddr         string
+	EnableReflection bool
+	EnableDocs       bool
+	// How often scheduled employee changes are checked for
+	SchedulerInterval time.Duration
+	// How often the outbox and pending webhook deliveries are checked for
+	WebhookInterval time.Duration
+	// Timeout of one webhook request
+	WebhookTimeout time.Duration
+	// Failed attempts before a delivery is dead-lettered
+	WebhookMaxAttempts int
+	// Internal receivers webhooks may reach: comma-separated host names, IP
+	// addresses and CIDR ranges. Other hosts must resolve to public
+	// addresses.
+	WebhookAllowedHosts string
+	// Where domain events are published: comma-separated file:<path> and
+	// http(s) URLs
+	EventSinks string
+	// json for structured CloudEvents, protobuf for binary event data
+	EventFormat string
+	// CloudEvents source attribute of published events
+	EventSource string
+	// Jobs run at the same time on this server
+	JobWorkers int
+	// How often idle workers look for jobs
+	JobInterval time.Duration
+	// How long a worker holds a job without renewing its lease
+	JobLease time.Duration
+	// Times a job is started before it fails
+	JobMaxAttempts int
+	// Secret the audit log's hash chain is keyed with. Required; entries
+	// written under another key fail verification.
+	AuditHMACKey string
+	// How long the search indexes wait before loading again when they cannot
+	// follow employee changes
+	SearchResyncInterval time.Duration
+	// Verifies the bearer tokens tenants are taken from: an HMAC secret or
+	// the path of an RSA or ECDSA public key in PEM. Without either, every
+	// request is served from the default tenant and tenants cannot be
+	// managed.
+	TenantJWTSecret    string
+	TenantJWTPublicKey string
+	// Without token keys, trust the X-Tenant-ID header and let anyone
+	// manage tenants. For development only.
+	TenantInsecureHeader bool
+	// Token claim holding the tenant ID
+	TenantClaim string
+	// Token claim that must be true to manage tenants
+	TenantAdminClaim string
+	// Reject requests that do not name a tenant instead of serving them
+	// from the default tenant. Needs token keys.
+	TenantRequired bool
+	// How often tenants created, changed or deleted on other servers are
+	// picked up
+	TenantRefreshInterval time.Duration
+}
+
+// LoadConfig reads the config from environment variables, falling back to
+// the defaults used by the K8s manifests.
+func LoadConfig() Config {
+	return Config{
+		MongoURI:         getEnv("MONGO_URI", "mongodb://mongo-service.employee-app.svc.cluster.local:27017"),
+		DatabaseName:     getEnv("MONGO_DATABASE", "employee_db"),
+		GRPCAddr:         getEnv("GRPC_ADDR", ":50051"),
+		HTTPAddr:         getEnv("HTTP_ADDR", ":8080"),
+		EnableReflection: getEnvBool("GRPC_REFLECTION", false),
+		EnableDocs:       getEnvBool("API_DOCS", true),
+
+		SchedulerInterval:   getEnvDuration("SCHEDULER_INTERVAL", time.Minute),
+		WebhookInterval:     getEnvDuration("WEBHOOK_INTERVAL", 5*time.Second),
+		WebhookTimeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookAllowedHosts: getEnv("WEBHOOK_ALLOWED_HOSTS", ""),
+		EventSinks:          getEnv("EVENT_SINKS", ""),
+		EventFormat:         getEnv("EVENT_FORMAT", eventFormatJSON),
+		EventSource:         getEnv("EVENT_SOURCE", "/employee-app"),
+		JobWorkers:          getEnvInt("JOB_WORKERS", 2),
+		JobInterval:         getEnvDuration("JOB_INTERVAL", 5*time.Second),
+		JobLease:            getEnvDuration("JOB_LEASE", 30*time.Second),
+		JobMaxAttempts:      getEnvInt("JOB_MAX_ATTEMPTS", 3),
+
+		AuditHMACKey: getEnv("AUDIT_HMAC_KEY", ""),
+
+		SearchResyncInterval: getEnvDuration("SEARCH_RESYNC_INTERVAL", 30*time.Second),
+
+		TenantJWTSecret:       getEnv("TENANT_JWT_SECRET", ""),
+		TenantJWTPublicKey:    getEnv("TENANT_JWT_PUBLIC_KEY", ""),
+		TenantInsecureHeader:  getEnvBool("TENANT_INSECURE_HEADER", false),
+		TenantClaim:           getEnv("TENANT_CLAIM", "tenant_id"),
+		TenantAdminClaim:      getEnv("TENANT_ADMIN_CLAIM", "tenant_admin"),
+		TenantRequired:        getEnvBool("TENANT_REQUIRED", false),
+		TenantRefreshInterval: getEnvDuration("TENANT_REFRESH_INTERVAL", 30*time.Second),
+	}
+}
+
+// Features lists the optional features switched on in this config
+func (c Config) Features() []string {
+	var features []string
+	if c.EnableReflection {
+		features = append(features, "grpc-reflection")
+	}
+	if c.EnableDocs {
+		features = append(features, "api-docs")
+	}
+	if c.TenantJWTSecret != "" || c.TenantJWTPublicKey != "" {
+		features = append(features, "tenant-tokens")
+	} else if c.TenantInsecureHeader {
+		features = append(features, "tenant-insecure-header")
+	}
+	return features
+}
+
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+func getEnvInt(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Custom field types as stored
+const (
+	customString  = "string"
+	customNumber  = "number"
+	customBoolean = "boolean"
+	customDate    = "date"
+	customEnum    = "enum"
+)
+
+var customTypeToProto = map[string]pb.CustomFieldType{
+	customString:  pb.CustomFieldType_FIELD_STRING,
+	customNumber:  pb.CustomFieldType_FIELD_NUMBER,
+	customBoolean: pb.CustomFieldType_FIELD_BOOLEAN,
+	customDate:    pb.CustomFieldType_FIELD_DATE,
+	customEnum:    pb.CustomFieldType_FIELD_ENUM,
+}
+
+var customTypeFromProto = map[pb.CustomFieldType]string{
+	pb.CustomFieldType_FIELD_STRING:  customString,
+	pb.CustomFieldType_FIELD_NUMBER:  customNumber,
+	pb.CustomFieldType_FIELD_BOOLEAN: customBoolean,
+	pb.CustomFieldType_FIELD_DATE:    customDate,
+	pb.CustomFieldType_FIELD_ENUM:    customEnum,
+}
+
+// Custom field names are keys of a subdocument and part of query field
+// names, so they stay clear of dots, dollars and colons
+var customFieldName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// MongoDB CustomField model
+type CustomField struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Label       string             `bson:"label"`
+	Type        string             `bson:"type"`
+	Required    bool               `bson:"required"`
+	EnumValues  []string           `bson:"enum_values,omitempty"`
+	Pattern     string             `bson:"pattern,omitempty"`
+	Description string             `bson:"description,omitempty"`
+}
+
+func (f CustomField) toProto() *pb.CustomField {
+	return &pb.CustomField{
+		Name:        f.Name,
+		Label:       f.Label,
+		Type:        customTypeToProto[f.Type],
+		Required:    f.Required,
+		EnumValues:  f.EnumValues,
+		Pattern:     f.Pattern,
+		Description: f.Description,
+	}
+}
+
+// customFieldFromProto validates req and converts it to the stored model
+func customFieldFromProto(req *pb.CustomField) (CustomField, error) {
+	field := CustomField{
+		Name:        strings.TrimSpace(req.GetName()),
+		Label:       strings.TrimSpace(req.GetLabel()),
+		Required:    req.GetRequired(),
+		Pattern:     req.GetPattern(),
+		Description: strings.TrimSpace(req.GetDescription()),
+	}
+	if !customFieldName.MatchString(field.Name) {
+		return field, status.Errorf(codes.InvalidArgument, "Custom field names are lower case letters, digits and underscores, starting with a letter")
+	}
+	if field.Label == "" {
+		field.Label = field.Name
+	}
+
+	var ok bool
+	field.Type, ok = customTypeFromProto[req.GetType()]
+	if !ok {
+		return field, status.Errorf(codes.InvalidArgument, "Custom field type is required")
+	}
+
+	seen := map[string]bool{}
+	for _, v := range req.GetEnumValues() {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		field.EnumValues = append(field.EnumValues, v)
+	}
+	if field.Type == customEnum && len(field.EnumValues) == 0 {
+		return field, status.Errorf(codes.InvalidArgument, "Enum fields need enum_values")
+	}
+	if field.Type != customEnum && len(field.EnumValues) > 0 {
+		return field, status.Errorf(codes.InvalidArgument, "Only enum fields take enum_values")
+	}
+
+	if field.Pattern != "" {
+		if field.Type != customString && field.Type != customEnum {
+			return field, status.Errorf(codes.InvalidArgument, "Only string and enum fields take a pattern")
+		}
+		if _, err := field.patternRegexp(); err != nil {
+			return field, status.Errorf(codes.InvalidArgument, "Invalid pattern: %v", err)
+		}
+	}
+	return field, nil
+}
+
+// patternRegexp compiles the pattern to match whole values
+func (f CustomField) patternRegexp() (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + f.Pattern + `)$`)
+}
+
+// queryKind is how queries treat values of the field
+func (f CustomField) queryKind() string {
+	switch f.Type {
+	case customNumber:
+		return fieldNumber
+	case customBoolean:
+		return fieldBoolean
+	case customDate:
+		return fieldDate
+	}
+	return fieldString
+}
+
+// value checks an API value against the definition and converts it for
+// storage. Numbers, booleans and dates may also be given as strings, as
+// they are in imports. It returns nil for null and empty values.
+func (f CustomField) value(v *structpb.Value) (interface{}, error) {
+	text, isText := v.GetKind().(*structpb.Value_StringValue)
+	if _, isNull := v.GetKind().(*structpb.Value_NullValue); v == nil || isNull || (isText && strings.TrimSpace(text.StringValue) == "") {
+		return nil, nil
+	}
+
+	switch f.Type {
+	case customNumber:
+		if n, ok := v.GetKind().(*structpb.Value_NumberValue); ok {
+			return n.NumberValue, nil
+		}
+		if isText {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(text.StringValue), 64); err == nil {
+				return n, nil
+			}
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "Custom field %s must be a number", f.Name)
+	case customBoolean:
+		if b, ok := v.GetKind().(*structpb.Value_BoolValue); ok {
+			return b.BoolValue, nil
+		}
+		if isText {
+			if b, err := strconv.ParseBool(strings.TrimSpace(text.StringValue)); err == nil {
+				return b, nil
+			}
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "Custom field %s must be true or false", f.Name)
+	case customDate:
+		if isText {
+			if t, err := parseImportDate(strings.TrimSpace(text.StringValue)); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "Custom field %s must be a date as YYYY-MM-DD or RFC 3339", f.Name)
+	}
+
+	if !isText {
+		return nil, status.Errorf(codes.InvalidArgument, "Custom field %s must be a string", f.Name)
+	}
+	s := text.StringValue
+	if f.Type == customEnum {
+		found := false
+		for _, allowed := range f.EnumValues {
+			found = found || s == allowed
+		}
+		if !found {
+			return nil, status.Errorf(codes.InvalidArgument, "Custom field %s must be one of %s", f.Name, strings.Join(f.EnumValues, ", "))
+		}
+	}
+	if f.Pattern != "" {
+		re, err := f.patternRegexp()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Invalid pattern for custom field %s: %v", f.Name, err)
+		}
+		if !re.MatchString(s) {
+			return nil, status.Errorf(codes.InvalidArgument, "Custom field %s does not match %s", f.Name, f.Pattern)
+		}
+	}
+	return s, nil
+}
+
+// customValues validates the custom field values of an employee against
+// the definitions. It returns nil if there are none.
+func customValues(defs []CustomField, values *structpb.Struct) (bson.M, error) {
+	byName := make(map[string]CustomField, len(defs))
+	for _, f := range defs {
+		byName[f.Name] = f
+	}
+
+	stored := bson.M{}
+	for name, v := range values.GetFields() {
+		f, ok := byName[name]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown custom field: %s", name)
+		}
+		value, err := f.value(v)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			stored[name] = value
+		}
+	}
+	for _, f := range defs {
+		if _, ok := stored[f.Name]; f.Required && !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Custom field %s is required", f.Name)
+		}
+	}
+
+	if len(stored) == 0 {
+		return nil, nil
+	}
+	return stored, nil
+}
+
+// customValuesToProto converts stored custom field values for the API
+func customValuesToProto(values bson.M) *structpb.Struct {
+	if len(values) == 0 {
+		return nil
+	}
+	fields := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(values))}
+	for name, v := range values {
+		if value := bsonToValue(v); value != nil {
+			fields.Fields[name] = value
+		}
+	}
+	return fields
+}
+
+// listCustomFields returns the custom field definitions by name
+func listCustomFields(ctx context.Context, coll *mongo.Collection) ([]CustomField, error) {
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve custom fields: %v", err)
+	}
+	var fields []CustomField
+	if err := cursor.All(ctx, &fields); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode custom field: %v", err)
+	}
+	return fields, nil
+}
+
+// loadQueryFields returns the fields queries can use: the built-in ones and
+// custom.<name> for each custom field
+func loadQueryFields(ctx context.Context, customFields *mongo.Collection) (queryFieldSet, error) {
+	defs, err := listCustomFields(ctx, customFields)
+	if err != nil {
+		return nil, err
+	}
+	fields := append(queryFieldSet{}, queryFields...)
+	for _, f := range defs {
+		fields = append(fields, queryField{name: "custom." + f.Name, kind: f.queryKind(), path: "custom_fields." + f.Name})
+	}
+	return fields, nil
+}
+
+// customFieldValues validates the custom field values of an employee write
+func (s *server) customFieldValues(ctx context.Context, values *structpb.Struct) (bson.M, error) {
+	defs, err := listCustomFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	return customValues(defs, values)
+}
+
+// customExportFields are the export columns of the custom fields, named
+// custom.<name> like in queries
+func customExportFields(defs []CustomField) []exportField {
+	fields := make([]exportField, 0, len(defs))
+	for _, f := range defs {
+		name := f.Name
+		if f.Type == customDate {
+			fields = append(fields, exportField{name: "custom." + name, timestamp: true, value: func(e *Employee) interface{} {
+				if t, ok := e.CustomFields[name].(primitive.DateTime); ok {
+					tt := t.Time()
+					return &tt
+				}
+				return (*time.Time)(nil)
+			}})
+			continue
+		}
+		fields = append(fields, exportField{name: "custom." + name, value: func(e *Employee) interface{} {
+			switch v := e.CustomFields[name].(type) {
+			case string:
+				return v
+			case float64:
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			case int32:
+				return strconv.FormatInt(int64(v), 10)
+			case int64:
+				return strconv.FormatInt(v, 10)
+			case bool:
+				return strconv.FormatBool(v)
+			}
+			return ""
+		}})
+	}
+	return fields
+}
+
+type customFieldServer struct {
+	pb.UnimplementedCustomFieldServiceServer
+	customFieldsCollection *mongo.Collection
+	employeesCollection    *mongo.Collection
+}
+
+func NewCustomFieldServer(customFields, employees *mongo.Collection) pb.CustomFieldServiceServer {
+	return &customFieldServer{customFieldsCollection: customFields, employeesCollection: employees}
+}
+
+// ListCustomFields
+func (s *customFieldServer) ListCustomFields(ctx context.Context, req *pb.Empty) (*pb.CustomFieldList, error) {
+	log.Println("ListCustomFields RPC called")
+
+	fields, err := listCustomFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	list := &pb.CustomFieldList{}
+	for _, f := range fields {
+		list.Fields = append(list.Fields, f.toProto())
+	}
+	return list, nil
+}
+
+// GetCustomField
+func (s *customFieldServer) GetCustomField(ctx context.Context, req *pb.CustomFieldName) (*pb.CustomField, error) {
+	log.Println("GetCustomField RPC called")
+
+	var field CustomField
+	err := s.customFieldsCollection.FindOne(ctx, bson.M{"name": req.GetName()}).Decode(&field)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Custom field not found: %s", req.GetName())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve custom field: %v", err)
+	}
+	return field.toProto(), nil
+}
+
+// CreateCustomField
+func (s *customFieldServer) CreateCustomField(ctx context.Context, req *pb.CustomField) (*pb.CustomField, error) {
+	log.Println("CreateCustomField RPC called")
+
+	field, err := customFieldFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A required field would make every existing employee invalid
+	if field.Required {
+		count, err := s.employeesCollection.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+		}
+		if count > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Create %s as optional, fill it in for the existing employees, then make it required", field.Name)
+		}
+	}
+
+	res, err := s.customFieldsCollection.InsertOne(ctx, field)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Custom field %s already exists", field.Name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create custom field: %v", err)
+	}
+
+	field.ID = res.InsertedID.(primitive.ObjectID)
+	return field.toProto(), nil
+}
+
+// UpdateCustomField
+func (s *customFieldServer) UpdateCustomField(ctx context.Context, req *pb.CustomField) (*pb.CustomField, error) {
+	log.Println("UpdateCustomField RPC called")
+
+	field, err := customFieldFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing CustomField
+	err = s.customFieldsCollection.FindOne(ctx, bson.M{"name": field.Name}).Decode(&existing)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Custom field not found: %s", field.Name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve custom field: %v", err)
+	}
+	if field.Type != existing.Type {
+		return nil, status.Errorf(codes.FailedPrecondition, "The type of custom field %s cannot be changed, create a new field instead", field.Name)
+	}
+
+	if field.Required && !existing.Required {
+		missing, err := s.employeesCollection.CountDocuments(ctx, bson.M{"custom_fields." + field.Name: bson.M{"$exists": false}})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to check custom field values: %v", err)
+		}
+		if missing > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "%d employees have no %s yet", missing, field.Name)
+		}
+	}
+
+	field.ID = existing.ID
+	if _, err := s.customFieldsCollection.ReplaceOne(ctx, bson.M{"_id": existing.ID}, field); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update custom field: %v", err)
+	}
+	return field.toProto(), nil
+}
+
+// DeleteCustomField
+func (s *customFieldServer) DeleteCustomField(ctx context.Context, req *pb.CustomFieldName) (*pb.Empty, error) {
+	log.Println("DeleteCustomField RPC called")
+
+	inUse, err := s.employeesCollection.CountDocuments(ctx, bson.M{"custom_fields." + req.GetName(): bson.M{"$exists": true}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check custom field values: %v", err)
+	}
+	if inUse > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%d employees still have a value for %s", inUse, req.GetName())
+	}
+
+	res, err := s.customFieldsCollection.DeleteOne(ctx, bson.M{"name": req.GetName()})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete custom field: %v", err)
+	}
+	if res.DeletedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Custom field not found: %s", req.GetName())
+	}
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MongoDB Department model
+type Department struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Code       string             `bson:"code"`
+	Name       string             `bson:"name"`
+	ParentID   primitive.ObjectID `bson:"parent_id,omitempty"`
+	HeadID     primitive.ObjectID `bson:"head_id,omitempty"`
+	CostCenter string             `bson:"cost_center"`
+	ExternalID string             `bson:"external_id,omitempty"`
+}
+
+func (d Department) toProto() *pb.Department {
+	dept := &pb.Department{
+		Id:         d.ID.Hex(),
+		Code:       d.Code,
+		Name:       d.Name,
+		CostCenter: d.CostCenter,
+		ExternalId: d.ExternalID,
+	}
+	if !d.ParentID.IsZero() {
+		dept.ParentId = d.ParentID.Hex()
+	}
+	if !d.HeadID.IsZero() {
+		dept.HeadId = d.HeadID.Hex()
+	}
+	return dept
+}
+
+type departmentServer struct {
+	pb.UnimplementedDepartmentServiceServer
+	departmentsCollection *mongo.Collection
+	// Renames reach employees through the employee server, so they are
+	// versioned and published like any other change
+	employees *server
+}
+
+func NewDepartmentServer(departments *mongo.Collection, employees *server) pb.DepartmentServiceServer {
+	return &departmentServer{departmentsCollection: departments, employees: employees}
+}
+
+// ListDepartments
+func (s *departmentServer) ListDepartments(ctx context.Context, req *pb.Empty) (*pb.DepartmentList, error) {
+	log.Println("ListDepartments RPC called")
+
+	cursor, err := s.departmentsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve departments: %v", err)
+	}
+
+	var departments []Department
+	if err := cursor.All(ctx, &departments); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode department: %v", err)
+	}
+
+	list := &pb.DepartmentList{}
+	for _, d := range departments {
+		list.Departments = append(list.Departments, d.toProto())
+	}
+	return list, nil
+}
+
+// GetDepartment
+func (s *departmentServer) GetDepartment(ctx context.Context, req *pb.DepartmentID) (*pb.Department, error) {
+	log.Println("GetDepartment RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	var dept Department
+	err = s.departmentsCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&dept)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Department not found with ID: %s", req.GetId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve department: %v", err)
+	}
+
+	return dept.toProto(), nil
+}
+
+// CreateDepartment
+func (s *departmentServer) CreateDepartment(ctx context.Context, req *pb.Department) (*pb.Department, error) {
+	log.Println("CreateDepartment RPC called")
+
+	dept, err := s.departmentFromProto(ctx, primitive.NilObjectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.departmentsCollection.InsertOne(ctx, dept)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Department code already in use: %s", dept.Code)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create department: %v", err)
+	}
+
+	dept.ID = res.InsertedID.(primitive.ObjectID)
+	return dept.toProto(), nil
+}
+
+// UpdateDepartment
+func (s *departmentServer) UpdateDepartment(ctx context.Context, req *pb.Department) (*pb.Department, error) {
+	log.Println("UpdateDepartment RPC called")
+	return inTransaction(ctx, s.employees.mongoClient(), func(ctx context.Context) (*pb.Department, error) {
+		return s.updateDepartment(ctx, req)
+	})
+}
+
+func (s *departmentServer) updateDepartment(ctx context.Context, req *pb.Department) (*pb.Department, error) {
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	dept, err := s.departmentFromProto(ctx, oid, req)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": dept}
+	unset := bson.M{}
+	if dept.ParentID.IsZero() {
+		unset["parent_id"] = ""
+	}
+	if dept.HeadID.IsZero() {
+		unset["head_id"] = ""
+	}
+	if dept.ExternalID == "" {
+		unset["external_id"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	res, err := s.departmentsCollection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Department code already in use: %s", dept.Code)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update department: %v", err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Department not found with ID: %s", req.GetId())
+	}
+
+	// Keep the denormalized department name on employees in sync
+	if _, err := s.employees.setEmployeeFields(ctx, bson.M{"department_id": oid}, bson.M{"department": dept.Name}); err != nil {
+		return nil, err
+	}
+
+	dept.ID = oid
+	return dept.toProto(), nil
+}
+
+// DeleteDepartment
+func (s *departmentServer) DeleteDepartment(ctx context.Context, req *pb.DepartmentID) (*pb.Empty, error) {
+	log.Println("DeleteDepartment RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	members, err := s.employees.employeesCollection.CountDocuments(ctx, bson.M{"department_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check department members: %v", err)
+	}
+	if members > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Department %s still has %d employees", req.GetId(), members)
+	}
+
+	children, err := s.departmentsCollection.CountDocuments(ctx, bson.M{"parent_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check sub-departments: %v", err)
+	}
+	if children > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Department %s still has %d sub-departments", req.GetId(), children)
+	}
+
+	res, err := s.departmentsCollection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete department: %v", err)
+	}
+	if res.DeletedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Department not found with ID: %s", req.GetId())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// departmentFromProto validates req and converts it to the stored model.
+// id is NilObjectID for departments that do not exist yet.
+func (s *departmentServer) departmentFromProto(ctx context.Context, id primitive.ObjectID, req *pb.Department) (Department, error) {
+	dept := Department{
+		Code:       strings.ToUpper(strings.TrimSpace(req.GetCode())),
+		Name:       strings.TrimSpace(req.GetName()),
+		CostCenter: strings.TrimSpace(req.GetCostCenter()),
+		ExternalID: strings.TrimSpace(req.GetExternalId()),
+	}
+	if dept.Code == "" {
+		return dept, status.Errorf(codes.InvalidArgument, "Department code is required")
+	}
+	if dept.Name == "" {
+		return dept, status.Errorf(codes.InvalidArgument, "Department name is required")
+	}
+
+	if req.GetParentId() != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.GetParentId())
+		if err != nil {
+			return dept, status.Errorf(codes.InvalidArgument, "Invalid parent ID format: %v", err)
+		}
+		if err := s.checkParent(ctx, id, parentID); err != nil {
+			return dept, err
+		}
+		dept.ParentID = parentID
+	}
+
+	if req.GetHeadId() != "" {
+		headID, err := primitive.ObjectIDFromHex(req.GetHeadId())
+		if err != nil {
+			return dept, status.Errorf(codes.InvalidArgument, "Invalid head ID format: %v", err)
+		}
+		n, err := s.employees.employeesCollection.CountDocuments(ctx, bson.M{"_id": headID})
+		if err != nil {
+			return dept, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+		}
+		if n == 0 {
+			return dept, status.Errorf(codes.FailedPrecondition, "Department head not found with ID: %s", req.GetHeadId())
+		}
+		dept.HeadID = headID
+	}
+
+	return dept, nil
+}
+
+// checkParent walks up from parentID to make sure it exists and that id is
+// not one of its ancestors.
+func (s *departmentServer) checkParent(ctx context.Context, id, parentID primitive.ObjectID) error {
+	seen := map[primitive.ObjectID]bool{}
+	for cur := parentID; !cur.IsZero(); {
+		if cur == id {
+			return status.Errorf(codes.FailedPrecondition, "Parent department %s would create a cycle", parentID.Hex())
+		}
+		if seen[cur] {
+			break
+		}
+		seen[cur] = true
+
+		var d Department
+		err := s.departmentsCollection.FindOne(ctx, bson.M{"_id": cur}).Decode(&d)
+		if err == mongo.ErrNoDocuments {
+			return status.Errorf(codes.FailedPrecondition, "Parent department not found with ID: %s", cur.Hex())
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "Failed to retrieve department: %v", err)
+		}
+		cur = d.ParentID
+	}
+	return nil
+}
+
+// findDepartment looks a department up by ID, code or case-insensitive name
+func findDepartment(ctx context.Context, departments *mongo.Collection, ref string) (*Department, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"code": strings.ToUpper(ref)},
+		bson.M{"name": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(ref) + "$", Options: "i"}},
+	}}
+	if oid, err := primitive.ObjectIDFromHex(ref); err == nil {
+		filter = bson.M{"_id": oid}
+	}
+
+	var dept Department
+	err := departments.FindOne(ctx, filter).Decode(&dept)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve department: %v", err)
+	}
+	return &dept, nil
+}
+
+// resolveDepartment validates the department of an employee write. The
+// department may be given by ID or, for older clients, by code or name.
+func (s *server) resolveDepartment(ctx context.Context, req *pb.Employee) (primitive.ObjectID, string, error) {
+	if req.GetDepartmentId() != "" {
+		if _, err := primitive.ObjectIDFromHex(req.GetDepartmentId()); err != nil {
+			return primitive.NilObjectID, "", status.Errorf(codes.InvalidArgument, "Invalid department ID format: %v", err)
+		}
+		dept, err := findDepartment(ctx, s.departmentsCollection, req.GetDepartmentId())
+		if err != nil {
+			return primitive.NilObjectID, "", err
+		}
+		if dept == nil {
+			return primitive.NilObjectID, "", status.Errorf(codes.FailedPrecondition, "Department not found with ID: %s", req.GetDepartmentId())
+		}
+		return dept.ID, dept.Name, nil
+	}
+
+	name := strings.TrimSpace(req.GetDepartment())
+	if name == "" {
+		return primitive.NilObjectID, "", nil
+	}
+	dept, err := findDepartment(ctx, s.departmentsCollection, name)
+	if err != nil {
+		return primitive.NilObjectID, "", err
+	}
+	if dept == nil {
+		return primitive.NilObjectID, "", status.Errorf(codes.InvalidArgument, "Unknown department: %s", name)
+	}
+	return dept.ID, dept.Name, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testDatabase returns an empty database with the indexes of a tenant,
+// dropped when the test ends. It needs a MongoDB replica set in
+// MONGO_TEST_URI, such as the one in docker-compose.yml with
+// mongodb://localhost:27017/?directConnection=true; without it the test is
+// skipped.
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	db := client.Database(fmt.Sprintf("employee_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+
+	err = ensureIndexes(ctx, db.Collection("employees"), db.Collection("departments"), db.Collection("positions"),
+		db.Collection("employee_versions"), db.Collection("employee_revisions"), db.Collection("audit_log"),
+		db.Collection("outbox"), db.Collection("webhook_deliveries"), db.Collection("operations"), db.Collection("custom_fields"))
+	if err != nil {
+		t.Fatalf("Failed to create indexes: %v", err)
+	}
+	return db
+}
+
+func TestRenameSurvivesLaterChanges(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	employees := newDatabaseServer(db)
+	departments := NewDepartmentServer(db.Collection("departments"), employees)
+	positions := NewPositionServer(db.Collection("positions"), employees)
+
+	dept, err := departments.CreateDepartment(ctx, &pb.Department{Code: "ENG", Name: "Engineering"})
+	if err != nil {
+		t.Fatalf("CreateDepartment: %v", err)
+	}
+	pos, err := positions.CreatePosition(ctx, &pb.Position{Title: "Engineer", JobFamily: "Engineering", Level: 2})
+	if err != nil {
+		t.Fatalf("CreatePosition: %v", err)
+	}
+	emp, err := employees.CreateEmployee(ctx, &pb.Employee{
+		FirstName:    "Ada",
+		LastName:     "Byron",
+		Email:        "ada@example.com",
+		DepartmentId: dept.GetId(),
+		PositionId:   pos.GetId(),
+	})
+	if err != nil {
+		t.Fatalf("CreateEmployee: %v", err)
+	}
+
+	dept.Name = "Research & Development"
+	if _, err := departments.UpdateDepartment(ctx, dept); err != nil {
+		t.Fatalf("UpdateDepartment: %v", err)
+	}
+	pos.Title = "Software Engineer"
+	if _, err := positions.UpdatePosition(ctx, pos); err != nil {
+		t.Fatalf("UpdatePosition: %v", err)
+	}
+
+	// A change scheduled for tomorrow splits the current version and copies
+	// it back onto the employee
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	_, err = employees.UpdateEmployee(ctx, &pb.Employee{
+		Id:            emp.GetId(),
+		FirstName:     "Ada",
+		LastName:      "Lovelace",
+		Email:         "ada@example.com",
+		DepartmentId:  dept.GetId(),
+		PositionId:    pos.GetId(),
+		EffectiveDate: timestamppb.New(tomorrow),
+	})
+	if err != nil {
+		t.Fatalf("UpdateEmployee: %v", err)
+	}
+	if err := employees.applyDueVersions(ctx); err != nil {
+		t.Fatalf("applyDueVersions: %v", err)
+	}
+
+	for _, asOf := range []*timestamppb.Timestamp{nil, timestamppb.Now(), timestamppb.New(tomorrow.Add(time.Hour))} {
+		got, err := employees.GetEmployee(ctx, &pb.GetEmployeeRequest{Id: emp.GetId(), AsOf: asOf})
+		if err != nil {
+			t.Fatalf("GetEmployee as of %v: %v", asOf.AsTime(), err)
+		}
+		if got.GetDepartment() != dept.Name || got.GetPosition() != pos.Title {
+			t.Errorf("as of %v the employee is in %q as %q, want %q as %q", asOf.AsTime(), got.GetDepartment(), got.GetPosition(), dept.Name, pos.Title)
+		}
+	}
+
+	revisions, err := employees.ListEmployeeRevisions(ctx, &pb.ListEmployeeRevisionsRequest{EmployeeId: emp.GetId()})
+	if err != nil {
+		t.Fatalf("ListEmployeeRevisions: %v", err)
+	}
+	renamed := map[string]bool{}
+	for _, rev := range revisions.GetRevisions() {
+		for _, c := range rev.GetChanges() {
+			renamed[c.GetField()+"="+c.GetAfter().GetStringValue()] = true
+		}
+	}
+	if !renamed["department="+dept.Name] || !renamed["position="+pos.Title] {
+		t.Errorf("the renames were not recorded as revisions: %v", renamed)
+	}
+
+	id, _ := primitive.ObjectIDFromHex(emp.GetId())
+	events, err := db.Collection("outbox").CountDocuments(ctx, bson.M{"subject": id})
+	if err != nil {
+		t.Fatalf("Failed to count events: %v", err)
+	}
+	// Created, two renames and the scheduled change
+	if events != 4 {
+		t.Errorf("%d events were queued for the employee, want 4", events)
+	}
+}
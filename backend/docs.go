@@ -0,0 +1,50 @@
+package main
+
+import (
+	_ "embed"
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// OpenAPI document generated from employee.proto (see gen.go)
+//
+//go:embed openapi/employee.swagger.json
+var openAPISpec []byte
+
+// swaggerInitializer points the bundled Swagger UI at our own spec instead
+// of the petstore example shipped with the dist files.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// openAPIHandler serves the embedded OpenAPI document
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+// swaggerUIHandler serves the embedded Swagger UI under /docs/
+func swaggerUIHandler() http.Handler {
+	files := http.StripPrefix("/docs/", http.FileServer(http.FS(swaggerFiles.FS)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+		case "/docs/swagger-initializer.js":
+			w.Header().Set("Content-Type", "application/javascript")
+			w.Write([]byte(swaggerInitializer))
+		default:
+			files.ServeHTTP(w, r)
+		}
+	})
+}
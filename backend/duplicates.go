@@ -0,0 +1,625 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Weights of the duplicate score parts; they add up to 1
+const (
+	duplicateNameWeight       = 0.55
+	duplicateEmailWeight      = 0.3
+	duplicateDepartmentWeight = 0.15
+)
+
+// maxDuplicateBlock skips blocking keys shared by more employees than this,
+// which say too little to be worth comparing every pair
+const maxDuplicateBlock = 1000
+
+// nicknameGroups are first names that can stand for one another. A name can
+// be in several groups, like chris.
+var nicknameGroups = [][]string{
+	{"william", "will", "bill", "billy", "willy", "liam"},
+	{"robert", "rob", "robbie", "bob", "bobby", "bert"},
+	{"james", "jim", "jimmy", "jamie"},
+	{"john", "jon", "johnny", "jack"},
+	{"jonathan", "jon", "jonny"},
+	{"michael", "mike", "mikey", "mick"},
+	{"david", "dave", "davy"},
+	{"richard", "rich", "rick", "ricky", "dick"},
+	{"thomas", "tom", "tommy"},
+	{"joseph", "joe", "joey"},
+	{"christopher", "chris", "kit"},
+	{"christine", "christina", "chris", "tina"},
+	{"charles", "charlie", "chuck"},
+	{"daniel", "dan", "danny"},
+	{"matthew", "matt"},
+	{"anthony", "tony"},
+	{"edward", "ed", "eddie", "ted"},
+	{"alexander", "alex", "sasha"},
+	{"alexandra", "alex", "alexa", "sasha"},
+	{"benjamin", "ben", "benny"},
+	{"samuel", "sam", "sammy"},
+	{"samantha", "sam", "sammy"},
+	{"nicholas", "nick", "nicky"},
+	{"patrick", "pat", "paddy"},
+	{"patricia", "pat", "patty", "trish"},
+	{"steven", "stephen", "steve"},
+	{"andrew", "andy", "drew"},
+	{"gregory", "greg"},
+	{"timothy", "tim"},
+	{"kenneth", "ken", "kenny"},
+	{"joshua", "josh"},
+	{"elizabeth", "liz", "lizzie", "beth", "betty", "eliza"},
+	{"margaret", "maggie", "meg", "peggy"},
+	{"katherine", "catherine", "kate", "katie", "kathy", "cathy"},
+	{"jennifer", "jen", "jenny"},
+	{"susan", "sue", "suzy"},
+	{"deborah", "debbie", "deb"},
+	{"rebecca", "becky"},
+}
+
+// nicknames maps a first name to the nickname groups it is in
+var nicknames = func() map[string][]int {
+	m := make(map[string][]int)
+	for i, group := range nicknameGroups {
+		for _, name := range group {
+			m[name] = append(m[name], i)
+		}
+	}
+	return m
+}()
+
+// sameNickname tells whether two folded first names can be the same person's
+func sameNickname(a, b string) bool {
+	for _, i := range nicknames[a] {
+		for _, j := range nicknames[b] {
+			if i == j {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// foldName folds a name into its words run together, so "Van der Berg" and
+// "vanderberg" compare equal
+func foldName(name string) string {
+	return strings.ReplaceAll(suggestTerms(name), " ", "")
+}
+
+// normalizeEmail lowercases an email and drops a +tag from its local part.
+// It returns the whole address and the local part.
+func normalizeEmail(email string) (string, string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email, email
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + domain, local
+}
+
+// duplicateKey is what the scoring needs of an employee, computed once
+type duplicateKey struct {
+	emp        *Employee
+	first      string
+	last       string
+	email      string
+	local      string
+	department string
+}
+
+func newDuplicateKey(emp *Employee) *duplicateKey {
+	k := &duplicateKey{
+		emp:        emp,
+		first:      foldName(emp.FirstName),
+		last:       foldName(emp.LastName),
+		department: emp.DepartmentID.Hex(),
+	}
+	k.email, k.local = normalizeEmail(emp.Email)
+	if emp.DepartmentID.IsZero() {
+		k.department = suggestTerms(emp.Department)
+	}
+	return k
+}
+
+// blocks lists the keys under which the employee is compared with others.
+// Only employees sharing a block are scored, instead of every pair.
+func (k *duplicateKey) blocks() []string {
+	var blocks []string
+	if k.local != "" {
+		blocks = append(blocks, "email:"+k.email, "local:"+k.local)
+	}
+	if k.last != "" {
+		// Typos are most often past the first letters
+		blocks = append(blocks, "last:"+prefixRunes(k.last, 4))
+	}
+	if k.first != "" && k.last != "" {
+		// The same whichever way round the names were entered
+		names := []string{prefixRunes(k.first, 4), prefixRunes(k.last, 4)}
+		sort.Strings(names)
+		blocks = append(blocks, "names:"+names[0]+":"+names[1])
+
+		initial := prefixRunes(k.last, 1)
+		blocks = append(blocks, "first:"+k.first+":"+initial)
+		for _, i := range nicknames[k.first] {
+			blocks = append(blocks, "nick:"+nicknameGroups[i][0]+":"+initial)
+		}
+	}
+	return blocks
+}
+
+// prefixRunes returns the first n letters of s
+func prefixRunes(s string, n int) string {
+	r := []rune(s)
+	return string(r[:min(n, len(r))])
+}
+
+// firstNameSimilarity scores two folded first names from 0 to 1
+func firstNameSimilarity(a, b string) (float64, string) {
+	switch {
+	case a == "" || b == "":
+		return 0, ""
+	case a == b:
+		return 1, "same first name"
+	case sameNickname(a, b):
+		return 0.95, "nickname"
+	case len([]rune(a)) == 1 && strings.HasPrefix(b, a), len([]rune(b)) == 1 && strings.HasPrefix(a, b):
+		return 0.85, "initial"
+	}
+	return jaroWinkler(a, b), ""
+}
+
+// scoreDuplicate scores how likely two employees are the same person, from
+// 0 to 1, with the reasons for it
+func scoreDuplicate(a, b *duplicateKey) (float64, []string) {
+	var reasons []string
+
+	first, why := firstNameSimilarity(a.first, b.first)
+	last := 0.0
+	if a.last != "" && b.last != "" {
+		last = jaroWinkler(a.last, b.last)
+	}
+	name := (first + last) / 2
+	// First and last name entered the wrong way round
+	swappedFirst, _ := firstNameSimilarity(a.first, b.last)
+	swappedLast, _ := firstNameSimilarity(a.last, b.first)
+	if swapped := 0.9 * (swappedFirst + swappedLast) / 2; swapped > name && swapped >= 0.8 {
+		name = swapped
+		reasons = append(reasons, "first and last name swapped")
+	} else {
+		if why == "nickname" || why == "initial" {
+			reasons = append(reasons, why)
+		}
+		switch {
+		case name == 1:
+			reasons = append(reasons, "same name")
+		case name >= 0.85:
+			reasons = append(reasons, "similar name")
+		}
+	}
+
+	email := 0.0
+	switch {
+	case a.local == "" || b.local == "":
+	case a.email == b.email && strings.TrimSpace(a.emp.Email) == strings.TrimSpace(b.emp.Email):
+		email = 1
+		reasons = append(reasons, "same email")
+	case a.email == b.email:
+		email = 1
+		reasons = append(reasons, "same email after normalization")
+	case a.local == b.local:
+		email = 0.6
+		reasons = append(reasons, "same email local part")
+	}
+
+	department := 0.0
+	if a.department != "" && a.department == b.department {
+		department = 1
+		reasons = append(reasons, "same department")
+	}
+
+	score := duplicateNameWeight*name + duplicateEmailWeight*email + duplicateDepartmentWeight*department
+	return math.Round(score*1000) / 1000, reasons
+}
+
+// jaroWinkler is the Jaro-Winkler similarity of two words, from 0 to 1. It
+// favours words that start the same, as names with typos usually do.
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(0, max(len(ra), len(rb))/2-1)
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// FindDuplicateEmployees
+func (s *server) FindDuplicateEmployees(ctx context.Context, req *pb.FindDuplicateEmployeesRequest) (*pb.DuplicateCandidateList, error) {
+	log.Println("FindDuplicateEmployees RPC called")
+
+	minScore := req.GetMinScore()
+	if minScore < 0 || minScore > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "min_score must be between 0 and 1")
+	}
+	if minScore == 0 {
+		minScore = 0.6
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	var only primitive.ObjectID
+	if req.GetEmployeeId() != "" {
+		var err error
+		if only, err = s.requireEmployee(ctx, req.GetEmployeeId()); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := notTerminated
+	if req.GetIncludeTerminated() {
+		filter = bson.M{}
+	}
+	cursor, err := s.employeesCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	var emps []*Employee
+	if err := cursor.All(ctx, &emps); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	return &pb.DuplicateCandidateList{Candidates: findDuplicates(emps, only, minScore, limit)}, nil
+}
+
+// findDuplicates scores the employees sharing a block and returns the pairs
+// scoring at least minScore, best first. With only set, just the pairs that
+// employee is in.
+func findDuplicates(emps []*Employee, only primitive.ObjectID, minScore float64, limit int) []*pb.DuplicateCandidate {
+	blocks := make(map[string][]*duplicateKey)
+	for _, emp := range emps {
+		k := newDuplicateKey(emp)
+		for _, block := range k.blocks() {
+			blocks[block] = append(blocks[block], k)
+		}
+	}
+
+	seen := make(map[[2]primitive.ObjectID]bool)
+	var candidates []*pb.DuplicateCandidate
+	for block, keys := range blocks {
+		if len(keys) > maxDuplicateBlock {
+			log.Printf("Skipping duplicate block %s of %d employees", block, len(keys))
+			continue
+		}
+		for i, a := range keys {
+			for _, b := range keys[i+1:] {
+				if !only.IsZero() && a.emp.ID != only && b.emp.ID != only {
+					continue
+				}
+				pair := [2]primitive.ObjectID{a.emp.ID, b.emp.ID}
+				if a.emp.ID.Hex() > b.emp.ID.Hex() {
+					a, b = b, a
+					pair = [2]primitive.ObjectID{a.emp.ID, b.emp.ID}
+				}
+				if a.emp.ID == b.emp.ID || seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				score, reasons := scoreDuplicate(a, b)
+				if score < minScore {
+					continue
+				}
+				candidates = append(candidates, &pb.DuplicateCandidate{
+					First:   a.emp.toProto(),
+					Second:  b.emp.toProto(),
+					Score:   score,
+					Reasons: reasons,
+				})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.First.Id != b.First.Id {
+			return a.First.Id < b.First.Id
+		}
+		return a.Second.Id < b.Second.Id
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// RevisionMerge is the merge a revision was written for
+type RevisionMerge struct {
+	SurvivorID  primitive.ObjectID `bson:"survivor_id"`
+	DuplicateID primitive.ObjectID `bson:"duplicate_id"`
+}
+
+type mergeContextKey struct{}
+
+// withMerge marks the revisions recorded with ctx as part of a merge
+func withMerge(ctx context.Context, merge *RevisionMerge) context.Context {
+	return context.WithValue(ctx, mergeContextKey{}, merge)
+}
+
+// mergeFromContext returns the merge ctx was marked with, or nil
+func mergeFromContext(ctx context.Context) *RevisionMerge {
+	merge, _ := ctx.Value(mergeContextKey{}).(*RevisionMerge)
+	return merge
+}
+
+// mergeField is an employee field a merge resolves. Department and position
+// carry their name along with their ID.
+type mergeField struct {
+	name string
+	get  func(e *pb.Employee) string
+	set  func(to, from *pb.Employee)
+}
+
+var mergeFields = []mergeField{
+	{
+		name: "first_name",
+		get:  func(e *pb.Employee) string { return e.FirstName },
+		set:  func(to, from *pb.Employee) { to.FirstName = from.FirstName },
+	},
+	{
+		name: "last_name",
+		get:  func(e *pb.Employee) string { return e.LastName },
+		set:  func(to, from *pb.Employee) { to.LastName = from.LastName },
+	},
+	{
+		name: "email",
+		get:  func(e *pb.Employee) string { return e.Email },
+		set:  func(to, from *pb.Employee) { to.Email = from.Email },
+	},
+	{
+		name: "department",
+		get:  func(e *pb.Employee) string { return e.DepartmentId + e.Department },
+		set:  func(to, from *pb.Employee) { to.DepartmentId, to.Department = from.DepartmentId, from.Department },
+	},
+	{
+		name: "position",
+		get:  func(e *pb.Employee) string { return e.PositionId + e.Position },
+		set:  func(to, from *pb.Employee) { to.PositionId, to.Position = from.PositionId, from.Position },
+	},
+	{
+		name: "manager_id",
+		get:  func(e *pb.Employee) string { return e.ManagerId },
+		set:  func(to, from *pb.Employee) { to.ManagerId = from.ManagerId },
+	},
+}
+
+// MergeEmployees
+func (s *server) MergeEmployees(ctx context.Context, req *pb.MergeEmployeesRequest) (*pb.MergeEmployeesResponse, error) {
+	log.Println("MergeEmployees RPC called")
+
+	survivorID, err := primitive.ObjectIDFromHex(req.GetSurvivorId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid survivor ID format: %v", err)
+	}
+	duplicateID, err := primitive.ObjectIDFromHex(req.GetDuplicateId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid duplicate ID format: %v", err)
+	}
+	if survivorID == duplicateID {
+		return nil, status.Errorf(codes.InvalidArgument, "An employee cannot be merged with itself")
+	}
+	for name := range req.GetFieldPolicies() {
+		known := false
+		for _, f := range mergeFields {
+			known = known || f.name == name
+		}
+		if !known {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown merge field: %s", name)
+		}
+	}
+
+	ctx = withMerge(ctx, &RevisionMerge{SurvivorID: survivorID, DuplicateID: duplicateID})
+	if req.GetValidateOnly() {
+		var resp *pb.MergeEmployeesResponse
+		err := s.inAbortedTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			resp, err = s.mergeEmployees(ctx, req, survivorID, duplicateID)
+			return err
+		})
+		return resp, err
+	}
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.MergeEmployeesResponse, error) {
+		return s.mergeEmployees(ctx, req, survivorID, duplicateID)
+	})
+}
+
+func (s *server) mergeEmployees(ctx context.Context, req *pb.MergeEmployeesRequest, survivorID, duplicateID primitive.ObjectID) (*pb.MergeEmployeesResponse, error) {
+	var survivor, duplicate Employee
+	for _, e := range []struct {
+		id  primitive.ObjectID
+		emp *Employee
+	}{{survivorID, &survivor}, {duplicateID, &duplicate}} {
+		err := s.employeesCollection.FindOne(ctx, bson.M{"_id": e.id}).Decode(e.emp)
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", e.id.Hex())
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+		}
+	}
+
+	// The record changed last, for MERGE_PREFER_NEWER
+	survivorChanged, err := s.lastChanged(ctx, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateChanged, err := s.lastChanged(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateNewer := duplicateChanged.After(survivorChanged)
+
+	resp := &pb.MergeEmployeesResponse{}
+	merged, other := survivor.toProto(), duplicate.toProto()
+	for _, f := range mergeFields {
+		policy, ok := req.GetFieldPolicies()[f.name]
+		if !ok {
+			policy = req.GetDefaultPolicy()
+		}
+
+		fromDuplicate := false
+		switch policy {
+		case pb.MergePolicy_MERGE_KEEP_SURVIVOR:
+		case pb.MergePolicy_MERGE_KEEP_DUPLICATE:
+			fromDuplicate = true
+		case pb.MergePolicy_MERGE_PREFER_NEWER:
+			fromDuplicate = duplicateNewer && f.get(other) != "" || f.get(merged) == ""
+		default:
+			fromDuplicate = f.get(merged) == ""
+		}
+		if fromDuplicate && f.get(other) != f.get(merged) {
+			f.set(merged, other)
+			resp.FieldsFromDuplicate = append(resp.FieldsFromDuplicate, f.name)
+		}
+	}
+
+	// Neither record can end up managing the survivor
+	pair := map[string]bool{survivorID.Hex(): true, duplicateID.Hex(): true}
+	for _, m := range []string{merged.ManagerId, survivor.toProto().ManagerId, other.ManagerId, ""} {
+		if !pair[m] {
+			merged.ManagerId = m
+			break
+		}
+	}
+
+	// The other reports of the duplicate move to the survivor
+	cursor, err := s.employeesCollection.Find(ctx, bson.M{"manager_id": duplicateID, "_id": bson.M{"$ne": survivorID}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check direct reports: %v", err)
+	}
+	var reports []Employee
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	merged.EffectiveDate = nil
+	updated, err := s.updateEmployee(ctx, merged, actionMerge)
+	if err != nil {
+		return nil, err
+	}
+	updated.EffectiveDate = nil
+	resp.Employee = updated
+
+	for _, r := range reports {
+		report := r.toProto()
+		report.ManagerId = survivorID.Hex()
+		if _, err := s.updateEmployee(ctx, report, actionUpdate); err != nil {
+			return nil, err
+		}
+		resp.MovedReportIds = append(resp.MovedReportIds, report.Id)
+	}
+
+	// Scheduled changes that make someone report to the duplicate
+	_, err = s.versionsCollection.UpdateMany(ctx,
+		bson.M{"applied": false, "employee.manager_id": duplicateID, "employee_id": bson.M{"$ne": survivorID}},
+		bson.M{"$set": bson.M{"employee.manager_id": survivorID}},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update later versions: %v", err)
+	}
+	_, err = s.versionsCollection.UpdateMany(ctx,
+		bson.M{"applied": false, "employee.manager_id": duplicateID, "employee_id": survivorID},
+		bson.M{"$unset": bson.M{"employee.manager_id": ""}},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update later versions: %v", err)
+	}
+
+	res, err := s.departmentsCollection.UpdateMany(ctx, bson.M{"head_id": duplicateID}, bson.M{"$set": bson.M{"head_id": survivorID}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update departments: %v", err)
+	}
+	resp.DepartmentsUpdated = int32(res.ModifiedCount)
+
+	if _, err := s.deleteEmployee(ctx, &pb.EmployeeID{Id: duplicateID.Hex()}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// lastChanged is when the employee's latest revision was recorded, or when
+// it was created if it has none
+func (s *server) lastChanged(ctx context.Context, id primitive.ObjectID) (time.Time, error) {
+	var last EmployeeRevision
+	err := s.revisionsCollection.FindOne(ctx, bson.M{"employee_id": id},
+		options.FindOne().SetSort(bson.M{"revision": -1}).SetProjection(bson.M{"timestamp": 1}),
+	).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return id.Timestamp(), nil
+	}
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.Internal, "Failed to retrieve revisions: %v", err)
+	}
+	return last.Timestamp, nil
+}
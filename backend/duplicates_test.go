@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"", "a", 0},
+		{"a", "", 0},
+		{"smith", "smith", 1},
+		{"abc", "xyz", 0},
+		{"martha", "marhta", 0.9611},
+		{"dwayne", "duane", 0.84},
+		{"dixon", "dicksonx", 0.8133},
+		{"jones", "johnson", 0.8324},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got := jaroWinkler(tt.a, tt.b)
+			if math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("jaroWinkler(%q, %q) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+			}
+			if back := jaroWinkler(tt.b, tt.a); math.Abs(back-got) > 1e-9 {
+				t.Errorf("jaroWinkler(%q, %q) = %.4f, not the same both ways", tt.b, tt.a, back)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email, want, local string
+	}{
+		{"", "", ""},
+		{" Ada@Example.COM ", "ada@example.com", "ada"},
+		{"ada+hr@example.com", "ada@example.com", "ada"},
+		{"ada+hr+x@example.com", "ada@example.com", "ada"},
+		{"+hr@example.com", "@example.com", ""},
+		{"not-an-email", "not-an-email", "not-an-email"},
+	}
+	for _, tt := range tests {
+		email, local := normalizeEmail(tt.email)
+		if email != tt.want || local != tt.local {
+			t.Errorf("normalizeEmail(%q) = %q, %q, want %q, %q", tt.email, email, local, tt.want, tt.local)
+		}
+	}
+}
+
+func TestFirstNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   float64
+		reason string
+	}{
+		{"", "john", 0, ""},
+		{"john", "", 0, ""},
+		{"john", "john", 1, "same first name"},
+		{"john", "jon", 0.95, "nickname"},
+		{"jon", "jonathan", 0.95, "nickname"},
+		{"chris", "christine", 0.95, "nickname"},
+		{"christopher", "christine", 0.8828, ""},
+		{"j", "john", 0.85, "initial"},
+		{"john", "j", 0.85, "initial"},
+		{"k", "john", 0, ""},
+		{"martha", "marhta", 0.9611, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got, reason := firstNameSimilarity(tt.a, tt.b)
+			if math.Abs(got-tt.want) > 0.0001 || reason != tt.reason {
+				t.Errorf("firstNameSimilarity(%q, %q) = %.4f %q, want %.4f %q", tt.a, tt.b, got, reason, tt.want, tt.reason)
+			}
+		})
+	}
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	dept, other := primitive.NewObjectID(), primitive.NewObjectID()
+	john := &Employee{FirstName: "John", LastName: "Smith", Email: "john.smith@example.com", DepartmentID: dept}
+	tests := []struct {
+		name    string
+		b       *Employee
+		score   float64
+		reasons []string
+	}{
+		{
+			name:    "identical",
+			b:       &Employee{FirstName: "John", LastName: "Smith", Email: "john.smith@example.com", DepartmentID: dept},
+			score:   1,
+			reasons: []string{"same name", "same email", "same department"},
+		},
+		{
+			name:    "email differs in case and tag",
+			b:       &Employee{FirstName: "JOHN", LastName: "smith", Email: "John.Smith+hr@Example.com", DepartmentID: dept},
+			score:   1,
+			reasons: []string{"same name", "same email after normalization", "same department"},
+		},
+		{
+			name:    "nickname",
+			b:       &Employee{FirstName: "Jon", LastName: "Smith", Email: "jsmith@example.com", DepartmentID: dept},
+			score:   0.686,
+			reasons: []string{"nickname", "similar name", "same department"},
+		},
+		{
+			name:    "initial",
+			b:       &Employee{FirstName: "J", LastName: "Smith", Email: "js@example.com", DepartmentID: other},
+			score:   0.509,
+			reasons: []string{"initial", "similar name"},
+		},
+		{
+			name:    "swapped names",
+			b:       &Employee{FirstName: "Smith", LastName: "John", Email: "x@example.com", DepartmentID: other},
+			score:   0.495,
+			reasons: []string{"first and last name swapped"},
+		},
+		{
+			name:    "swapped names with a nickname",
+			b:       &Employee{FirstName: "Smith", LastName: "Jon", Email: "x@example.com", DepartmentID: other},
+			score:   0.483,
+			reasons: []string{"first and last name swapped"},
+		},
+		{
+			name:    "typo in the last name and same local part",
+			b:       &Employee{FirstName: "John", LastName: "Smyth", Email: "john.smith@other.org", DepartmentID: other},
+			score:   0.701,
+			reasons: []string{"similar name", "same email local part"},
+		},
+		{
+			name:    "same name only",
+			b:       &Employee{FirstName: "John", LastName: "Smith"},
+			score:   0.55,
+			reasons: []string{"same name"},
+		},
+		{
+			name:    "department name instead of ID",
+			b:       &Employee{FirstName: "Alice", LastName: "Brown", Email: "a@example.com", Department: "Engineering"},
+			score:   0,
+			reasons: nil,
+		},
+		{
+			name:    "same department only",
+			b:       &Employee{FirstName: "Alice", LastName: "Brown", Email: "a@example.com", DepartmentID: dept},
+			score:   0.15,
+			reasons: []string{"same department"},
+		},
+		{
+			name:    "unrelated names",
+			b:       &Employee{FirstName: "Xu", LastName: "Qiang", Email: "", DepartmentID: other},
+			score:   0.128,
+			reasons: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newDuplicateKey(john), newDuplicateKey(tt.b)
+			score, reasons := scoreDuplicate(a, b)
+			if score != tt.score || !reflect.DeepEqual(reasons, tt.reasons) {
+				t.Errorf("scoreDuplicate = %v %q, want %v %q", score, reasons, tt.score, tt.reasons)
+			}
+			if back, _ := scoreDuplicate(b, a); back != score {
+				t.Errorf("scoreDuplicate the other way round = %v, want %v", back, score)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	dept := primitive.NewObjectID()
+	newEmp := func(first, last, email string, dept primitive.ObjectID) *Employee {
+		return &Employee{ID: primitive.NewObjectID(), FirstName: first, LastName: last, Email: email, DepartmentID: dept}
+	}
+	john := newEmp("John", "Smith", "john.smith@example.com", dept)
+	// 0.686 with john, 0.536 with moved
+	jon := newEmp("Jon", "Smith", "jsmith@example.com", dept)
+	// 0.85 with john
+	moved := newEmp("John", "Smith", "john.smith+hr@example.com", primitive.NewObjectID())
+	alice := newEmp("Alice", "Brown", "alice@example.com", dept)
+	emps := []*Employee{john, jon, moved, alice}
+
+	pair := func(a, b *Employee) [2]primitive.ObjectID {
+		if a.ID.Hex() > b.ID.Hex() {
+			a, b = b, a
+		}
+		return [2]primitive.ObjectID{a.ID, b.ID}
+	}
+	tests := []struct {
+		name     string
+		only     primitive.ObjectID
+		minScore float64
+		limit    int
+		want     [][2]primitive.ObjectID
+	}{
+		{"at the default threshold", primitive.NilObjectID, 0.6, 50, [][2]primitive.ObjectID{pair(john, moved), pair(john, jon)}},
+		{"exactly at a score", primitive.NilObjectID, 0.686, 50, [][2]primitive.ObjectID{pair(john, moved), pair(john, jon)}},
+		{"just above a score", primitive.NilObjectID, 0.687, 50, [][2]primitive.ObjectID{pair(john, moved)}},
+		{"exactly at the best score", primitive.NilObjectID, 0.85, 50, [][2]primitive.ObjectID{pair(john, moved)}},
+		{"only perfect matches", primitive.NilObjectID, 1, 50, nil},
+		{"everything in a block", primitive.NilObjectID, 0, 50, [][2]primitive.ObjectID{pair(john, moved), pair(john, jon), pair(jon, moved)}},
+		{"limited", primitive.NilObjectID, 0.6, 1, [][2]primitive.ObjectID{pair(john, moved)}},
+		{"one employee", jon.ID, 0.5, 50, [][2]primitive.ObjectID{pair(john, jon), pair(jon, moved)}},
+		// alice shares no block with the others, so is never scored
+		{"an employee without duplicates", alice.ID, 0, 50, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]primitive.ObjectID
+			for _, c := range findDuplicates(emps, tt.only, tt.minScore, tt.limit) {
+				first, _ := primitive.ObjectIDFromHex(c.GetFirst().GetId())
+				second, _ := primitive.ObjectIDFromHex(c.GetSecond().GetId())
+				got = append(got, [2]primitive.ObjectID{first, second})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findDuplicates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateEmployeesInvalidMinScore(t *testing.T) {
+	s := &server{}
+	for _, minScore := range []float64{-0.001, 1.001, math.Inf(1)} {
+		_, err := s.FindDuplicateEmployees(context.Background(), &pb.FindDuplicateEmployeesRequest{MinScore: minScore})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("FindDuplicateEmployees with min_score %v = %v, want InvalidArgument", minScore, err)
+		}
+	}
+}
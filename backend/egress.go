@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// blockedPrefixes are address ranges webhooks may not reach besides the
+// loopback, private, link-local, multicast and unspecified ones the netip
+// predicates cover: "this network", carrier-grade NAT, IETF protocol
+// assignments, benchmarking and NAT64, which can all lead back inside.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// webhookGuard keeps webhooks from being pointed at internal services, such
+// as the cloud metadata endpoint on 169.254.169.254. Hosts must resolve to
+// public addresses only, unless the host or its address is on the allowlist.
+// URLs are checked when a webhook is registered, and every connection made
+// on delivery is checked again, so a DNS change or a redirect cannot get
+// around the check.
+type webhookGuard struct {
+	hosts    map[string]bool
+	prefixes []netip.Prefix
+	resolver *net.Resolver
+}
+
+// newWebhookGuard parses the allowlist: comma-separated host names, IP
+// addresses and CIDR ranges of internal receivers
+func newWebhookGuard(allowlist string) (*webhookGuard, error) {
+	g := &webhookGuard{hosts: map[string]bool{}, resolver: net.DefaultResolver}
+	for _, entry := range strings.Split(allowlist, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+		case strings.Contains(entry, "/"):
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", entry, err)
+			}
+			g.prefixes = append(g.prefixes, prefix.Masked())
+		default:
+			if addr, err := netip.ParseAddr(entry); err == nil {
+				g.prefixes = append(g.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			} else {
+				g.hosts[strings.ToLower(entry)] = true
+			}
+		}
+	}
+	return g, nil
+}
+
+// allowedAddr tells whether a webhook may connect to addr
+func (g *webhookGuard) allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range g.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve returns the addresses of host, failing if any of them is not
+// allowed. Allowlisted host names are not resolved and return nil.
+func (g *webhookGuard) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if g.hosts[strings.ToLower(host)] {
+		return nil, nil
+	}
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = g.resolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return nil, fmt.Errorf("cannot resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !g.allowedAddr(addr) {
+			return nil, fmt.Errorf("%s resolves to the internal address %s", host, addr.Unmap())
+		}
+	}
+	return addrs, nil
+}
+
+// checkURL checks the host of a webhook URL on registration
+func (g *webhookGuard) checkURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	_, err = g.resolve(ctx, u.Hostname())
+	return err
+}
+
+// client returns an HTTP client that only connects to allowed addresses. It
+// dials the addresses it checked, rather than resolving the host again, and
+// ignores proxy settings, which would hide the real destination.
+func (g *webhookGuard) client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := g.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if addrs == nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+		var lastErr error
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportFormat is a file format employees can be exported in
+type exportFormat struct {
+	contentType string
+	extension   string
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":     {contentType: "text/csv; charset=utf-8", extension: "csv"},
+	"jsonl":   {contentType: "application/x-ndjson", extension: "jsonl"},
+	"parquet": {contentType: "application/vnd.apache.parquet", extension: "parquet"},
+	"xlsx":    {contentType: xlsxContentType, extension: "xlsx"},
+}
+
+// exportField is a column of an export. Timestamp fields hold a *time.Time,
+// the rest a string.
+type exportField struct {
+	name      string
+	timestamp bool
+	value     func(e *Employee) interface{}
+}
+
+// exportFields are the exportable columns in their default order. Columns
+// the import also reads have the same names, so an export can be imported
+// again.
+var exportFields = []exportField{
+	{name: "id", value: func(e *Employee) interface{} { return e.ID.Hex() }},
+	{name: "first_name", value: func(e *Employee) interface{} { return e.FirstName }},
+	{name: "last_name", value: func(e *Employee) interface{} { return e.LastName }},
+	{name: "email", value: func(e *Employee) interface{} { return e.Email }},
+	{name: "position", value: func(e *Employee) interface{} { return e.Position }},
+	{name: "position_id", value: func(e *Employee) interface{} { return hexOrEmpty(e.PositionID) }},
+	{name: "department", value: func(e *Employee) interface{} { return e.Department }},
+	{name: "department_id", value: func(e *Employee) interface{} { return hexOrEmpty(e.DepartmentID) }},
+	{name: "manager_id", value: func(e *Employee) interface{} { return hexOrEmpty(e.ManagerID) }},
+	{name: "status", value: func(e *Employee) interface{} { return statusToProto[e.Status].String() }},
+	{name: "hire_date", timestamp: true, value: func(e *Employee) interface{} { return e.HireDate }},
+	{name: "termination_date", timestamp: true, value: func(e *Employee) interface{} { return e.TerminationDate }},
+}
+
+// exportChunkSize is how much of the file goes in one stream message
+const exportChunkSize = 64 << 10
+
+// ExportEmployees streams the matching employees straight from a cursor, so
+// an export of any size uses the same memory
+func (s *server) ExportEmployees(req *pb.ExportEmployeesRequest, stream pb.EmployeeService_ExportEmployeesServer) error {
+	log.Println("ExportEmployees RPC called")
+
+	_, format, err := exportFormatOf(req)
+	if err != nil {
+		return err
+	}
+	out := &exportStreamWriter{stream: stream, contentType: format.contentType}
+	if _, err := s.writeExport(stream.Context(), req, out, nil); err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// exportFormatOf looks up the format of an export request, CSV by default
+func exportFormatOf(req *pb.ExportEmployeesRequest) (string, exportFormat, error) {
+	name := strings.ToLower(req.GetFormat())
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		return "", exportFormat{}, status.Errorf(codes.InvalidArgument, "Unsupported export format: %s", req.GetFormat())
+	}
+	if req.GetSheetPerDepartment() && name != "xlsx" {
+		return "", exportFormat{}, status.Errorf(codes.InvalidArgument, "sheet_per_department needs the xlsx format")
+	}
+	return name, format, nil
+}
+
+// writeExport encodes the employees an export request asks for to w and
+// returns how many rows it wrote. progress, if given, is called after each
+// row with the rows so far and stops the export if it returns an error.
+func (s *server) writeExport(ctx context.Context, req *pb.ExportEmployeesRequest, w io.Writer, progress func(rows int64) error) (int64, error) {
+	formatName, _, err := exportFormatOf(req)
+	if err != nil {
+		return 0, err
+	}
+
+	custom, err := listCustomFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return 0, err
+	}
+	fields, err := selectExportFields(req.GetFields(), custom)
+	if err != nil {
+		return 0, err
+	}
+
+	filter, err := s.exportFilter(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	// Sheets are written one after the other, so their rows must come together
+	sort := bson.D{{Key: "_id", Value: 1}}
+	if req.GetSheetPerDepartment() {
+		sort = bson.D{{Key: "department", Value: 1}, {Key: "_id", Value: 1}}
+	}
+
+	var cursor *mongo.Cursor
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+		}
+		cursor, err = s.employeesAsOf(ctx, req.GetAsOf().AsTime(), filter, sort)
+	} else {
+		cursor, err = s.employeesCollection.Find(ctx, filter, options.Find().SetSort(sort))
+	}
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	enc, err := newExportEncoder(formatName, w, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	row := make([]interface{}, len(fields))
+	sheets, _ := enc.(*xlsxEncoder)
+	var rows int64
+	var department string
+	for cursor.Next(ctx) {
+		var emp Employee
+		if err := cursor.Decode(&emp); err != nil {
+			return rows, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+		}
+		if req.GetSheetPerDepartment() && (rows == 0 || emp.Department != department) {
+			if err := sheets.SetSheet(emp.Department); err != nil {
+				return rows, err
+			}
+			department = emp.Department
+		}
+		for i, f := range fields {
+			row[i] = f.value(&emp)
+		}
+		if err := enc.WriteRow(row); err != nil {
+			return rows, err
+		}
+		rows++
+		if progress != nil {
+			if err := progress(rows); err != nil {
+				return rows, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return rows, status.Errorf(codes.Internal, "Cursor error: %v", err)
+	}
+
+	return rows, enc.Close()
+}
+
+// selectExportFields looks up the requested columns, all of them if none are
+// given. Custom fields come after the built-in columns.
+func selectExportFields(names []string, custom []CustomField) ([]exportField, error) {
+	all := append(append([]exportField{}, exportFields...), customExportFields(custom)...)
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	var fields []exportField
+	seen := make(map[string]bool)
+	for _, entry := range names {
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" || seen[name] {
+				continue
+			}
+
+			found := false
+			for _, f := range all {
+				if f.name == name {
+					fields = append(fields, f)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, status.Errorf(codes.InvalidArgument, "Unknown export field: %s", name)
+			}
+			seen[name] = true
+		}
+	}
+	if len(fields) == 0 {
+		return all, nil
+	}
+	return fields, nil
+}
+
+// exportFilter builds the employee filter for an export request
+func (s *server) exportFilter(ctx context.Context, req *pb.ExportEmployeesRequest) (bson.M, error) {
+	filter := bson.M{}
+
+	if len(req.GetStatuses()) > 0 {
+		in := bson.A{}
+		for _, st := range req.GetStatuses() {
+			name, ok := statusFromProto[st]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid status: %s", st)
+			}
+			in = append(in, name)
+			if name == statusActive {
+				// Documents without a status count as active
+				in = append(in, nil)
+			}
+		}
+		filter["status"] = bson.M{"$in": in}
+	} else if !req.GetIncludeTerminated() {
+		filter["status"] = notTerminated["status"]
+	}
+
+	if ref := req.GetDepartment(); ref != "" {
+		dept, err := findDepartment(ctx, s.departmentsCollection, ref)
+		if err != nil {
+			return nil, err
+		}
+		if dept == nil {
+			return nil, status.Errorf(codes.NotFound, "Department not found: %s", ref)
+		}
+		filter["department_id"] = dept.ID
+	}
+
+	if id := req.GetManagerId(); id != "" {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid manager ID format: %v", err)
+		}
+		filter["manager_id"] = oid
+	}
+
+	return filter, nil
+}
+
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+// exportEncoder writes rows of export values in one file format
+type exportEncoder interface {
+	WriteRow(row []interface{}) error
+	Close() error
+}
+
+func newExportEncoder(format string, w io.Writer, fields []exportField) (exportEncoder, error) {
+	switch format {
+	case "jsonl":
+		return &jsonlEncoder{w: w, fields: fields}, nil
+	case "xlsx":
+		return newXLSXEncoder(w, fields)
+	case "parquet":
+		columns := make([]parquetColumn, len(fields))
+		for i, f := range fields {
+			columns[i] = parquetColumn{name: f.name, timestamp: f.timestamp}
+		}
+		return newParquetWriter(w, columns), nil
+	default:
+		enc := &csvEncoder{w: csv.NewWriter(w)}
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = f.name
+		}
+		if err := enc.w.Write(header); err != nil {
+			return nil, err
+		}
+		return enc, nil
+	}
+}
+
+// csvEncoder writes a header row and then one record per employee. Missing
+// values are empty.
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (e *csvEncoder) WriteRow(row []interface{}) error {
+	e.record = e.record[:0]
+	for _, v := range row {
+		e.record = append(e.record, exportText(v))
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonlEncoder writes one JSON object per employee, with the fields in the
+// requested order. Missing values are null.
+type jsonlEncoder struct {
+	w      io.Writer
+	fields []exportField
+	line   []byte
+}
+
+func (e *jsonlEncoder) WriteRow(row []interface{}) error {
+	e.line = append(e.line[:0], '{')
+	for i, v := range row {
+		if i > 0 {
+			e.line = append(e.line, ',')
+		}
+		key, _ := json.Marshal(e.fields[i].name)
+		e.line = append(e.line, key...)
+		e.line = append(e.line, ':')
+		if text := exportText(v); text != "" {
+			value, err := json.Marshal(text)
+			if err != nil {
+				return err
+			}
+			e.line = append(e.line, value...)
+		} else {
+			e.line = append(e.line, "null"...)
+		}
+	}
+	e.line = append(e.line, '}', '\n')
+	_, err := e.w.Write(e.line)
+	return err
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+// exportText formats an export value for the text formats. Timestamps are
+// RFC 3339 in UTC.
+func exportText(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+// exportStreamWriter sends what is written to it as HttpBody chunks of about
+// exportChunkSize. The first chunk carries the content type.
+type exportStreamWriter struct {
+	stream      grpc.ServerStreamingServer[httpbody.HttpBody]
+	contentType string
+	buf         []byte
+	sent        bool
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= exportChunkSize {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// flush sends what is buffered. Even an empty file is sent as one chunk, so
+// the client always learns the content type.
+func (w *exportStreamWriter) flush() error {
+	if len(w.buf) == 0 && w.sent {
+		return nil
+	}
+	chunk := &httpbody.HttpBody{Data: w.buf}
+	if !w.sent {
+		chunk.ContentType = w.contentType
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return err
+	}
+	w.sent = true
+	// The sent chunk may still be read, so start a new buffer
+	w.buf = make([]byte, 0, exportChunkSize)
+	return nil
+}
+
+// exportHandler serves GET /v1/employees:export as a file download. The
+// generated gateway handler would add a newline after every chunk, so the
+// chunks are copied here as they are.
+func exportHandler(mux *runtime.ServeMux, client pb.EmployeeServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, pb.EmployeeService_ExportEmployees_FullMethodName,
+			runtime.WithHTTPPathPattern("/v1/employees:export"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		req := &pb.ExportEmployeesRequest{}
+		if err := runtime.PopulateQueryParameters(req, r.URL.Query(), &utilities.DoubleArray{}); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+
+		stream, err := client.ExportEmployees(ctx, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		streamDownload(ctx, mux, outbound, w, r, stream, func() string { return exportFilename(req) })
+	}
+}
+
+// streamDownload copies the HttpBody chunks of a download stream to the
+// response as they are. Errors before the first chunk, such as an unknown
+// format, can still be sent as an error response. filename is called once
+// the first chunk has arrived.
+func streamDownload(ctx context.Context, mux *runtime.ServeMux, outbound runtime.Marshaler, w http.ResponseWriter, r *http.Request,
+	stream grpc.ServerStreamingClient[httpbody.HttpBody], filename func() string) {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		err = status.Errorf(codes.Internal, "Download returned no data")
+	}
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outbound, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", first.GetContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": filename(),
+	}))
+	if _, err := w.Write(first.GetData()); err != nil {
+		return
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Too late for an error status. Abort the response so the
+			// download fails instead of looking complete.
+			log.Printf("Download failed after the first chunk: %v", err)
+			panic(http.ErrAbortHandler)
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+// exportFilename names the download after the date the export is for
+func exportFilename(req *pb.ExportEmployeesRequest) string {
+	date := time.Now().UTC()
+	if req.GetAsOf() != nil {
+		date = req.GetAsOf().AsTime()
+	}
+	format := exportFormats[strings.ToLower(req.GetFormat())]
+	if format.extension == "" {
+		format = exportFormats["csv"]
+	}
+	return "employees-" + date.Format("2006-01-02") + "." + format.extension
+}
@@ -0,0 +1,10 @@
+package main
+
+// Regenerate the gRPC, gateway and OpenAPI code from the proto definitions.
+// Requires protoc plus the protoc-gen-go, protoc-gen-go-grpc,
+// protoc-gen-grpc-gateway and protoc-gen-openapiv2 plugins on PATH.
+//go:generate protoc -I . -I ../third_party/googleapis --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative --grpc-gateway_out=pb --grpc-gateway_opt=paths=source_relative --openapiv2_out=openapi --openapiv2_opt=allow_merge=true,merge_file_name=employee employee.proto admin.proto department.proto position.proto audit.proto webhook.proto events.proto jobs.proto analytics.proto customfield.proto tenant.proto scim.proto
+//
+// The Operations service comes from google/longrunning, whose messages are in
+// cloud.google.com/go/longrunning; only its gateway is generated here.
+//go:generate protoc -I ../third_party/googleapis --grpc-gateway_out=pb/longrunning --grpc-gateway_opt=standalone=true,module=cloud.google.com/go/longrunning/autogen/longrunningpb google/longrunning/operations.proto
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateManager checks that managerHex names an existing employee and that
+// making it the manager of empID would not create a reporting cycle. empID is
+// NilObjectID for employees that do not exist yet. An empty managerHex means
+// the employee has no manager.
+func (s *server) validateManager(ctx context.Context, empID primitive.ObjectID, managerHex string) (primitive.ObjectID, error) {
+	if managerHex == "" {
+		return primitive.NilObjectID, nil
+	}
+
+	managerID, err := primitive.ObjectIDFromHex(managerHex)
+	if err != nil {
+		return primitive.NilObjectID, status.Errorf(codes.InvalidArgument, "Invalid manager ID format: %v", err)
+	}
+	if managerID == empID {
+		return primitive.NilObjectID, status.Errorf(codes.InvalidArgument, "Employee cannot be their own manager")
+	}
+
+	chain, err := s.managementChain(ctx, managerID)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if chain == nil {
+		return primitive.NilObjectID, status.Errorf(codes.FailedPrecondition, "Manager not found with ID: %s", managerHex)
+	}
+
+	if !empID.IsZero() {
+		for _, m := range chain {
+			if m.ID == empID {
+				return primitive.NilObjectID, status.Errorf(codes.FailedPrecondition, "Manager %s reports to this employee, which would create a cycle", managerHex)
+			}
+		}
+	}
+
+	return managerID, nil
+}
+
+// managementChain returns the managers above id, nearest first. It returns
+// nil if id does not exist.
+func (s *server) managementChain(ctx context.Context, id primitive.ObjectID) ([]Employee, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": id}}},
+		{{Key: "$graphLookup", Value: bson.M{
+			"from":             s.employeesCollection.Name(),
+			"startWith":        "$manager_id",
+			"connectFromField": "manager_id",
+			"connectToField":   "_id",
+			"as":               "chain",
+			"depthField":       "depth",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$chain", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$sort", Value: bson.M{"chain.depth": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$_id",
+			"chain": bson.M{"$push": "$chain"},
+		}}},
+	}
+
+	cursor, err := s.employeesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to look up management chain: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Chain []Employee `bson:"chain"`
+	}
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return nil, status.Errorf(codes.Internal, "Cursor error: %v", err)
+		}
+		return nil, nil
+	}
+	if err := cursor.Decode(&result); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode management chain: %v", err)
+	}
+	if result.Chain == nil {
+		result.Chain = []Employee{}
+	}
+
+	return result.Chain, nil
+}
+
+// requireEmployee parses id and checks that the employee exists
+func (s *server) requireEmployee(ctx context.Context, id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	n, err := s.employeesCollection.CountDocuments(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return primitive.NilObjectID, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+	}
+	if n == 0 {
+		return primitive.NilObjectID, status.Errorf(codes.NotFound, "Employee not found with ID: %s", id)
+	}
+
+	return oid, nil
+}
+
+// ListDirectReports
+func (s *server) ListDirectReports(ctx context.Context, req *pb.EmployeeID) (*pb.EmployeeList, error) {
+	log.Println("ListDirectReports RPC called")
+
+	oid, err := s.requireEmployee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.employeesCollection.Find(ctx, bson.M{"manager_id": oid, "status": bson.M{"$ne": statusTerminated}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve direct reports: %v", err)
+	}
+
+	return decodeEmployeeList(ctx, cursor)
+}
+
+// ListReportingTree
+func (s *server) ListReportingTree(ctx context.Context, req *pb.EmployeeID) (*pb.EmployeeList, error) {
+	log.Println("ListReportingTree RPC called")
+
+	oid, err := s.requireEmployee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": oid}}},
+		{{Key: "$graphLookup", Value: bson.M{
+			"from":                    s.employeesCollection.Name(),
+			"startWith":               "$_id",
+			"connectFromField":        "_id",
+			"connectToField":          "manager_id",
+			"as":                      "reports",
+			"depthField":              "depth",
+			"restrictSearchWithMatch": notTerminated,
+		}}},
+		{{Key: "$unwind", Value: "$reports"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$reports"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "depth", Value: 1}, {Key: "last_name", Value: 1}}}},
+	}
+
+	cursor, err := s.employeesCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve reporting tree: %v", err)
+	}
+
+	return decodeEmployeeList(ctx, cursor)
+}
+
+// ListManagementChain
+func (s *server) ListManagementChain(ctx context.Context, req *pb.EmployeeID) (*pb.EmployeeList, error) {
+	log.Println("ListManagementChain RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	chain, err := s.managementChain(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", req.GetId())
+	}
+
+	employees := make([]*pb.Employee, 0, len(chain))
+	for _, m := range chain {
+		employees = append(employees, m.toProto())
+	}
+
+	return &pb.EmployeeList{Employees: employees}, nil
+}
@@ -0,0 +1,718 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Row actions in an import report
+const (
+	importCreate = "create"
+	importUpdate = "update"
+	importError  = "error"
+)
+
+// importFields are the employee fields a column can fill, besides custom
+// fields as custom.<name>
+var importFields = map[string]bool{
+	"first_name":     true,
+	"last_name":      true,
+	"email":          true,
+	"position":       true,
+	"position_id":    true,
+	"department":     true,
+	"department_id":  true,
+	"manager_id":     true,
+	"manager_email":  true,
+	"status":         true,
+	"effective_date": true,
+}
+
+// importChunkSize is how much of an uploaded file goes in one stream message
+const importChunkSize = 32 << 10
+
+// ImportEmployees reads a CSV file or a sheet of an XLSX workbook and
+// creates or updates an employee per row. Each row is saved in its own
+// transaction, so one bad row does not stop the others. A dry run saves each
+// row in a transaction of its own and aborts it, so no transaction grows with
+// the file; rows that refer to earlier rows of the file (such as a
+// manager_email) are checked against the emails of those rows instead.
+func (s *server) ImportEmployees(stream pb.EmployeeService_ImportEmployeesServer) error {
+	log.Println("ImportEmployees RPC called")
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Errorf(codes.InvalidArgument, "Import is empty")
+	}
+	if err != nil {
+		return err
+	}
+	opts := first.GetOptions()
+	file, err := s.openImport(ctx, bufio.NewReader(&importStreamReader{stream: stream, buf: first.GetChunk()}), opts)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report := newImportReport(opts, file)
+	tally := func(result *pb.ImportRowResult) error {
+		countImportRow(report, result)
+		report.Rows = append(report.Rows, result)
+		return nil
+	}
+
+	if !opts.GetDryRun() {
+		err = file.importRows(0, func(line int32, record []string) *pb.ImportRowResult {
+			return s.importRow(ctx, line, file.columns, record, opts, nil, func(ctx context.Context, _ *pb.ImportRowResult, write importWrite) (*pb.Employee, error) {
+				return inTransaction(ctx, s.mongoClient(), write)
+			})
+		}, tally)
+	} else {
+		earlier := map[string]bool{}
+		err = file.importRows(0, func(line int32, record []string) *pb.ImportRowResult {
+			return s.importRow(ctx, line, file.columns, record, opts, earlier, s.abortedSave)
+		}, tally)
+	}
+	if err != nil {
+		return err
+	}
+
+	if file.book != nil {
+		report.AnnotatedWorkbook, err = file.book.annotate(report.Rows)
+		if err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(report)
+}
+
+// importFile is an uploaded file opened for import, past its header row
+type importFile struct {
+	rows importSource
+	// The workbook of an XLSX file, nil for CSV
+	book    *xlsxImport
+	columns []string
+	ignored []string
+}
+
+// openImport works out the format of an uploaded file, unless the options
+// give it, and reads its header
+func (s *server) openImport(ctx context.Context, input *bufio.Reader, opts *pb.ImportOptions) (*importFile, error) {
+	format := strings.ToLower(opts.GetFormat())
+	if format == "" {
+		format = "csv"
+		// XLSX files are zip archives
+		if sig, _ := input.Peek(4); string(sig) == "PK\x03\x04" {
+			format = "xlsx"
+		}
+	}
+
+	file := &importFile{}
+	mapping := opts.GetHeaderMapping()
+	switch format {
+	case "csv":
+		file.rows = newCSVImportSource(input)
+	case "xlsx":
+		book, err := openXLSXImport(input, opts.GetSheet())
+		if err != nil {
+			return nil, err
+		}
+		file.rows, file.book = book, book
+
+		// The errors column of a workbook annotated before is not data
+		mapping = map[string]string{xlsxImportErrorsHeader: ""}
+		for from, to := range opts.GetHeaderMapping() {
+			mapping[from] = to
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unsupported import format: %s", opts.GetFormat())
+	}
+
+	_, header, err := file.rows.Next()
+	if err == io.EOF {
+		err = status.Errorf(codes.InvalidArgument, "The %s file is empty", strings.ToUpper(format))
+	}
+	if err == nil {
+		file.columns, file.ignored, err = importColumns(header, mapping)
+	}
+	if err != nil {
+		file.Close()
+		return nil, importReadError(err)
+	}
+	if file.book != nil {
+		// Custom date fields are read like effective_date
+		custom, err := listCustomFields(ctx, s.customFieldsCollection)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		dates := map[string]bool{"effective_date": true}
+		for _, f := range custom {
+			if f.Type == customDate {
+				dates["custom."+f.Name] = true
+			}
+		}
+		file.book.setColumns(header, file.columns, dates)
+	}
+	return file, nil
+}
+
+// importRows imports the rows after line `after` with handle and passes each
+// result to tally. Rows that cannot be parsed fail without reaching handle.
+func (f *importFile) importRows(after int32, handle func(line int32, record []string) *pb.ImportRowResult, tally func(*pb.ImportRowResult) error) error {
+	for {
+		line, record, err := f.rows.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		var result *pb.ImportRowResult
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			result = &pb.ImportRowResult{Line: line, Action: importError, Errors: []string{parseErr.Err.Error()}}
+		case err != nil:
+			return importReadError(err)
+		case line <= after:
+			continue
+		default:
+			result = handle(line, record)
+		}
+		if result.Line <= after {
+			continue
+		}
+		if err := tally(result); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *importFile) Close() {
+	if f.book != nil {
+		f.book.Close()
+	}
+}
+
+// countImportRow adds a row result to the report totals
+func countImportRow(report *pb.ImportReport, result *pb.ImportRowResult) {
+	report.TotalRows++
+	switch result.Action {
+	case importCreate:
+		report.Created++
+	case importUpdate:
+		report.Updated++
+	default:
+		report.Failed++
+	}
+}
+
+// importWrite saves one imported row
+type importWrite func(ctx context.Context) (*pb.Employee, error)
+
+// importSave runs an importWrite in a transaction of its own. The result
+// already holds the action the write takes.
+type importSave func(ctx context.Context, result *pb.ImportRowResult, write importWrite) (*pb.Employee, error)
+
+// dryRunNote tells the reader of a dry run report what it could not check
+const dryRunNote = "Dry run: each row was saved in its own transaction and rolled back, " +
+	"so rows were checked against the stored employees and the emails of earlier rows, not against earlier rows as saved"
+
+// newImportReport starts the report of an import
+func newImportReport(opts *pb.ImportOptions, file *importFile) *pb.ImportReport {
+	report := &pb.ImportReport{DryRun: opts.GetDryRun(), IgnoredColumns: file.ignored}
+	if opts.GetDryRun() {
+		report.Note = dryRunNote
+	}
+	return report
+}
+
+// abortedSave runs the write of one row in a transaction that is rolled
+// back, for dry runs
+func (s *server) abortedSave(ctx context.Context, _ *pb.ImportRowResult, write importWrite) (*pb.Employee, error) {
+	var emp *pb.Employee
+	err := s.inAbortedTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		emp, err = write(ctx)
+		return err
+	})
+	return emp, err
+}
+
+// inAbortedTransaction runs fn in a transaction that is always rolled back,
+// for dry runs
+func (s *server) inAbortedTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := s.mongoClient().StartSession()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+	return mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+		if err := session.StartTransaction(); err != nil {
+			return status.Errorf(codes.Internal, "Failed to start transaction: %v", err)
+		}
+		defer session.AbortTransaction(context.Background())
+		return fn(sc)
+	})
+}
+
+// importRow validates one row and creates or updates its employee, saving it
+// with save. On a dry run earlier holds the emails of the rows before it that
+// passed, which were not kept; it is nil otherwise.
+func (s *server) importRow(ctx context.Context, line int32, columns []string, record []string, opts *pb.ImportOptions, earlier map[string]bool, save importSave) *pb.ImportRowResult {
+	result := &pb.ImportRowResult{Line: line}
+	fail := func(format string, args ...interface{}) *pb.ImportRowResult {
+		result.Action = importError
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+		return result
+	}
+
+	if len(record) > len(columns) {
+		return fail("Row has %d cells but the header only %d", len(record), len(columns))
+	}
+	values := map[string]string{}
+	for i, v := range record {
+		if columns[i] != "" {
+			values[columns[i]] = strings.TrimSpace(v)
+		}
+	}
+
+	// Emails are matched without case but stored as written
+	email := strings.ToLower(values["email"])
+	result.Email = values["email"]
+	if email == "" {
+		fail("email is required")
+	} else if addr, err := mail.ParseAddress(values["email"]); err != nil || addr.Address != values["email"] {
+		fail("Invalid email: %s", values["email"])
+	}
+
+	var st pb.EmploymentStatus
+	if v := values["status"]; v != "" {
+		name := strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_").Replace(v))
+		n, ok := pb.EmploymentStatus_value[name]
+		if !ok {
+			fail("Unknown status: %s", v)
+		}
+		st = pb.EmploymentStatus(n)
+	}
+
+	var effective *timestamppb.Timestamp
+	if v := values["effective_date"]; v != "" {
+		t, err := parseImportDate(v)
+		if err != nil {
+			fail("Invalid effective_date %q, expected YYYY-MM-DD or RFC 3339", v)
+		} else {
+			effective = timestamppb.New(t)
+		}
+	}
+
+	existing, err := s.findEmployeeByEmail(ctx, email)
+	if err != nil {
+		return fail("%s", status.Convert(err).Message())
+	}
+	// An earlier row of a dry run would have created the employee
+	repeated := existing == nil && earlier[email]
+	if (existing != nil || repeated) && !opts.GetUpsertByEmail() {
+		fail("Employee with email %s already exists", values["email"])
+	}
+
+	// New employees need every required field, updates keep what is not given
+	emp := &pb.Employee{Status: st}
+	if existing != nil {
+		emp = existing.toProto()
+	}
+	for field, v := range values {
+		if v == "" {
+			continue
+		}
+		switch field {
+		case "first_name":
+			emp.FirstName = v
+		case "last_name":
+			emp.LastName = v
+		case "email":
+			// Updates keep the stored spelling of the matched email
+			if existing == nil {
+				emp.Email = v
+			}
+		case "position":
+			emp.Position, emp.PositionId = v, ""
+		case "position_id":
+			emp.PositionId = v
+		case "department":
+			emp.Department, emp.DepartmentId = v, ""
+		case "department_id":
+			emp.DepartmentId = v
+		case "manager_id":
+			emp.ManagerId = v
+		default:
+			if name, ok := strings.CutPrefix(field, "custom."); ok {
+				if emp.CustomFields == nil {
+					emp.CustomFields = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+				}
+				emp.CustomFields.Fields[name] = structpb.NewStringValue(v)
+			}
+		}
+	}
+	if emp.GetFirstName() == "" && !repeated {
+		fail("first_name is required")
+	}
+	if emp.GetLastName() == "" && !repeated {
+		fail("last_name is required")
+	}
+
+	// Managers may be given by email, including ones created earlier in the file
+	if v := strings.ToLower(values["manager_email"]); v != "" && values["manager_id"] == "" {
+		manager, err := s.findEmployeeByEmail(ctx, v)
+		switch {
+		case err != nil:
+			fail("%s", status.Convert(err).Message())
+		case manager == nil && earlier[v]:
+			// Created by an earlier row, which a dry run did not keep
+		case manager == nil:
+			fail("Manager not found with email: %s", v)
+		default:
+			emp.ManagerId = manager.ID.Hex()
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result
+	}
+
+	emp.EffectiveDate = effective
+	result.Action = importCreate
+	if existing != nil || repeated {
+		result.Action = importUpdate
+	}
+	if repeated {
+		// There is nothing stored to update
+		return result
+	}
+	saved, err := save(ctx, result, func(ctx context.Context) (*pb.Employee, error) {
+		if existing != nil {
+			return s.updateEmployee(ctx, emp, actionUpdate)
+		}
+		return s.createEmployee(ctx, emp)
+	})
+	if err != nil {
+		return fail("%s", status.Convert(err).Message())
+	}
+	result.EmployeeId = saved.GetId()
+	if earlier != nil {
+		earlier[email] = true
+	}
+	return result
+}
+
+// findEmployeeByEmail looks an employee up by case-insensitive email. It
+// returns nil if there is none.
+func (s *server) findEmployeeByEmail(ctx context.Context, email string) (*Employee, error) {
+	if email == "" {
+		return nil, nil
+	}
+	filter := bson.M{"email": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(email) + "$", Options: "i"}}
+
+	cursor, err := s.employeesCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	var matches []Employee
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "%d employees have the email %s", len(matches), email)
+}
+
+// importColumns works out which field each column fills, "" for columns
+// that are skipped. Headers are matched without regard to case, spaces or
+// dashes.
+func importColumns(header []string, mapping map[string]string) ([]string, []string, error) {
+	normalized := map[string]string{}
+	for from, to := range mapping {
+		to = normalizeImportHeader(to)
+		if to != "" && !isImportField(to) {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "Unknown field %q in header mapping", to)
+		}
+		normalized[normalizeImportHeader(from)] = to
+	}
+
+	columns := make([]string, len(header))
+	var ignored []string
+	seen := map[string]bool{}
+	for i, h := range header {
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		key := normalizeImportHeader(h)
+		field, mapped := normalized[key]
+		if !mapped {
+			field = key
+		}
+		if field != "" && !isImportField(field) {
+			ignored = append(ignored, h)
+			field = ""
+		}
+		if field != "" && seen[field] {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "More than one column maps to %s", field)
+		}
+		seen[field] = true
+		columns[i] = field
+	}
+
+	if !seen["email"] {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "The file needs an email column")
+	}
+	return columns, ignored, nil
+}
+
+// isImportField reports whether a column can fill the field. Custom field
+// names are checked when the employee is saved.
+func isImportField(field string) bool {
+	return importFields[field] || strings.HasPrefix(field, "custom.")
+}
+
+func normalizeImportHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+}
+
+func parseImportDate(v string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func importReadError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.InvalidArgument, "Failed to read file: %v", err)
+}
+
+// importSource yields the rows of an uploaded file, each with the line or
+// row number it starts on. It returns io.EOF after the last row. A
+// *csv.ParseError fails that row only; other errors stop the import.
+type importSource interface {
+	Next() (int32, []string, error)
+}
+
+type csvImportSource struct {
+	r *csv.Reader
+}
+
+func newCSVImportSource(r io.Reader) *csvImportSource {
+	rows := csv.NewReader(r)
+	rows.FieldsPerRecord = -1
+	rows.TrimLeadingSpace = true
+	return &csvImportSource{r: rows}
+}
+
+func (c *csvImportSource) Next() (int32, []string, error) {
+	record, err := c.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return int32(parseErr.StartLine), nil, err
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	line, _ := c.r.FieldPos(0)
+	return int32(line), record, nil
+}
+
+// importStreamReader reads the file chunks of an ImportEmployees or
+// StartImportJob stream
+type importStreamReader struct {
+	stream interface {
+		Recv() (*pb.ImportEmployeesRequest, error)
+	}
+	buf []byte
+}
+
+func (r *importStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetOptions() != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "Import options must come first")
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// importHandler serves POST /v1/employees:import. It takes a multipart form
+// with the CSV or XLSX file in a "file" part and passes it on to
+// ImportEmployees. Options come from the query string or from form fields
+// sent before the file: dry_run, upsert_by_email, format, sheet and mapping,
+// a JSON object of header to field. For XLSX imports, a client that accepts
+// XLSX gets the annotated workbook back instead of the JSON report.
+func importHandler(mux *runtime.ServeMux, client pb.EmployeeServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, pb.EmployeeService_ImportEmployees_FullMethodName,
+			runtime.WithHTTPPathPattern("/v1/employees:import"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		opts, part, err := readImportForm(r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		stream, err := client.ImportEmployees(ctx)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		report, err := sendImport(stream, opts, part)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		if report.GetAnnotatedWorkbook() != nil && strings.Contains(r.Header.Get("Accept"), xlsxContentType) {
+			name := strings.TrimSuffix(path.Base(part.FileName()), path.Ext(part.FileName()))
+			if name == "" || name == "." || name == "/" {
+				name = "import"
+			}
+			w.Header().Set("Content-Type", xlsxContentType)
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+				"filename": name + "-checked.xlsx",
+			}))
+			w.Write(report.GetAnnotatedWorkbook())
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, report)
+	}
+}
+
+// readImportForm reads the import options of a multipart import request, up
+// to the file part, which it returns unread
+func readImportForm(r *http.Request) (*pb.ImportOptions, *multipart.Part, error) {
+	opts := &pb.ImportOptions{HeaderMapping: map[string]string{}}
+	for key, values := range r.URL.Query() {
+		for _, v := range values {
+			if err := setImportOption(opts, key, v); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	parts, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Expected a multipart form: %v", err)
+	}
+
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "The form has no file part")
+		}
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "Failed to read form: %v", err)
+		}
+		if part.FormName() == "file" {
+			return opts, part, nil
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, 64<<10))
+		if err == nil {
+			err = setImportOption(opts, part.FormName(), string(value))
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// sendImport streams a file to ImportEmployees or StartImportJob
+func sendImport[T any](stream grpc.ClientStreamingClient[pb.ImportEmployeesRequest, T], opts *pb.ImportOptions, file io.Reader) (*T, error) {
+	if err := stream.Send(&pb.ImportEmployeesRequest{Part: &pb.ImportEmployeesRequest_Options{Options: opts}}); err != nil {
+		return stream.CloseAndRecv()
+	}
+
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			chunk := append([]byte(nil), buf[:n]...)
+			if err := stream.Send(&pb.ImportEmployeesRequest{Part: &pb.ImportEmployeesRequest_Chunk{Chunk: chunk}}); err != nil {
+				// The server stopped reading, its status says why
+				return stream.CloseAndRecv()
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to read file: %v", err)
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func setImportOption(opts *pb.ImportOptions, key, value string) error {
+	var err error
+	switch key {
+	case "dry_run":
+		opts.DryRun, err = strconv.ParseBool(value)
+	case "upsert_by_email":
+		opts.UpsertByEmail, err = strconv.ParseBool(value)
+	case "format":
+		opts.Format = value
+	case "sheet":
+		opts.Sheet = value
+	case "mapping":
+		err = json.Unmarshal([]byte(value), &opts.HeaderMapping)
+	default:
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid %s: %v", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "EMPLOYEE_APP/backend/pb"
+)
+
+func TestImportKeepsEmailCase(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	s := newDatabaseServer(db)
+	save := func(ctx context.Context, _ *pb.ImportRowResult, write importWrite) (*pb.Employee, error) {
+		return inTransaction(ctx, s.mongoClient(), write)
+	}
+
+	emp, err := s.CreateEmployee(ctx, &pb.Employee{FirstName: "Ada", LastName: "Byron", Email: "Ada.Byron@Example.com"})
+	if err != nil {
+		t.Fatalf("CreateEmployee: %v", err)
+	}
+
+	columns := []string{"first_name", "last_name", "email"}
+	opts := &pb.ImportOptions{UpsertByEmail: true}
+	tests := []struct {
+		name   string
+		record []string
+		action string
+		stored string
+	}{
+		{"update matched without case", []string{"Ada", "Lovelace", "ada.byron@example.com"}, importUpdate, "Ada.Byron@Example.com"},
+		{"create as written", []string{"Grace", "Hopper", "Grace.Hopper@Example.com"}, importCreate, "Grace.Hopper@Example.com"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.importRow(ctx, int32(i+2), columns, tt.record, opts, nil, save)
+			if result.GetAction() != tt.action {
+				t.Fatalf("action = %q %v, want %q", result.GetAction(), result.GetErrors(), tt.action)
+			}
+			got, err := s.GetEmployee(ctx, &pb.GetEmployeeRequest{Id: result.GetEmployeeId()})
+			if err != nil {
+				t.Fatalf("GetEmployee: %v", err)
+			}
+			if got.GetEmail() != tt.stored {
+				t.Errorf("email = %q, want %q", got.GetEmail(), tt.stored)
+			}
+		})
+	}
+
+	// The duplicate check ignores case too
+	result := s.importRow(ctx, 4, columns, []string{"Ada", "Byron", "ADA.BYRON@EXAMPLE.COM"}, &pb.ImportOptions{}, nil, save)
+	if result.GetAction() != importError {
+		t.Errorf("action = %q, want %q for an existing email", result.GetAction(), importError)
+	}
+	if result.GetEmployeeId() != "" && result.GetEmployeeId() != emp.GetId() {
+		t.Errorf("a second employee %s was created", result.GetEmployeeId())
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ensureIndexes creates the indexes the queries rely on. CreateMany is a
+// no-op for indexes that already exist.
+func ensureIndexes(ctx context.Context, employees, departments, positions, versions, revisions, audit, outbox, deliveries, jobs, customFields *mongo.Collection) error {
+	_, err := employees.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "manager_id", Value: 1}}},
+		{Keys: bson.D{{Key: "department_id", Value: 1}}},
+		{Keys: bson.D{{Key: "position_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		// Filters on custom fields
+		{Keys: bson.D{{Key: "custom_fields.$**", Value: 1}}},
+		// Lookups by the identity provider's ID
+		{Keys: bson.D{{Key: "external_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = departments.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "parent_id", Value: 1}}},
+		{Keys: bson.D{{Key: "external_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = positions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "job_family", Value: 1}, {Key: "level", Value: 1}, {Key: "title", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = versions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "employee_id", Value: 1}, {Key: "effective_from", Value: -1}}},
+		{Keys: bson.D{{Key: "effective_from", Value: 1}, {Key: "effective_to", Value: 1}}},
+		{Keys: bson.D{{Key: "applied", Value: 1}, {Key: "effective_from", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = revisions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "employee_id", Value: 1}, {Key: "revision", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = audit.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "sequence", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "target_ids", Value: 1}}},
+		{Keys: bson.D{{Key: "actor", Value: 1}}},
+		{Keys: bson.D{{Key: "method", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Dispatched events are kept for a week for troubleshooting
+	_, err = outbox.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "dispatched", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(7 * 24 * 60 * 60).
+				SetPartialFilterExpression(bson.M{"dispatched": true}),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "webhook_id", Value: 1}, {Key: "event_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "_id", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = jobs.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "lease_until", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = customFields.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	return err
+}
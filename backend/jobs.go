@@ -0,0 +1,634 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxJobFailedRows caps the failed rows an import job keeps for its report
+// and annotated workbook. Failures past it are still counted.
+const maxJobFailedRows = 10000
+
+// jobFileNameHeader is the response header of DownloadJobFile that names the
+// file
+const jobFileNameHeader = "x-file-name"
+
+type jobServer struct {
+	pb.UnimplementedJobServiceServer
+	runner *jobRunner
+}
+
+func NewJobServer(runner *jobRunner) pb.JobServiceServer {
+	return &jobServer{runner: runner}
+}
+
+// StartImportJob stores the uploaded file and queues the import
+func (s *jobServer) StartImportJob(stream pb.JobService_StartImportJobServer) error {
+	log.Println("StartImportJob RPC called")
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Errorf(codes.InvalidArgument, "Import is empty")
+	}
+	if err != nil {
+		return err
+	}
+	opts := first.GetOptions()
+	if format := strings.ToLower(opts.GetFormat()); format != "" && format != "csv" && format != "xlsx" {
+		return status.Errorf(codes.InvalidArgument, "Unsupported import format: %s", opts.GetFormat())
+	}
+
+	bucket, err := jobFiles(s.runner.jobsCollection.Database())
+	if err != nil {
+		return err
+	}
+	upload, err := bucket.OpenUploadStream("import")
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to store file: %v", err)
+	}
+	if _, err := io.Copy(upload, &importStreamReader{stream: stream, buf: first.GetChunk()}); err != nil {
+		upload.Abort()
+		return importReadError(err)
+	}
+	if err := upload.Close(); err != nil {
+		return status.Errorf(codes.Internal, "Failed to store file: %v", err)
+	}
+
+	fileID := upload.FileID.(primitive.ObjectID)
+	job, err := s.runner.create(ctx, jobImport, opts, fileID)
+	if err != nil {
+		bucket.DeleteContext(ctx, fileID)
+		return err
+	}
+	op, err := job.toOperation()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(op)
+}
+
+// StartExportJob
+func (s *jobServer) StartExportJob(ctx context.Context, req *pb.ExportEmployeesRequest) (*longrunningpb.Operation, error) {
+	log.Println("StartExportJob RPC called")
+
+	if _, _, err := exportFormatOf(req); err != nil {
+		return nil, err
+	}
+	custom, err := listCustomFields(ctx, s.runner.employees.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := selectExportFields(req.GetFields(), custom); err != nil {
+		return nil, err
+	}
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+		}
+	}
+
+	job, err := s.runner.create(ctx, jobExport, req, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return job.toOperation()
+}
+
+// StartReindexJob
+func (s *jobServer) StartReindexJob(ctx context.Context, req *pb.ReindexRequest) (*longrunningpb.Operation, error) {
+	log.Println("StartReindexJob RPC called")
+
+	job, err := s.runner.create(ctx, jobReindex, req, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return job.toOperation()
+}
+
+// DownloadJobFile
+func (s *jobServer) DownloadJobFile(req *pb.JobFileRequest, stream pb.JobService_DownloadJobFileServer) error {
+	log.Println("DownloadJobFile RPC called")
+	ctx := stream.Context()
+
+	job, err := findJob(ctx, s.runner.jobsCollection, req.GetName())
+	if err != nil {
+		return err
+	}
+	if job.File == nil {
+		return status.Errorf(codes.NotFound, "Operation %s has no file", job.operationName())
+	}
+
+	bucket, err := jobFiles(s.runner.jobsCollection.Database())
+	if err != nil {
+		return err
+	}
+	file, err := bucket.OpenDownloadStream(job.ID)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return status.Errorf(codes.NotFound, "Operation %s has no file", job.operationName())
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to open job file: %v", err)
+	}
+	defer file.Close()
+
+	if err := stream.SetHeader(metadata.Pairs(jobFileNameHeader, job.File.Name)); err != nil {
+		return err
+	}
+	out := &exportStreamWriter{stream: stream, contentType: job.File.ContentType}
+	if _, err := io.Copy(out, file); err != nil {
+		return status.Errorf(codes.Internal, "Failed to read job file: %v", err)
+	}
+	return out.flush()
+}
+
+// runImport imports an uploaded file. Each row saves the checkpoint in its
+// own transaction, so a resumed import carries on after the last row saved.
+// Dry runs save nothing and start over.
+func (r *jobRunner) runImport(ctx context.Context, job *Job) (*jobResult, error) {
+	opts := &pb.ImportOptions{}
+	if err := proto.Unmarshal(job.Request, opts); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid job request: %v", err)
+	}
+
+	bucket, err := jobFiles(r.jobsCollection.Database())
+	if err != nil {
+		return nil, err
+	}
+	input, err := bucket.OpenDownloadStream(job.InputFileID)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "The uploaded file is gone")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to open uploaded file: %v", err)
+	}
+	defer input.Close()
+
+	file, err := r.employees.openImport(ctx, bufio.NewReader(input), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if job.Total == 0 {
+		total, err := countImportRows(bucket, job.InputFileID, file)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"total": total}}); err != nil {
+			return nil, err
+		}
+	}
+
+	s := r.employees
+	report := newImportReport(opts, file)
+	failed := func(result *pb.ImportRowResult) {
+		if len(report.Rows) < maxJobFailedRows {
+			report.Rows = append(report.Rows, result)
+		}
+	}
+
+	if !opts.GetDryRun() {
+		cp := job.Checkpoint
+		report.Created, report.Updated, report.Failed = cp.Created, cp.Updated, cp.Failed
+		report.TotalRows = cp.Created + cp.Updated + cp.Failed
+		for _, row := range cp.FailedRows {
+			report.Rows = append(report.Rows, &pb.ImportRowResult{
+				Line: row.Line, Action: importError, Email: row.Email, Errors: row.Errors,
+			})
+		}
+
+		// Rows that are saved record themselves as part of the save
+		save := func(ctx context.Context, result *pb.ImportRowResult, write importWrite) (*pb.Employee, error) {
+			return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Employee, error) {
+				emp, err := write(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return emp, r.recordImportRow(ctx, job.ID, result)
+			})
+		}
+		tally := func(result *pb.ImportRowResult) error {
+			countImportRow(report, result)
+			if result.Action != importError {
+				return nil
+			}
+			failed(result)
+			return r.recordImportRow(ctx, job.ID, result)
+		}
+		err = file.importRows(cp.Line, func(line int32, record []string) *pb.ImportRowResult {
+			return s.importRow(ctx, line, file.columns, record, opts, nil, save)
+		}, tally)
+	} else {
+		if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": 0}}); err != nil {
+			return nil, err
+		}
+		saved := time.Now()
+		tally := func(result *pb.ImportRowResult) error {
+			countImportRow(report, result)
+			if result.Action == importError {
+				failed(result)
+			}
+			if time.Since(saved) < jobProgressInterval {
+				return nil
+			}
+			saved = time.Now()
+			return r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": int64(report.TotalRows)}})
+		}
+		earlier := map[string]bool{}
+		err = file.importRows(0, func(line int32, record []string) *pb.ImportRowResult {
+			return s.importRow(ctx, line, file.columns, record, opts, earlier, s.abortedSave)
+		}, tally)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &jobResult{response: report}
+	if file.book != nil {
+		workbook, err := file.book.annotate(report.Rows)
+		if err != nil {
+			return nil, err
+		}
+		result.file, err = r.saveFile(ctx, job.ID, "import-checked.xlsx", xlsxContentType, func(w io.Writer) error {
+			_, err := w.Write(workbook)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// recordImportRow moves the checkpoint of an import job past a row
+func (r *jobRunner) recordImportRow(ctx context.Context, id primitive.ObjectID, result *pb.ImportRowResult) error {
+	var counter string
+	switch result.Action {
+	case importCreate:
+		counter = "checkpoint.created"
+	case importUpdate:
+		counter = "checkpoint.updated"
+	default:
+		counter = "checkpoint.failed"
+	}
+	update := bson.M{
+		"$set": bson.M{"checkpoint.line": result.Line},
+		"$inc": bson.M{"processed": 1, counter: 1},
+	}
+	if result.Action == importError {
+		row := ImportFailedRow{Line: result.Line, Email: result.Email, Errors: result.Errors}
+		update["$push"] = bson.M{"checkpoint.failed_rows": bson.M{
+			"$each":  bson.A{row},
+			"$slice": maxJobFailedRows,
+		}}
+	}
+	return r.progress(ctx, id, update)
+}
+
+// countImportRows estimates the data rows of an uploaded file: the lines of
+// a CSV file or the rows of a sheet, less the header
+func countImportRows(bucket *gridfs.Bucket, id primitive.ObjectID, file *importFile) (int64, error) {
+	if file.book != nil {
+		return max(file.book.rowCount()-int64(file.book.headerLine), 0), nil
+	}
+
+	input, err := bucket.OpenDownloadStream(id)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to open uploaded file: %v", err)
+	}
+	defer input.Close()
+
+	var lines int64
+	last := byte('\n')
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			lines += int64(bytes.Count(buf[:n], []byte{'\n'}))
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, status.Errorf(codes.Internal, "Failed to read uploaded file: %v", err)
+		}
+	}
+	if last != '\n' {
+		lines++
+	}
+	return max(lines-1, 0), nil
+}
+
+// runExport writes an export file. An interrupted export starts over.
+func (r *jobRunner) runExport(ctx context.Context, job *Job) (*jobResult, error) {
+	req := &pb.ExportEmployeesRequest{}
+	if err := proto.Unmarshal(job.Request, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid job request: %v", err)
+	}
+	_, format, err := exportFormatOf(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The total is unknown for exports as of a date, which count versions
+	var total int64
+	if req.GetAsOf() == nil {
+		filter, err := r.employees.exportFilter(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		total, err = r.employees.employeesCollection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+		}
+	}
+	if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": 0, "total": total}}); err != nil {
+		return nil, err
+	}
+
+	var rows int64
+	saved := time.Now()
+	file, err := r.saveFile(ctx, job.ID, exportFilename(req), format.contentType, func(w io.Writer) error {
+		rows, err = r.employees.writeExport(ctx, req, w, func(rows int64) error {
+			if time.Since(saved) < jobProgressInterval {
+				return nil
+			}
+			saved = time.Now()
+			return r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": rows}})
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": rows}}); err != nil {
+		return nil, err
+	}
+
+	return &jobResult{
+		response: &pb.ExportJobResult{
+			FileName:    file.Name,
+			ContentType: file.ContentType,
+			SizeBytes:   file.Size,
+			Rows:        rows,
+		},
+		file: file,
+	}, nil
+}
+
+// runReindex copies department names and job titles onto the employees that
+// refer to them, in ID order, saving the last employee done as it goes. Each
+// employee is fixed in one transaction, as a revision with its event.
+func (r *jobRunner) runReindex(ctx context.Context, job *Job) (*jobResult, error) {
+	req := &pb.ReindexRequest{}
+	if err := proto.Unmarshal(job.Request, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid job request: %v", err)
+	}
+	s := r.employees
+
+	departments, err := namesByID(ctx, s.departmentsCollection, "name")
+	if err != nil {
+		return nil, err
+	}
+	positions, err := namesByID(ctx, s.positionsCollection, "title")
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Total == 0 {
+		total, err := s.employeesCollection.CountDocuments(ctx, bson.M{})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to count employees: %v", err)
+		}
+		if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"total": total}}); err != nil {
+			return nil, err
+		}
+	}
+
+	cp := job.Checkpoint
+	filter := bson.M{}
+	if !cp.LastID.IsZero() {
+		filter["_id"] = bson.M{"$gt": cp.LastID}
+	}
+	cursor, err := s.employeesCollection.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"department": 1, "department_id": 1, "position": 1, "position_id": 1}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	checked, changed := job.Processed, cp.Changed
+	saved := time.Now()
+	for cursor.Next(ctx) {
+		var emp Employee
+		if err := cursor.Decode(&emp); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+		}
+
+		// Names are fixed in the employee and in its current and future
+		// versions that point at the same record, so syncCurrent keeps them
+		var fixes []struct{ filter, set bson.M }
+		if name, ok := departments[emp.DepartmentID]; ok && name != emp.Department {
+			fixes = append(fixes, struct{ filter, set bson.M }{
+				bson.M{"_id": emp.ID, "department_id": emp.DepartmentID}, bson.M{"department": name},
+			})
+		}
+		if title, ok := positions[emp.PositionID]; ok && title != emp.Position {
+			fixes = append(fixes, struct{ filter, set bson.M }{
+				bson.M{"_id": emp.ID, "position_id": emp.PositionID}, bson.M{"position": title},
+			})
+		}
+		if len(fixes) > 0 {
+			changed++
+			if !req.GetDryRun() {
+				_, err := inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (int64, error) {
+					for _, f := range fixes {
+						if _, err := s.setEmployeeFields(ctx, f.filter, f.set); err != nil {
+							return 0, err
+						}
+					}
+					return 0, nil
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		checked++
+
+		if time.Since(saved) >= jobProgressInterval {
+			saved = time.Now()
+			err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{
+				"processed":          checked,
+				"checkpoint.last_id": emp.ID,
+				"checkpoint.changed": changed,
+			}})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Cursor error: %v", err)
+	}
+	if err := r.progress(ctx, job.ID, bson.M{"$set": bson.M{"processed": checked}}); err != nil {
+		return nil, err
+	}
+
+	// The search indexes of this server are built again from scratch; the
+	// others see the updates through the change stream
+	if !req.GetDryRun() {
+		if err := loadEmployeeIndexes(ctx, s.employeesCollection, s.search, s.suggest); err != nil {
+			return nil, err
+		}
+	}
+
+	return &jobResult{response: &pb.ReindexResult{
+		DryRun:           req.GetDryRun(),
+		EmployeesChecked: checked,
+		EmployeesUpdated: changed,
+	}}, nil
+}
+
+// namesByID maps the IDs of a collection to one of their string fields
+func namesByID(ctx context.Context, coll *mongo.Collection, field string) (map[primitive.ObjectID]string, error) {
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{field: 1}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve %s: %v", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	names := make(map[primitive.ObjectID]string)
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode %s: %v", coll.Name(), err)
+		}
+		id, _ := doc["_id"].(primitive.ObjectID)
+		name, _ := doc[field].(string)
+		names[id] = name
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Cursor error: %v", err)
+	}
+	return names, nil
+}
+
+// saveFile stores the file a job produces under the job ID, replacing what
+// an earlier attempt left
+func (r *jobRunner) saveFile(ctx context.Context, id primitive.ObjectID, name, contentType string, write func(w io.Writer) error) (*JobFile, error) {
+	bucket, err := jobFiles(r.jobsCollection.Database())
+	if err != nil {
+		return nil, err
+	}
+	if err := bucket.DeleteContext(ctx, id); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, status.Errorf(codes.Internal, "Failed to replace job file: %v", err)
+	}
+
+	upload, err := bucket.OpenUploadStreamWithID(id, name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to store job file: %v", err)
+	}
+	out := &countingWriter{w: upload}
+	if err := write(out); err != nil {
+		upload.Abort()
+		return nil, err
+	}
+	if err := upload.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to store job file: %v", err)
+	}
+	return &JobFile{Name: name, ContentType: contentType, Size: out.n}, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// jobImportHandler serves POST /v1/jobs:import, the multipart form of
+// POST /v1/employees:import, and returns the operation
+func jobImportHandler(mux *runtime.ServeMux, client pb.JobServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, pb.JobService_StartImportJob_FullMethodName,
+			runtime.WithHTTPPathPattern("/v1/jobs:import"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		opts, part, err := readImportForm(r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		stream, err := client.StartImportJob(ctx)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		op, err := sendImport(stream, opts, part)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, op)
+	}
+}
+
+// jobFileHandler serves GET /v1/jobs/{id}/file as a file download
+func jobFileHandler(mux *runtime.ServeMux, client pb.JobServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, pb.JobService_DownloadJobFile_FullMethodName,
+			runtime.WithHTTPPathPattern("/v1/jobs/{id}/file"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		var header metadata.MD
+		stream, err := client.DownloadJobFile(ctx, &pb.JobFileRequest{Name: pathParams["id"]}, grpc.Header(&header))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		streamDownload(ctx, mux, outbound, w, r, stream, func() string {
+			if v := header.Get(jobFileNameHeader); len(v) > 0 && v[0] != "" {
+				return v[0]
+			}
+			return "job-file"
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Employment statuses as stored in MongoDB. Documents without a status
+// predate the lifecycle and count as active.
+const (
+	statusCandidate   = "candidate"
+	statusPreBoarding = "pre_boarding"
+	statusActive      = "active"
+	statusOnLeave     = "on_leave"
+	statusSuspended   = "suspended"
+	statusTerminated  = "terminated"
+)
+
+var statusToProto = map[string]pb.EmploymentStatus{
+	"":                pb.EmploymentStatus_ACTIVE,
+	statusCandidate:   pb.EmploymentStatus_CANDIDATE,
+	statusPreBoarding: pb.EmploymentStatus_PRE_BOARDING,
+	statusActive:      pb.EmploymentStatus_ACTIVE,
+	statusOnLeave:     pb.EmploymentStatus_ON_LEAVE,
+	statusSuspended:   pb.EmploymentStatus_SUSPENDED,
+	statusTerminated:  pb.EmploymentStatus_TERMINATED,
+}
+
+var statusFromProto = map[pb.EmploymentStatus]string{
+	pb.EmploymentStatus_CANDIDATE:    statusCandidate,
+	pb.EmploymentStatus_PRE_BOARDING: statusPreBoarding,
+	pb.EmploymentStatus_ACTIVE:       statusActive,
+	pb.EmploymentStatus_ON_LEAVE:     statusOnLeave,
+	pb.EmploymentStatus_SUSPENDED:    statusSuspended,
+	pb.EmploymentStatus_TERMINATED:   statusTerminated,
+}
+
+// notTerminated filters terminated employees out of default listings
+var notTerminated = bson.M{"status": bson.M{"$ne": statusTerminated}}
+
+// EmploymentEvent is one recorded status transition
+type EmploymentEvent struct {
+	From          string    `bson:"from"`
+	To            string    `bson:"to"`
+	EffectiveDate time.Time `bson:"effective_date"`
+	Reason        string    `bson:"reason"`
+	Actor         string    `bson:"actor"`
+	RecordedAt    time.Time `bson:"recorded_at"`
+}
+
+func (e EmploymentEvent) toProto() *pb.EmploymentEvent {
+	return &pb.EmploymentEvent{
+		From:          statusToProto[e.From],
+		To:            statusToProto[e.To],
+		EffectiveDate: timestamppb.New(e.EffectiveDate),
+		Reason:        e.Reason,
+		Actor:         e.Actor,
+		RecordedAt:    timestamppb.New(e.RecordedAt),
+	}
+}
+
+// initialStatus validates the status requested on create
+func initialStatus(st pb.EmploymentStatus) (string, error) {
+	switch st {
+	case pb.EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED, pb.EmploymentStatus_ACTIVE:
+		return statusActive, nil
+	case pb.EmploymentStatus_CANDIDATE, pb.EmploymentStatus_PRE_BOARDING:
+		return statusFromProto[st], nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "New employees must start as CANDIDATE, PRE_BOARDING or ACTIVE")
+	}
+}
+
+// transition is one edge set of the lifecycle state machine
+type transition struct {
+	verb         string
+	from         []string
+	reasonNeeded bool
+	// to picks the target status, which may depend on the effective date
+	to func(effective, now time.Time) string
+}
+
+func always(st string) func(time.Time, time.Time) string {
+	return func(time.Time, time.Time) string { return st }
+}
+
+// startsOn is pre-boarding until the start date, active from then on
+func startsOn(effective, now time.Time) string {
+	if effective.After(now) {
+		return statusPreBoarding
+	}
+	return statusActive
+}
+
+var (
+	hireTransition      = transition{verb: "hire", from: []string{statusCandidate}, to: startsOn}
+	startTransition     = transition{verb: "start", from: []string{statusPreBoarding}, to: always(statusActive)}
+	leaveTransition     = transition{verb: "place on leave", from: []string{statusActive}, to: always(statusOnLeave)}
+	returnTransition    = transition{verb: "return from leave", from: []string{statusOnLeave}, to: always(statusActive)}
+	suspendTransition   = transition{verb: "suspend", from: []string{statusActive, statusOnLeave}, reasonNeeded: true, to: always(statusSuspended)}
+	reinstateTransition = transition{verb: "reinstate", from: []string{statusSuspended}, to: always(statusActive)}
+	terminateTransition = transition{
+		verb:         "terminate",
+		from:         []string{statusPreBoarding, statusActive, statusOnLeave, statusSuspended},
+		reasonNeeded: true,
+		to:           always(statusTerminated),
+	}
+	rehireTransition = transition{verb: "rehire", from: []string{statusTerminated}, to: startsOn}
+)
+
+// HireEmployee
+func (s *server) HireEmployee(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("HireEmployee RPC called")
+	return s.changeEmployment(ctx, req, hireTransition)
+}
+
+// StartEmployment
+func (s *server) StartEmployment(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("StartEmployment RPC called")
+	return s.changeEmployment(ctx, req, startTransition)
+}
+
+// PlaceOnLeave
+func (s *server) PlaceOnLeave(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("PlaceOnLeave RPC called")
+	return s.changeEmployment(ctx, req, leaveTransition)
+}
+
+// ReturnFromLeave
+func (s *server) ReturnFromLeave(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("ReturnFromLeave RPC called")
+	return s.changeEmployment(ctx, req, returnTransition)
+}
+
+// SuspendEmployee
+func (s *server) SuspendEmployee(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("SuspendEmployee RPC called")
+	return s.changeEmployment(ctx, req, suspendTransition)
+}
+
+// ReinstateEmployee
+func (s *server) ReinstateEmployee(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("ReinstateEmployee RPC called")
+	return s.changeEmployment(ctx, req, reinstateTransition)
+}
+
+// TerminateEmployee
+func (s *server) TerminateEmployee(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("TerminateEmployee RPC called")
+	return s.changeEmployment(ctx, req, terminateTransition)
+}
+
+// RehireEmployee
+func (s *server) RehireEmployee(ctx context.Context, req *pb.EmploymentChangeRequest) (*pb.Employee, error) {
+	log.Println("RehireEmployee RPC called")
+	return s.changeEmployment(ctx, req, rehireTransition)
+}
+
+// changeEmployment applies t to the employee in req from the effective date
+// if the status in effect on that date allows it, and records the transition
+// in the status history.
+func (s *server) changeEmployment(ctx context.Context, req *pb.EmploymentChangeRequest, t transition) (*pb.Employee, error) {
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Employee, error) {
+		return s.applyTransition(ctx, req, t)
+	})
+}
+
+func (s *server) applyTransition(ctx context.Context, req *pb.EmploymentChangeRequest, t transition) (*pb.Employee, error) {
+	oid, err := s.requireEmployee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.GetReason())
+	if t.reasonNeeded && reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A reason is required to %s an employee", t.verb)
+	}
+
+	now := time.Now().UTC()
+	effective, err := effectiveDate(req.GetEffectiveDate())
+	if err != nil {
+		return nil, err
+	}
+
+	// The transition must be valid for the status in effect on that date
+	base, err := s.versionAt(ctx, oid, effective)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Employee %s did not exist on %s", req.GetId(), effective.Format(time.RFC3339))
+	}
+	current, err := snapshotToEmployee(base.Employee)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	from := current.Status
+	if from == "" {
+		from = statusActive
+	}
+	allowed := false
+	for _, st := range t.from {
+		allowed = allowed || st == from
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.FailedPrecondition, "Cannot %s an employee who is %s", t.verb, strings.ReplaceAll(from, "_", "-"))
+	}
+
+	target := t.to(effective, now)
+	if target == statusTerminated {
+		reports, err := s.employeesCollection.CountDocuments(ctx, bson.M{"manager_id": oid, "status": bson.M{"$ne": statusTerminated}})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to check direct reports: %v", err)
+		}
+		if reports > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "Employee %s still has %d direct reports", req.GetId(), reports)
+		}
+	}
+
+	change := employeeChange{
+		action: actionStatusChange,
+		set:    bson.M{"status": target},
+		expect: bson.M{"status": current.Status},
+		push: &EmploymentEvent{
+			From:          from,
+			To:            target,
+			EffectiveDate: effective,
+			Reason:        reason,
+			Actor:         actorFromContext(ctx),
+			RecordedAt:    now,
+		},
+	}
+	switch t.verb {
+	case hireTransition.verb, rehireTransition.verb:
+		change.set["hire_date"] = effective
+		change.unset = []string{"termination_date"}
+	case terminateTransition.verb:
+		change.set["termination_date"] = effective
+	}
+
+	snapshot, err := s.recordChange(ctx, oid, effective, change)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := snapshotToEmployee(snapshot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+	updated.ID = oid
+
+	res := updated.toProto()
+	res.EffectiveDate = timestamppb.New(effective)
+	return res, nil
+}
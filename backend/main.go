@@ -5,20 +5,30 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
 	"time"
 
 	pb "EMPLOYEE_APP/backend/pb"
+	lrgw "EMPLOYEE_APP/backend/pb/longrunning"
 
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	startedAt := time.Now()
+	cfg := LoadConfig()
+	if cfg.AuditHMACKey == "" {
+		log.Fatalf("AUDIT_HMAC_KEY must be set to key the audit log")
+	}
+
 	// MongoDB connection
-	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://mongo-service.employee-app.svc.cluster.local:27017"))
+	client, err := mongo.NewClient(options.Client().ApplyURI(cfg.MongoURI))
 	if err != nil {
 		log.Fatalf("Failed to create MongoDB client: %v", err)
 	}
@@ -32,40 +42,167 @@ func main() {
 	}
 	defer client.Disconnect(ctx)
 
-	// Get a handle for the employees collection
-	db := client.Database("employee_db")
-	employeesCollection := db.Collection("employees")
+	// Maintenance commands run against a tenant's database and exit
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), cfg, client, os.Args[1:]); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
+	// Every tenant has a database of its own, served by its own set of
+	// servers; the default tenant's is the configured database
+	tenants, err := newTenantRegistry(cfg, client)
+	if err != nil {
+		log.Fatalf("Invalid tenant token settings: %v", err)
+	}
+	if err := tenants.refresh(ctx); err != nil {
+		log.Fatalf("Failed to start tenants: %v", err)
+	}
 
 	// Start gRPC server
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterEmployeeServiceServer(grpcServer, NewServer(employeesCollection))
+	// Audited calls are written to the audit log of their tenant
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(tenants.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(tenants.StreamInterceptor),
+	)
+	pb.RegisterEmployeeServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.employees }), nil)
+	pb.RegisterDepartmentServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.departments }), nil)
+	pb.RegisterPositionServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.positions }), nil)
+	pb.RegisterAuditServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.audits }), nil)
+	pb.RegisterWebhookServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.webhooks }), nil)
+	pb.RegisterAnalyticsServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.analytics }), nil)
+	pb.RegisterCustomFieldServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.customFields }), nil)
+	pb.RegisterJobServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.jobs }), nil)
+	pb.RegisterScimServiceServer(tenants.registrar(grpcServer, func(t *tenantRuntime) interface{} { return t.scim }), nil)
+	longrunningpb.RegisterOperationsServer(grpcServer, newTenantOperations(tenants))
+	pb.RegisterAdminServiceServer(grpcServer, NewAdminServer(cfg, startedAt))
+	pb.RegisterTenantServiceServer(grpcServer, NewTenantServer(tenants))
+
+	if cfg.EnableReflection {
+		reflection.Register(grpcServer)
+		log.Println("gRPC reflection enabled")
+	}
+
+	// Tenants created, changed or deleted on other servers
+	go tenants.run(context.Background(), cfg.TenantRefreshInterval)
 
 	go func() {
-		log.Println("gRPC server running on port 50051...")
+		log.Printf("gRPC server running on %s...", cfg.GRPCAddr)
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("Failed to serve gRPC: %v", err)
 		}
 	}()
 
 	// Start gRPC-Gateway server (REST proxy)
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		// Streaming RPCs such as WatchEmployees as SSE or NDJSON
+		runtime.WithMarshalerOption("text/event-stream", eventStreamMarshaler{streamJSON()}),
+		runtime.WithMarshalerOption("application/x-ndjson", ndjsonMarshaler{streamJSON()}),
+		// Query results as CSV
+		runtime.WithMarshalerOption("text/csv", csvMarshaler{streamJSON()}),
+		// SCIM status codes and errors
+		runtime.WithForwardResponseOption(scimForwardResponse),
+		runtime.WithErrorHandler(scimErrorHandler),
+	)
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	err = pb.RegisterEmployeeServiceHandlerFromEndpoint(
 		context.Background(),
 		mux,
-		":50051", // ✅ Use container-local port, not localhost
-		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		cfg.GRPCAddr, // ✅ Use container-local port, not localhost
+		opts,
 	)
 	if err != nil {
 		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
 	}
+	err = pb.RegisterAdminServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterDepartmentServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterPositionServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterAuditServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterWebhookServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterAnalyticsServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterCustomFieldServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterJobServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterTenantServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = pb.RegisterScimServiceHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+	err = lrgw.RegisterOperationsHandlerFromEndpoint(context.Background(), mux, cfg.GRPCAddr, opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC-Gateway: %v", err)
+	}
+
+	// CSV uploads are multipart, which the generated handlers cannot take, so
+	// they go through a gRPC client of our own
+	conn, err := grpc.NewClient(cfg.GRPCAddr, opts...)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC client: %v", err)
+	}
+	defer conn.Close()
+	err = mux.HandlePath(http.MethodPost, "/v1/employees:import", importHandler(mux, pb.NewEmployeeServiceClient(conn)))
+	if err != nil {
+		log.Fatalf("Failed to register import handler: %v", err)
+	}
+	err = mux.HandlePath(http.MethodGet, "/v1/employees:export", exportHandler(mux, pb.NewEmployeeServiceClient(conn)))
+	if err != nil {
+		log.Fatalf("Failed to register export handler: %v", err)
+	}
+	err = mux.HandlePath(http.MethodPost, "/v1/jobs:import", jobImportHandler(mux, pb.NewJobServiceClient(conn)))
+	if err != nil {
+		log.Fatalf("Failed to register job import handler: %v", err)
+	}
+	err = mux.HandlePath(http.MethodGet, "/v1/jobs/{id}/file", jobFileHandler(mux, pb.NewJobServiceClient(conn)))
+	if err != nil {
+		log.Fatalf("Failed to register job file handler: %v", err)
+	}
+
+	httpMux := http.NewServeMux()
+	if cfg.EnableDocs {
+		// API docs are served next to the gateway
+		httpMux.HandleFunc("/openapi.json", openAPIHandler)
+		httpMux.Handle("/docs", swaggerUIHandler())
+		httpMux.Handle("/docs/", swaggerUIHandler())
+	}
+	// SCIM provisioning for identity providers
+	httpMux.Handle(scimBasePath+"/", scimHandler(mux))
+	httpMux.Handle("/", mux)
 
-	log.Println("HTTP gateway running on port 8080...")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+	log.Printf("HTTP gateway running on %s...", cfg.HTTPAddr)
+	if err := http.ListenAndServe(cfg.HTTPAddr, httpMux); err != nil {
 		log.Fatalf("Failed to serve HTTP: %v", err)
 	}
 }
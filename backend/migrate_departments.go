@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// spellingGroup is one department or position and every free-text spelling
+// of it employees had
+type spellingGroup struct {
+	key      string
+	variants map[string]int64 // spelling -> employee count
+}
+
+func (g *spellingGroup) total() int64 {
+	var n int64
+	for _, c := range g.variants {
+		n += c
+	}
+	return n
+}
+
+// canonicalName picks the most used spelling, preferring the longer one on
+// a tie so "Engineering" wins over "Eng".
+func (g *spellingGroup) canonicalName() string {
+	var best string
+	var bestCount int64 = -1
+	for v, c := range g.variants {
+		if c > bestCount || (c == bestCount && len(v) > len(best)) || (c == bestCount && len(v) == len(best) && v < best) {
+			best, bestCount = v, c
+		}
+	}
+	return best
+}
+
+// normalizeDepartmentKey folds case, punctuation and spacing so that
+// "Engineering", "engineering " and "ENGINEERING" compare equal.
+func normalizeDepartmentKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// migrateDepartments turns the free-text department values on employees into
+// department records and points each employee at its record. Spellings that
+// normalize to the same key are merged. aliases maps extra spellings onto a
+// department name for cases the heuristics cannot catch. Unambiguous
+// abbreviations ("Eng" into "Engineering") are only merged with foldPrefixes
+// set, since a short key can prefix an unrelated name ("IT" and "Italy Ops");
+// otherwise they are logged so they can be checked and passed as aliases.
+// Each department is created and linked in one transaction, which also
+// updates the employee versions in effect now or later, so the link survives
+// later versioned changes, and records a revision for every employee linked.
+// With dryRun set the plan is only logged.
+func migrateDepartments(ctx context.Context, s *server, aliases map[string]string, foldPrefixes, dryRun bool) error {
+	cursor, err := s.employeesCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"department_id": bson.M{"$exists": false},
+			"department":    bson.M{"$nin": bson.A{"", nil}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$department", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to group departments: %w", err)
+	}
+	var rows []struct {
+		Name  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return fmt.Errorf("failed to decode departments: %w", err)
+	}
+
+	aliasKeys := make(map[string]string, len(aliases))
+	for from, to := range aliases {
+		aliasKeys[normalizeDepartmentKey(from)] = normalizeDepartmentKey(to)
+	}
+
+	groups := map[string]*spellingGroup{}
+	for _, row := range rows {
+		key := normalizeDepartmentKey(row.Name)
+		if key == "" {
+			continue
+		}
+		if to, ok := aliasKeys[key]; ok {
+			key = to
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &spellingGroup{key: key, variants: map[string]int64{}}
+			groups[key] = g
+		}
+		g.variants[row.Name] += row.Count
+	}
+
+	// Abbreviations fold into the single longer key they prefix
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	into := map[string]string{}
+	for _, key := range keys {
+		var target string
+		matches := 0
+		for _, other := range keys {
+			if other != key && len(key) >= 2 && strings.HasPrefix(other, key) {
+				target = other
+				matches++
+			}
+		}
+		if matches == 1 {
+			into[key] = target
+		}
+	}
+	for _, key := range keys {
+		target, ok := into[key]
+		if !ok {
+			continue
+		}
+		for next, ok := into[target]; ok; next, ok = into[target] {
+			target = next
+		}
+		if !foldPrefixes {
+			log.Printf("possible abbreviation %q of %q, not merged (use -fold-prefixes or -alias)", groups[key].canonicalName(), groups[target].canonicalName())
+			continue
+		}
+		log.Printf("fold %q into %q", groups[key].canonicalName(), groups[target].canonicalName())
+		for v, c := range groups[key].variants {
+			groups[target].variants[v] += c
+		}
+		delete(groups, key)
+	}
+
+	// Existing records win over new ones
+	existing := map[string]Department{}
+	usedCodes := map[string]bool{}
+	cursor, err = s.departmentsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to load departments: %w", err)
+	}
+	var current []Department
+	if err := cursor.All(ctx, &current); err != nil {
+		return fmt.Errorf("failed to decode departments: %w", err)
+	}
+	for _, d := range current {
+		existing[normalizeDepartmentKey(d.Name)] = d
+		existing[normalizeDepartmentKey(d.Code)] = d
+		usedCodes[d.Code] = true
+	}
+
+	keys = keys[:0]
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		g := groups[key]
+		dept, found := existing[key]
+		if !found {
+			dept = Department{Name: g.canonicalName()}
+			dept.Code = uniqueDepartmentCode(dept.Name, usedCodes)
+		}
+
+		variants := make([]string, 0, len(g.variants))
+		for v := range g.variants {
+			variants = append(variants, v)
+		}
+		sort.Strings(variants)
+
+		action := "create"
+		if found {
+			action = "reuse"
+		}
+		log.Printf("%s department %s (%s): %d employees from %q", action, dept.Name, dept.Code, g.total(), variants)
+		if dryRun {
+			continue
+		}
+
+		_, err := inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (int64, error) {
+			if !found {
+				res, err := s.departmentsCollection.InsertOne(ctx, dept)
+				if err != nil {
+					return 0, fmt.Errorf("failed to create department %s: %w", dept.Name, err)
+				}
+				dept.ID = res.InsertedID.(primitive.ObjectID)
+			}
+			return s.setEmployeeFields(ctx,
+				bson.M{"department": bson.M{"$in": variants}, "department_id": bson.M{"$exists": false}},
+				bson.M{"department_id": dept.ID, "department": dept.Name},
+			)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update employees of %s: %w", dept.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// uniqueDepartmentCode derives a short code from a department name: the
+// initials for multi-word names, the first three letters otherwise.
+func uniqueDepartmentCode(name string, used map[string]bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var base string
+	if len(words) > 1 {
+		for _, w := range words {
+			base += string([]rune(w)[0])
+		}
+	} else if len(words) == 1 {
+		r := []rune(words[0])
+		if len(r) > 3 {
+			r = r[:3]
+		}
+		base = string(r)
+	}
+	base = strings.ToUpper(base)
+	if base == "" {
+		base = "DEPT"
+	}
+
+	code := base
+	for i := 2; used[code]; i++ {
+		code = fmt.Sprintf("%s%d", base, i)
+	}
+	used[code] = true
+	return code
+}
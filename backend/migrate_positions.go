@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migratePositions backfills the position catalog from the free-text job
+// titles employees had before positions existed, and points each employee at
+// its position. Titles are matched case-insensitively, the way
+// resolvePosition matches them. A title with no position gets a new one in
+// jobFamily at level 0 to be filled in later; a title that matches several
+// positions is logged and left alone. Each position is created and linked in
+// one transaction that also updates the employee versions in effect now or
+// later and records a revision for every employee linked. With dryRun set
+// the plan is only logged.
+func migratePositions(ctx context.Context, s *server, jobFamily string, dryRun bool) error {
+	cursor, err := s.employeesCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"position_id": bson.M{"$exists": false},
+			"position":    bson.M{"$nin": bson.A{"", nil}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$position", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to group positions: %w", err)
+	}
+	var rows []struct {
+		Title string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return fmt.Errorf("failed to decode positions: %w", err)
+	}
+
+	groups := map[string]*spellingGroup{}
+	for _, row := range rows {
+		key := strings.ToLower(strings.TrimSpace(row.Title))
+		if key == "" {
+			continue
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &spellingGroup{key: key, variants: map[string]int64{}}
+			groups[key] = g
+		}
+		g.variants[row.Title] += row.Count
+	}
+
+	existing := map[string][]Position{}
+	cursor, err = s.positionsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to load positions: %w", err)
+	}
+	var current []Position
+	if err := cursor.All(ctx, &current); err != nil {
+		return fmt.Errorf("failed to decode positions: %w", err)
+	}
+	for _, p := range current {
+		key := strings.ToLower(p.Title)
+		existing[key] = append(existing[key], p)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		g := groups[key]
+		variants := make([]string, 0, len(g.variants))
+		for v := range g.variants {
+			variants = append(variants, v)
+		}
+		sort.Strings(variants)
+
+		var pos Position
+		action := "create"
+		switch matches := existing[key]; len(matches) {
+		case 0:
+			pos = Position{Title: strings.TrimSpace(g.canonicalName()), JobFamily: jobFamily}
+		case 1:
+			pos, action = matches[0], "reuse"
+		default:
+			log.Printf("skip title %q: %d employees, matches %d positions", g.canonicalName(), g.total(), len(matches))
+			continue
+		}
+		log.Printf("%s position %s (%s, level %d): %d employees from %q", action, pos.Title, pos.JobFamily, pos.Level, g.total(), variants)
+		if dryRun {
+			continue
+		}
+
+		_, err := inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (int64, error) {
+			if action == "create" {
+				res, err := s.positionsCollection.InsertOne(ctx, pos)
+				if err != nil {
+					return 0, fmt.Errorf("failed to create position %s: %w", pos.Title, err)
+				}
+				pos.ID = res.InsertedID.(primitive.ObjectID)
+			}
+			return s.setEmployeeFields(ctx,
+				bson.M{"position": bson.M{"$in": variants}, "position_id": bson.M{"$exists": false}},
+				bson.M{"position_id": pos.ID, "position": pos.Title},
+			)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update employees of %s: %w", pos.Title, err)
+		}
+	}
+
+	return nil
+}
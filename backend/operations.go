@@ -0,0 +1,741 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Job kinds
+const (
+	jobImport  = "import"
+	jobExport  = "export"
+	jobReindex = "reindex"
+)
+
+// Job states
+const (
+	jobPending   = "pending"
+	jobRunning   = "running"
+	jobSucceeded = "succeeded"
+	jobFailed    = "failed"
+	jobCancelled = "cancelled"
+)
+
+var jobStateToProto = map[string]pb.JobState{
+	jobPending:   pb.JobState_JOB_PENDING,
+	jobRunning:   pb.JobState_JOB_RUNNING,
+	jobSucceeded: pb.JobState_JOB_SUCCEEDED,
+	jobFailed:    pb.JobState_JOB_FAILED,
+	jobCancelled: pb.JobState_JOB_CANCELLED,
+}
+
+// operationPrefix starts the name of every operation
+const operationPrefix = "operations/"
+
+// jobProgressInterval is how often a running job saves its progress when it
+// has no other reason to
+const jobProgressInterval = 2 * time.Second
+
+// maxOperationWait caps how long WaitOperation waits
+const maxOperationWait = time.Minute
+
+// Job is a background job, which clients follow as a long-running operation
+type Job struct {
+	ID    primitive.ObjectID `bson:"_id,omitempty"`
+	Kind  string             `bson:"kind"`
+	State string             `bson:"state"`
+	Actor string             `bson:"actor"`
+	// The start request, protobuf encoded
+	Request []byte `bson:"request"`
+	// The uploaded file of an import, in GridFS
+	InputFileID primitive.ObjectID `bson:"input_file_id,omitempty"`
+	// The file the job produced, in GridFS under the job ID
+	File *JobFile `bson:"file,omitempty"`
+
+	Processed  int64         `bson:"processed"`
+	Total      int64         `bson:"total"`
+	Checkpoint JobCheckpoint `bson:"checkpoint"`
+
+	CancelRequested bool  `bson:"cancel_requested"`
+	Attempts        int32 `bson:"attempts"`
+	// The worker running the job, which holds it until the lease runs out
+	Worker     string     `bson:"worker,omitempty"`
+	LeaseUntil *time.Time `bson:"lease_until,omitempty"`
+
+	// The response of a job that succeeded, as an encoded google.protobuf.Any
+	Result       []byte `bson:"result,omitempty"`
+	ErrorCode    int32  `bson:"error_code,omitempty"`
+	ErrorMessage string `bson:"error_message,omitempty"`
+
+	CreatedAt time.Time  `bson:"created_at"`
+	StartedAt *time.Time `bson:"started_at,omitempty"`
+	UpdatedAt time.Time  `bson:"updated_at"`
+	EndedAt   *time.Time `bson:"ended_at,omitempty"`
+}
+
+type JobFile struct {
+	Name        string `bson:"name"`
+	ContentType string `bson:"content_type"`
+	Size        int64  `bson:"size"`
+}
+
+// JobCheckpoint is how far a job got, for it to resume from
+type JobCheckpoint struct {
+	// Imports: the last row done and the rows so far
+	Line       int32             `bson:"line"`
+	Created    int32             `bson:"created"`
+	Updated    int32             `bson:"updated"`
+	Failed     int32             `bson:"failed"`
+	FailedRows []ImportFailedRow `bson:"failed_rows,omitempty"`
+	// Reindexes: the last employee done and how many were out of date
+	LastID  primitive.ObjectID `bson:"last_id,omitempty"`
+	Changed int64              `bson:"changed"`
+}
+
+type ImportFailedRow struct {
+	Line   int32    `bson:"line"`
+	Email  string   `bson:"email"`
+	Errors []string `bson:"errors"`
+}
+
+func (j Job) done() bool {
+	return j.State == jobSucceeded || j.State == jobFailed || j.State == jobCancelled
+}
+
+func (j Job) operationName() string {
+	return operationPrefix + j.ID.Hex()
+}
+
+func (j Job) toOperation() (*longrunningpb.Operation, error) {
+	meta := &pb.JobMetadata{
+		Kind:            j.Kind,
+		State:           jobStateToProto[j.State],
+		Processed:       j.Processed,
+		Total:           j.Total,
+		Attempts:        j.Attempts,
+		CancelRequested: j.CancelRequested,
+		Actor:           j.Actor,
+		CreateTime:      timestamppb.New(j.CreatedAt),
+		UpdateTime:      timestamppb.New(j.UpdatedAt),
+	}
+	switch {
+	case j.State == jobSucceeded:
+		meta.PercentComplete = 100
+	case j.Total > 0:
+		meta.PercentComplete = int32(min(j.Processed*100/j.Total, 99))
+	}
+	if j.StartedAt != nil {
+		meta.StartTime = timestamppb.New(*j.StartedAt)
+	}
+	if j.EndedAt != nil {
+		meta.EndTime = timestamppb.New(*j.EndedAt)
+	}
+	if j.File != nil {
+		meta.FileUri = "/v1/jobs/" + j.ID.Hex() + "/file"
+	}
+
+	metadata, err := anypb.New(meta)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode job metadata: %v", err)
+	}
+	op := &longrunningpb.Operation{Name: j.operationName(), Metadata: metadata, Done: j.done()}
+
+	switch j.State {
+	case jobSucceeded:
+		response := &anypb.Any{}
+		if err := proto.Unmarshal(j.Result, response); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode job result: %v", err)
+		}
+		op.Result = &longrunningpb.Operation_Response{Response: response}
+	case jobFailed, jobCancelled:
+		op.Result = &longrunningpb.Operation_Error{
+			Error: status.New(codes.Code(j.ErrorCode), j.ErrorMessage).Proto(),
+		}
+	}
+	return op, nil
+}
+
+// jobFiles is the GridFS bucket of uploaded and produced job files. Buckets
+// are not safe for concurrent use, so each use opens its own.
+func jobFiles(db *mongo.Database) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName("job_files"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to open job files: %v", err)
+	}
+	return bucket, nil
+}
+
+// findJob looks a job up by operation name or ID
+func findJob(ctx context.Context, jobs *mongo.Collection, name string) (*Job, error) {
+	oid, err := primitive.ObjectIDFromHex(strings.TrimPrefix(name, operationPrefix))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid operation name: %s", name)
+	}
+
+	var job Job
+	err = jobs.FindOne(ctx, bson.M{"_id": oid}).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Operation not found: %s", name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve operation: %v", err)
+	}
+	return &job, nil
+}
+
+// operationsServer is google.longrunning.Operations over the stored jobs
+type operationsServer struct {
+	longrunningpb.UnimplementedOperationsServer
+	jobsCollection *mongo.Collection
+	runner         *jobRunner
+}
+
+func NewOperationsServer(jobs *mongo.Collection, runner *jobRunner) longrunningpb.OperationsServer {
+	return &operationsServer{jobsCollection: jobs, runner: runner}
+}
+
+// ListOperations lists jobs, newest first. The filter takes kind=, state=
+// and done= terms joined by AND.
+func (s *operationsServer) ListOperations(ctx context.Context, req *longrunningpb.ListOperationsRequest) (*longrunningpb.ListOperationsResponse, error) {
+	log.Println("ListOperations RPC called")
+
+	if name := strings.TrimSuffix(req.GetName(), "/"); name != "" && name != "operations" {
+		return nil, status.Errorf(codes.NotFound, "Unknown operations collection: %s", req.GetName())
+	}
+	filter, err := operationFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPageToken() != "" {
+		before, err := primitive.ObjectIDFromHex(req.GetPageToken())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+		filter["_id"] = bson.M{"$lt": before}
+	}
+
+	pageSize := int64(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	cursor, err := s.jobsCollection.Find(ctx, filter,
+		options.Find().SetSort(bson.M{"_id": -1}).SetLimit(pageSize+1),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve operations: %v", err)
+	}
+	var jobs []Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode operation: %v", err)
+	}
+
+	resp := &longrunningpb.ListOperationsResponse{}
+	if int64(len(jobs)) > pageSize {
+		jobs = jobs[:pageSize]
+		resp.NextPageToken = jobs[len(jobs)-1].ID.Hex()
+	}
+	for _, j := range jobs {
+		op, err := j.toOperation()
+		if err != nil {
+			return nil, err
+		}
+		resp.Operations = append(resp.Operations, op)
+	}
+	return resp, nil
+}
+
+var operationFilterSpaces = regexp.MustCompile(`\s*=\s*`)
+
+// operationFilter parses a ListOperations filter such as
+// "kind=import AND done=false"
+func operationFilter(expr string) (bson.M, error) {
+	filter := bson.M{}
+	for _, term := range strings.Fields(operationFilterSpaces.ReplaceAllString(expr, "=")) {
+		if strings.EqualFold(term, "AND") {
+			continue
+		}
+		key, value, ok := strings.Cut(term, "=")
+		value = strings.Trim(value, `"`)
+		if !ok || value == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid filter term: %s", term)
+		}
+
+		switch strings.ToLower(key) {
+		case "kind":
+			filter["kind"] = strings.ToLower(value)
+		case "state":
+			state := strings.TrimPrefix(strings.ToLower(value), "job_")
+			if _, ok := jobStateToProto[state]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "Unknown job state: %s", value)
+			}
+			filter["state"] = state
+		case "done":
+			done, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid done: %v", err)
+			}
+			ended := bson.A{jobSucceeded, jobFailed, jobCancelled}
+			if done {
+				filter["state"] = bson.M{"$in": ended}
+			} else {
+				filter["state"] = bson.M{"$nin": ended}
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown filter field: %s", key)
+		}
+	}
+	return filter, nil
+}
+
+// GetOperation
+func (s *operationsServer) GetOperation(ctx context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	log.Println("GetOperation RPC called")
+
+	job, err := findJob(ctx, s.jobsCollection, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return job.toOperation()
+}
+
+// DeleteOperation removes a finished job and its files
+func (s *operationsServer) DeleteOperation(ctx context.Context, req *longrunningpb.DeleteOperationRequest) (*emptypb.Empty, error) {
+	log.Println("DeleteOperation RPC called")
+
+	job, err := findJob(ctx, s.jobsCollection, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if !job.done() {
+		return nil, status.Errorf(codes.FailedPrecondition, "Operation %s has not finished, cancel it first", job.operationName())
+	}
+
+	bucket, err := jobFiles(s.jobsCollection.Database())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []primitive.ObjectID{job.InputFileID, job.ID} {
+		if id.IsZero() {
+			continue
+		}
+		if err := bucket.DeleteContext(ctx, id); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, status.Errorf(codes.Internal, "Failed to delete job file: %v", err)
+		}
+	}
+
+	// A job that is done no longer changes, so only its state is checked
+	res, err := s.jobsCollection.DeleteOne(ctx, bson.M{"_id": job.ID, "state": job.State})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete operation: %v", err)
+	}
+	if res.DeletedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Operation not found: %s", req.GetName())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CancelOperation stops a job. A pending job is cancelled at once, a running
+// one when its worker notices, which is at once on this server. Cancelling a
+// finished job does nothing.
+func (s *operationsServer) CancelOperation(ctx context.Context, req *longrunningpb.CancelOperationRequest) (*emptypb.Empty, error) {
+	log.Println("CancelOperation RPC called")
+
+	job, err := findJob(ctx, s.jobsCollection, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if job.done() {
+		return &emptypb.Empty{}, nil
+	}
+
+	now := time.Now().UTC()
+	res, err := s.jobsCollection.UpdateOne(ctx,
+		bson.M{"_id": job.ID, "state": jobPending},
+		bson.M{"$set": bson.M{
+			"state":            jobCancelled,
+			"cancel_requested": true,
+			"error_code":       int32(codes.Canceled),
+			"error_message":    "Job was cancelled",
+			"updated_at":       now,
+			"ended_at":         now,
+		}},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to cancel operation: %v", err)
+	}
+	if res.MatchedCount > 0 {
+		return &emptypb.Empty{}, nil
+	}
+
+	// A worker has the job by now
+	_, err = s.jobsCollection.UpdateOne(ctx,
+		bson.M{"_id": job.ID, "state": jobRunning},
+		bson.M{"$set": bson.M{"cancel_requested": true, "updated_at": now}},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to cancel operation: %v", err)
+	}
+	s.runner.cancel(job.ID)
+	return &emptypb.Empty{}, nil
+}
+
+// WaitOperation returns once the job is done or the timeout, at most a
+// minute, has passed
+func (s *operationsServer) WaitOperation(ctx context.Context, req *longrunningpb.WaitOperationRequest) (*longrunningpb.Operation, error) {
+	log.Println("WaitOperation RPC called")
+
+	timeout := maxOperationWait
+	if req.GetTimeout() != nil {
+		if err := req.GetTimeout().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid timeout: %v", err)
+		}
+		timeout = min(req.GetTimeout().AsDuration(), maxOperationWait)
+	}
+	deadline := time.Now().Add(timeout)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		job, err := findJob(ctx, s.jobsCollection, req.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if job.done() || !time.Now().Before(deadline) {
+			return job.toOperation()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+var (
+	// errJobCancelled stops a job that was cancelled
+	errJobCancelled = errors.New("job cancelled")
+	// errJobLeaseLost stops a job that another worker has taken over
+	errJobLeaseLost = errors.New("job lease lost")
+)
+
+// jobResult is what a job that succeeded leaves behind
+type jobResult struct {
+	response proto.Message
+	file     *JobFile
+}
+
+// jobRunner runs the stored jobs. A worker claims a job with a lease, which
+// it renews while the job runs. The jobs of a server that stops, such as in
+// a restart, are claimed again once their leases run out; imports and
+// reindexes resume from their checkpoint, the rest start over. A job claimed
+// more than maxAttempts times fails.
+type jobRunner struct {
+	jobsCollection *mongo.Collection
+	employees      *server
+	// Identifies this server in the leases it takes
+	worker      string
+	lease       time.Duration
+	maxAttempts int32
+	wake        chan struct{}
+
+	mu      sync.Mutex
+	cancels map[primitive.ObjectID]context.CancelCauseFunc
+}
+
+func newJobRunner(jobs *mongo.Collection, employees *server, lease time.Duration, maxAttempts int) *jobRunner {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return &jobRunner{
+		jobsCollection: jobs,
+		employees:      employees,
+		worker:         fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix)),
+		lease:          lease,
+		maxAttempts:    int32(maxAttempts),
+		wake:           make(chan struct{}, 1),
+		cancels:        make(map[primitive.ObjectID]context.CancelCauseFunc),
+	}
+}
+
+// run starts the workers, which look for jobs every interval and as soon as
+// one is created on this server
+func (r *jobRunner) run(ctx context.Context, workers int, interval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx, interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *jobRunner) work(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := r.claim(ctx)
+		if err != nil {
+			log.Printf("Failed to claim job: %v", err)
+		}
+		if job != nil {
+			r.execute(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// notify wakes a worker for a job that was just created
+func (r *jobRunner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// create stores a pending job
+func (r *jobRunner) create(ctx context.Context, kind string, req proto.Message, input primitive.ObjectID) (*Job, error) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode job request: %v", err)
+	}
+
+	now := time.Now().UTC()
+	job := &Job{
+		ID:          primitive.NewObjectID(),
+		Kind:        kind,
+		State:       jobPending,
+		Actor:       actorFromContext(ctx),
+		Request:     data,
+		InputFileID: input,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if _, err := r.jobsCollection.InsertOne(ctx, job); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create job: %v", err)
+	}
+	r.notify()
+	return job, nil
+}
+
+// claim takes the oldest pending job, or a running one whose worker is gone
+func (r *jobRunner) claim(ctx context.Context) (*Job, error) {
+	now := time.Now().UTC()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"state": jobPending},
+		bson.M{"state": jobRunning, "lease_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"state": jobRunning, "worker": r.worker, "lease_until": now.Add(r.lease), "updated_at": now},
+		"$min": bson.M{"started_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job Job
+	err := r.jobsCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// execute runs a claimed job to the end, unless the server stops first
+func (r *jobRunner) execute(ctx context.Context, job *Job) {
+	if job.CancelRequested {
+		r.finish(ctx, job, nil, status.Error(codes.Canceled, "Job was cancelled"))
+		return
+	}
+	if job.Attempts > r.maxAttempts {
+		r.finish(ctx, job, nil, status.Errorf(codes.Aborted, "Job was interrupted %d times", job.Attempts-1))
+		return
+	}
+	log.Printf("Running %s job %s, attempt %d", job.Kind, job.ID.Hex(), job.Attempts)
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	r.mu.Lock()
+	r.cancels[job.ID] = cancel
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.cancels, job.ID)
+		r.mu.Unlock()
+	}()
+	go r.heartbeat(jobCtx, job.ID, cancel)
+
+	// The job makes its changes in the name of whoever started it
+	runCtx := metadata.NewIncomingContext(jobCtx, metadata.Pairs(actorHeader, job.Actor))
+
+	var result *jobResult
+	var err error
+	switch job.Kind {
+	case jobImport:
+		result, err = r.runImport(runCtx, job)
+	case jobExport:
+		result, err = r.runExport(runCtx, job)
+	case jobReindex:
+		result, err = r.runReindex(runCtx, job)
+	default:
+		err = status.Errorf(codes.Internal, "Unknown job kind: %s", job.Kind)
+	}
+
+	cause := context.Cause(jobCtx)
+	switch {
+	case ctx.Err() != nil:
+		// The server is stopping. The job is left to resume after its
+		// lease runs out.
+		return
+	case errors.Is(cause, errJobLeaseLost) || errors.Is(err, errJobLeaseLost):
+		log.Printf("Job %s was taken over by another worker", job.ID.Hex())
+		return
+	case err != nil && errors.Is(cause, errJobCancelled):
+		err = status.Error(codes.Canceled, "Job was cancelled")
+	}
+	r.finish(ctx, job, result, err)
+}
+
+// heartbeat renews the lease of a running job and stops the job when it is
+// cancelled or another worker has taken it over
+func (r *jobRunner) heartbeat(ctx context.Context, id primitive.ObjectID, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(r.lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var job Job
+		err := r.jobsCollection.FindOneAndUpdate(ctx,
+			bson.M{"_id": id, "worker": r.worker, "state": jobRunning},
+			bson.M{"$set": bson.M{"lease_until": time.Now().UTC().Add(r.lease)}},
+			options.FindOneAndUpdate().SetProjection(bson.M{"cancel_requested": 1}),
+		).Decode(&job)
+		switch {
+		case err == mongo.ErrNoDocuments:
+			cancel(errJobLeaseLost)
+			return
+		case err != nil:
+			log.Printf("Failed to renew the lease of job %s: %v", id.Hex(), err)
+		case job.CancelRequested:
+			cancel(errJobCancelled)
+			return
+		}
+	}
+}
+
+// cancel stops a job if it runs on this server
+func (r *jobRunner) cancel(id primitive.ObjectID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.cancels[id]; ok {
+		cancel(errJobCancelled)
+	}
+}
+
+// progress saves how far a job has got. It returns errJobLeaseLost once
+// another worker has taken the job over.
+func (r *jobRunner) progress(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	set, _ := update["$set"].(bson.M)
+	if set == nil {
+		set = bson.M{}
+		update["$set"] = set
+	}
+	set["updated_at"] = time.Now().UTC()
+
+	res, err := r.jobsCollection.UpdateOne(ctx, bson.M{"_id": id, "worker": r.worker}, update)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to save job progress: %v", err)
+	}
+	if res.MatchedCount == 0 {
+		return errJobLeaseLost
+	}
+	return nil
+}
+
+// finish records the outcome of a job
+func (r *jobRunner) finish(ctx context.Context, job *Job, result *jobResult, jobErr error) {
+	now := time.Now().UTC()
+	set := bson.M{"updated_at": now, "ended_at": now}
+	if jobErr == nil {
+		response, err := anypb.New(result.response)
+		var data []byte
+		if err == nil {
+			data, err = proto.Marshal(response)
+		}
+		if err != nil {
+			jobErr = status.Errorf(codes.Internal, "Failed to encode job result: %v", err)
+		} else {
+			set["state"] = jobSucceeded
+			set["result"] = data
+			if result.file != nil {
+				set["file"] = result.file
+			}
+		}
+	}
+	if jobErr != nil {
+		st := status.Convert(jobErr)
+		set["state"] = jobFailed
+		if st.Code() == codes.Canceled {
+			set["state"] = jobCancelled
+		}
+		set["error_code"] = int32(st.Code())
+		set["error_message"] = st.Message()
+	}
+
+	_, err := r.jobsCollection.UpdateOne(ctx,
+		bson.M{"_id": job.ID, "worker": r.worker},
+		bson.M{"$set": set, "$unset": bson.M{"worker": "", "lease_until": ""}},
+	)
+	if err != nil {
+		log.Printf("Failed to finish job %s: %v", job.ID.Hex(), err)
+		return
+	}
+	log.Printf("%s job %s %s", job.Kind, job.ID.Hex(), set["state"])
+}
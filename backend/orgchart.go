@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sort"
+	"strings"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ExportOrgChart
+func (s *server) ExportOrgChart(ctx context.Context, req *pb.OrgChartRequest) (*httpbody.HttpBody, error) {
+	log.Println("ExportOrgChart RPC called")
+
+	format := strings.ToLower(req.GetFormat())
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "dot" && format != "svg" {
+		return nil, status.Errorf(codes.InvalidArgument, "Unsupported org chart format: %s", req.GetFormat())
+	}
+	if req.GetMaxDepth() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_depth must not be negative")
+	}
+
+	employees, err := s.orgChartEmployees(ctx, req.GetRootId(), req.GetMaxDepth())
+	if err != nil {
+		return nil, err
+	}
+	if ref := req.GetDepartment(); ref != "" {
+		dept, err := findDepartment(ctx, s.departmentsCollection, ref)
+		if err != nil {
+			return nil, err
+		}
+		if dept == nil {
+			return nil, status.Errorf(codes.NotFound, "Department not found: %s", ref)
+		}
+
+		filtered := employees[:0]
+		for _, emp := range employees {
+			if emp.DepartmentID == dept.ID {
+				filtered = append(filtered, emp)
+			}
+		}
+		employees = filtered
+	}
+
+	roots := buildOrgChart(employees, int(req.GetMaxDepth()))
+
+	switch format {
+	case "dot":
+		return &httpbody.HttpBody{ContentType: "text/vnd.graphviz", Data: renderOrgChartDOT(roots)}, nil
+	case "svg":
+		return &httpbody.HttpBody{ContentType: "image/svg+xml", Data: renderOrgChartSVG(roots)}, nil
+	default:
+		data, err := protojson.Marshal(&pb.OrgChart{Roots: roots})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to encode org chart: %v", err)
+		}
+		return &httpbody.HttpBody{ContentType: "application/json", Data: data}, nil
+	}
+}
+
+// orgChartEmployees loads either every employee or, when rootHex is set, the
+// root and the people below it down to maxDepth levels.
+func (s *server) orgChartEmployees(ctx context.Context, rootHex string, maxDepth int32) ([]Employee, error) {
+	var cursor *mongo.Cursor
+	var err error
+
+	if rootHex == "" {
+		cursor, err = s.employeesCollection.Find(ctx, notTerminated)
+	} else {
+		var oid primitive.ObjectID
+		oid, err = s.requireEmployee(ctx, rootHex)
+		if err != nil {
+			return nil, err
+		}
+
+		lookup := bson.M{
+			"from":                    s.employeesCollection.Name(),
+			"startWith":               "$_id",
+			"connectFromField":        "_id",
+			"connectToField":          "manager_id",
+			"as":                      "reports",
+			"restrictSearchWithMatch": notTerminated,
+		}
+		if maxDepth > 0 {
+			// graphLookup depth 0 is the direct reports
+			lookup["maxDepth"] = maxDepth - 1
+		}
+
+		cursor, err = s.employeesCollection.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"_id": oid}}},
+			{{Key: "$graphLookup", Value: lookup}},
+			{{Key: "$project", Value: bson.M{"all": bson.M{"$concatArrays": bson.A{bson.A{"$$ROOT"}, "$reports"}}}}},
+			{{Key: "$unwind", Value: "$all"}},
+			{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$all"}}},
+			{{Key: "$unset", Value: "reports"}},
+		})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var employees []Employee
+	if err := cursor.All(ctx, &employees); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	// The requested root heads the chart even if it has a manager
+	if rootHex != "" {
+		for i := range employees {
+			if employees[i].ID.Hex() == rootHex {
+				employees[i].ManagerID = primitive.NilObjectID
+			}
+		}
+	}
+
+	return employees, nil
+}
+
+// buildOrgChart links employees into trees. Anyone whose manager is not in
+// the set becomes a root. maxDepth limits the levels below each root, 0
+// meaning no limit.
+func buildOrgChart(employees []Employee, maxDepth int) []*pb.OrgChartNode {
+	byID := make(map[primitive.ObjectID]Employee, len(employees))
+	for _, emp := range employees {
+		byID[emp.ID] = emp
+	}
+
+	children := make(map[primitive.ObjectID][]Employee)
+	var roots []Employee
+	for _, emp := range employees {
+		if _, ok := byID[emp.ManagerID]; ok && !emp.ManagerID.IsZero() {
+			children[emp.ManagerID] = append(children[emp.ManagerID], emp)
+		} else {
+			roots = append(roots, emp)
+		}
+	}
+
+	visited := make(map[primitive.ObjectID]bool)
+	var build func(emp Employee, depth int) *pb.OrgChartNode
+	build = func(emp Employee, depth int) *pb.OrgChartNode {
+		visited[emp.ID] = true
+		node := &pb.OrgChartNode{Employee: emp.toProto()}
+		if maxDepth > 0 && depth >= maxDepth {
+			return node
+		}
+
+		reports := children[emp.ID]
+		sortByName(reports)
+		for _, r := range reports {
+			if !visited[r.ID] {
+				node.Reports = append(node.Reports, build(r, depth+1))
+			}
+		}
+		return node
+	}
+
+	sortByName(roots)
+	var nodes []*pb.OrgChartNode
+	for _, r := range roots {
+		nodes = append(nodes, build(r, 0))
+	}
+	return nodes
+}
+
+func sortByName(employees []Employee) {
+	sort.Slice(employees, func(i, j int) bool {
+		if employees[i].LastName != employees[j].LastName {
+			return employees[i].LastName < employees[j].LastName
+		}
+		return employees[i].FirstName < employees[j].FirstName
+	})
+}
+
+func displayName(emp *pb.Employee) string {
+	return strings.TrimSpace(emp.GetFirstName() + " " + emp.GetLastName())
+}
+
+// renderOrgChartDOT writes the chart as a Graphviz digraph
+func renderOrgChartDOT(roots []*pb.OrgChartNode) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("digraph orgchart {\n")
+	buf.WriteString("  rankdir=TB;\n")
+	buf.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#f5f7fa\", fontname=\"Helvetica\"];\n")
+
+	var walk func(node *pb.OrgChartNode)
+	walk = func(node *pb.OrgChartNode) {
+		emp := node.GetEmployee()
+		label := displayName(emp)
+		if emp.GetPosition() != "" {
+			label += "\n" + emp.GetPosition()
+		}
+		fmt.Fprintf(&buf, "  %s [label=%s];\n", dotQuote(emp.GetId()), dotQuote(label))
+		for _, r := range node.GetReports() {
+			fmt.Fprintf(&buf, "  %s -> %s;\n", dotQuote(emp.GetId()), dotQuote(r.GetEmployee().GetId()))
+			walk(r)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// SVG layout, in pixels
+const (
+	svgBoxWidth  = 180
+	svgBoxHeight = 50
+	svgHGap      = 20
+	svgVGap      = 40
+	svgMargin    = 20
+	svgMaxChars  = 26
+)
+
+type svgBox struct {
+	node  *pb.OrgChartNode
+	x, y  int
+	boxes []*svgBox
+}
+
+// renderOrgChartSVG draws the chart as a standalone SVG document. Leaves are
+// laid out left to right and each manager is centred over its reports.
+func renderOrgChartSVG(roots []*pb.OrgChartNode) []byte {
+	nextLeaf, deepest := 0, 0
+
+	var layout func(node *pb.OrgChartNode, depth int) *svgBox
+	layout = func(node *pb.OrgChartNode, depth int) *svgBox {
+		b := &svgBox{node: node, y: svgMargin + depth*(svgBoxHeight+svgVGap)}
+		if depth > deepest {
+			deepest = depth
+		}
+		for _, r := range node.GetReports() {
+			b.boxes = append(b.boxes, layout(r, depth+1))
+		}
+		if len(b.boxes) == 0 {
+			b.x = svgMargin + nextLeaf*(svgBoxWidth+svgHGap)
+			nextLeaf++
+		} else {
+			b.x = (b.boxes[0].x + b.boxes[len(b.boxes)-1].x) / 2
+		}
+		return b
+	}
+
+	var tops []*svgBox
+	for _, root := range roots {
+		tops = append(tops, layout(root, 0))
+	}
+
+	width := 2 * svgMargin
+	height := 2 * svgMargin
+	if nextLeaf > 0 {
+		width += nextLeaf*(svgBoxWidth+svgHGap) - svgHGap
+		height += (deepest+1)*(svgBoxHeight+svgVGap) - svgVGap
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n", width, height, width, height)
+	buf.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+
+	var draw func(b *svgBox)
+	draw = func(b *svgBox) {
+		for _, c := range b.boxes {
+			midY := b.y + svgBoxHeight + svgVGap/2
+			fmt.Fprintf(&buf, `<path d="M%d %d V%d H%d V%d" fill="none" stroke="#8a94a6" stroke-width="1.5"/>`+"\n",
+				b.x+svgBoxWidth/2, b.y+svgBoxHeight, midY, c.x+svgBoxWidth/2, c.y)
+			draw(c)
+		}
+
+		emp := b.node.GetEmployee()
+		fmt.Fprintf(&buf, `<g><title>%s</title>`, html.EscapeString(emp.GetEmail()))
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#f5f7fa" stroke="#4a6fa5"/>`,
+			b.x, b.y, svgBoxWidth, svgBoxHeight)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle" font-size="13" font-weight="bold" fill="#1f2933">%s</text>`,
+			b.x+svgBoxWidth/2, b.y+21, html.EscapeString(truncate(displayName(emp), svgMaxChars)))
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle" font-size="11" fill="#52606d">%s</text>`,
+			b.x+svgBoxWidth/2, b.y+38, html.EscapeString(truncate(emp.GetPosition(), svgMaxChars)))
+		buf.WriteString("</g>\n")
+	}
+	for _, t := range tops {
+		draw(t)
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
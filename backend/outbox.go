@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Event types published for employee changes
+const (
+	eventEmployeeCreated       = "employee.created"
+	eventEmployeeUpdated       = "employee.updated"
+	eventEmployeeStatusChanged = "employee.status_changed"
+	eventEmployeeTerminated    = "employee.terminated"
+	eventEmployeeDeleted       = "employee.deleted"
+	eventEmployeeRestored      = "employee.restored"
+	eventEmployeeMerged        = "employee.merged"
+)
+
+// OutboxEvent is an event waiting to be handed to subscribers. It is written
+// in the same transaction as the change it describes, so an event exists if
+// and only if the change was committed.
+type OutboxEvent struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Type      string             `bson:"type"`
+	Subject   primitive.ObjectID `bson:"subject"`
+	Payload   string             `bson:"payload"`
+	CreatedAt time.Time          `bson:"created_at"`
+	// The domain event as binary protobuf, with its full message name
+	DataType string `bson:"data_type"`
+	Data     []byte `bson:"data"`
+	// Dispatched is set once the event has been published to the event
+	// sinks and deliveries have been queued for it
+	Dispatched bool `bson:"dispatched"`
+}
+
+// eventPayload is the JSON body subscribers receive. Data is the revision
+// recorded for the change, including the employee snapshot.
+type eventPayload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// eventType names the event for a revision. Terminations get their own type
+// since most subscribers only care about those.
+func eventType(rev EmployeeRevision) string {
+	switch rev.Action {
+	case actionCreate:
+		return eventEmployeeCreated
+	case actionStatusChange:
+		if rev.After["status"] == statusTerminated {
+			return eventEmployeeTerminated
+		}
+		return eventEmployeeStatusChanged
+	case actionDelete:
+		return eventEmployeeDeleted
+	case actionRestore:
+		return eventEmployeeRestored
+	case actionMerge:
+		return eventEmployeeMerged
+	}
+	return eventEmployeeUpdated
+}
+
+// domainEvent is the EmployeeCreated, EmployeeUpdated or EmployeeDeleted
+// event for a revision
+func domainEvent(rev *pb.EmployeeRevision, created, deleted bool) proto.Message {
+	switch {
+	case created:
+		return &pb.EmployeeCreated{
+			Employee:      rev.GetSnapshot(),
+			Actor:         rev.GetActor(),
+			EffectiveDate: rev.GetEffectiveDate(),
+			Revision:      rev.GetRevision(),
+		}
+	case deleted:
+		return &pb.EmployeeDeleted{
+			EmployeeId:    rev.GetEmployeeId(),
+			Employee:      rev.GetSnapshot(),
+			Actor:         rev.GetActor(),
+			EffectiveDate: rev.GetEffectiveDate(),
+			Revision:      rev.GetRevision(),
+		}
+	}
+	return &pb.EmployeeUpdated{
+		EmployeeId:    rev.GetEmployeeId(),
+		Action:        rev.GetAction(),
+		Changes:       rev.GetChanges(),
+		Employee:      rev.GetSnapshot(),
+		Actor:         rev.GetActor(),
+		EffectiveDate: rev.GetEffectiveDate(),
+		Revision:      rev.GetRevision(),
+	}
+}
+
+// enqueueEvent adds the event for a revision to the outbox. ctx should carry
+// the transaction of the change.
+func (s *server) enqueueEvent(ctx context.Context, rev EmployeeRevision) error {
+	revision := rev.toProto()
+	data, err := protojson.Marshal(revision)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to encode event: %v", err)
+	}
+
+	domain := domainEvent(revision, rev.Before == nil, rev.After == nil)
+	event := OutboxEvent{
+		ID:        primitive.NewObjectID(),
+		Type:      eventType(rev),
+		Subject:   rev.EmployeeID,
+		CreatedAt: time.Now().UTC(),
+		DataType:  string(domain.ProtoReflect().Descriptor().FullName()),
+	}
+	if event.Data, err = proto.Marshal(domain); err != nil {
+		return status.Errorf(codes.Internal, "Failed to encode event: %v", err)
+	}
+
+	payload, err := json.Marshal(eventPayload{
+		ID:        event.ID.Hex(),
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to encode event: %v", err)
+	}
+	event.Payload = string(payload)
+
+	if _, err := s.outboxCollection.InsertOne(ctx, event); err != nil {
+		return status.Errorf(codes.Internal, "Failed to record event: %v", err)
+	}
+	return nil
+}
+
+// inTransaction runs fn in a MongoDB transaction, passing it a context that
+// carries the session. The transaction is retried on transient errors, so
+// fn may run more than once.
+func inTransaction[T any](ctx context.Context, client *mongo.Client, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	session, err := client.StartSession()
+	if err != nil {
+		return zero, status.Errorf(codes.Internal, "Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	res, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return fn(sc)
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return zero, err
+		}
+		return zero, status.Errorf(codes.Internal, "Transaction failed: %v", err)
+	}
+	return res.(T), nil
+}
+
+// mongoClient returns the client the server's collections belong to
+func (s *server) mongoClient() *mongo.Client {
+	return s.employeesCollection.Database().Client()
+}
+
+// outboxDispatcher publishes outbox events to the event sinks and hands them
+// to webhooks. Each event becomes one delivery per subscribed webhook;
+// deliveries are retried with backoff and dead-lettered after maxAttempts
+// failures.
+type outboxDispatcher struct {
+	outboxCollection     *mongo.Collection
+	webhooksCollection   *mongo.Collection
+	deliveriesCollection *mongo.Collection
+	client               *http.Client
+	maxAttempts          int
+	sinks                []eventSink
+	// CloudEvents source of published events
+	source string
+}
+
+func newOutboxDispatcher(outbox, webhooks, deliveries *mongo.Collection, sinks []eventSink, guard *webhookGuard, source string, timeout time.Duration, maxAttempts int) *outboxDispatcher {
+	return &outboxDispatcher{
+		outboxCollection:     outbox,
+		webhooksCollection:   webhooks,
+		deliveriesCollection: deliveries,
+		client:               guard.client(timeout),
+		maxAttempts:          maxAttempts,
+		sinks:                sinks,
+		source:               source,
+	}
+}
+
+// run dispatches new events and due deliveries every interval until ctx is
+// done. Several replicas can run it at once: deliveries are unique per
+// webhook and event, and each attempt is claimed before it is made. Sinks
+// may see an event more than once.
+func (d *outboxDispatcher) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.dispatchEvents(ctx); err != nil {
+			log.Printf("Failed to dispatch events: %v", err)
+		}
+		if err := d.deliverDue(ctx); err != nil {
+			log.Printf("Failed to deliver webhooks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchEvents handles undispatched outbox events in order. An event that
+// a sink fails to take stops the batch, so that sinks receive events in the
+// order they happened; it is retried on the next run.
+func (d *outboxDispatcher) dispatchEvents(ctx context.Context) error {
+	cursor, err := d.outboxCollection.Find(ctx, bson.M{"dispatched": false},
+		options.Find().SetSort(bson.M{"_id": 1}).SetLimit(500),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to read outbox: %w", err)
+	}
+	var events []OutboxEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return fmt.Errorf("failed to decode outbox event: %w", err)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	cursor, err = d.webhooksCollection.Find(ctx, bson.M{"disabled": false})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+	var webhooks []Webhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	for _, event := range events {
+		// Events recorded before domain events existed only go to webhooks
+		if event.DataType != "" {
+			ce := newCloudEvent(d.source, event)
+			for _, sink := range d.sinks {
+				if err := sink.Send(ctx, ce); err != nil {
+					return fmt.Errorf("failed to publish event %s: %w", ce.ID, err)
+				}
+			}
+		}
+
+		if err := d.queueDeliveries(ctx, event, webhooks); err != nil {
+			return err
+		}
+
+		if _, err := d.outboxCollection.UpdateOne(ctx, bson.M{"_id": event.ID}, bson.M{"$set": bson.M{"dispatched": true}}); err != nil {
+			return fmt.Errorf("failed to mark event dispatched: %w", err)
+		}
+	}
+	return nil
+}
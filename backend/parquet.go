@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// A small Parquet writer for flat tables of optional string and timestamp
+// columns. Empty strings are written as nulls, like missing values, since
+// employee fields do not tell them apart. Values are PLAIN encoded and
+// uncompressed, one data page per column chunk. Rows are buffered until a
+// row group is full, so memory is bounded by the row group size rather than
+// by the number of rows.
+
+// Parquet physical types, repetitions and encodings used here
+const (
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetOptional = 1
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMillis = 9
+
+	parquetDataPage = 0
+)
+
+const parquetMagic = "PAR1"
+
+// parquetRowGroupSize is how many rows are buffered per row group
+const parquetRowGroupSize = 10000
+
+// parquetColumn is a column of a parquetWriter
+type parquetColumn struct {
+	name string
+	// Timestamps are stored as INT64 milliseconds since the epoch in UTC,
+	// everything else as UTF-8 strings
+	timestamp bool
+}
+
+// parquetChunk is the metadata of a column chunk that has been written
+type parquetChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+type parquetRowGroup struct {
+	chunks  []parquetChunk
+	size    int64
+	numRows int64
+}
+
+type parquetWriter struct {
+	w       io.Writer
+	offset  int64
+	columns []parquetColumn
+
+	// The row group being filled: definition levels and PLAIN encoded
+	// values per column
+	defined [][]bool
+	values  []bytes.Buffer
+	rows    int
+
+	groups  []parquetRowGroup
+	numRows int64
+}
+
+func newParquetWriter(w io.Writer, columns []parquetColumn) *parquetWriter {
+	return &parquetWriter{
+		w:       w,
+		columns: columns,
+		defined: make([][]bool, len(columns)),
+		values:  make([]bytes.Buffer, len(columns)),
+	}
+}
+
+// WriteRow adds a row. Each value is a string or a *time.Time matching its
+// column, nil or an empty string being a null.
+func (p *parquetWriter) WriteRow(row []interface{}) error {
+	if len(row) != len(p.columns) {
+		return errors.New("parquet: row does not match the columns")
+	}
+	if p.offset == 0 {
+		if err := p.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+			p.defined[i] = append(p.defined[i], false)
+		case string:
+			if v == "" {
+				p.defined[i] = append(p.defined[i], false)
+				continue
+			}
+			p.defined[i] = append(p.defined[i], true)
+			binary.Write(&p.values[i], binary.LittleEndian, uint32(len(v)))
+			p.values[i].WriteString(v)
+		case *time.Time:
+			if v == nil {
+				p.defined[i] = append(p.defined[i], false)
+				continue
+			}
+			p.defined[i] = append(p.defined[i], true)
+			binary.Write(&p.values[i], binary.LittleEndian, v.UnixMilli())
+		default:
+			return errors.New("parquet: unsupported value type")
+		}
+	}
+
+	p.rows++
+	if p.rows >= parquetRowGroupSize {
+		return p.flushRowGroup()
+	}
+	return nil
+}
+
+// Close writes the last row group and the footer
+func (p *parquetWriter) Close() error {
+	if p.offset == 0 {
+		if err := p.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+	if err := p.flushRowGroup(); err != nil {
+		return err
+	}
+
+	footer := p.fileMetaData()
+	if err := p.write(footer); err != nil {
+		return err
+	}
+	var tail [8]byte
+	binary.LittleEndian.PutUint32(tail[:4], uint32(len(footer)))
+	copy(tail[4:], parquetMagic)
+	return p.write(tail[:])
+}
+
+func (p *parquetWriter) write(b []byte) error {
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	return err
+}
+
+// flushRowGroup writes the buffered rows as a row group with one data page
+// per column
+func (p *parquetWriter) flushRowGroup() error {
+	if p.rows == 0 {
+		return nil
+	}
+
+	group := parquetRowGroup{numRows: int64(p.rows)}
+	for i := range p.columns {
+		var page bytes.Buffer
+		levels := encodeDefinitionLevels(p.defined[i])
+		binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+		page.Write(levels)
+		page.Write(p.values[i].Bytes())
+
+		var header thriftWriter
+		header.beginStruct()
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(page.Len()))
+		header.structField(5)
+		header.i32(1, int32(p.rows))
+		header.i32(2, parquetPlain)
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.endStruct()
+		header.endStruct()
+
+		chunk := parquetChunk{
+			offset:    p.offset,
+			size:      int64(header.buf.Len() + page.Len()),
+			numValues: int64(p.rows),
+		}
+		if err := p.write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if err := p.write(page.Bytes()); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.size
+
+		p.defined[i] = p.defined[i][:0]
+		p.values[i].Reset()
+	}
+
+	p.groups = append(p.groups, group)
+	p.numRows += int64(p.rows)
+	p.rows = 0
+	return nil
+}
+
+// fileMetaData encodes the footer: the schema and where each column chunk is
+func (p *parquetWriter) fileMetaData() []byte {
+	var t thriftWriter
+	t.beginStruct()
+	t.i32(1, 1)
+
+	t.listField(2, thriftStruct, len(p.columns)+1)
+	t.beginStruct()
+	t.binary(4, []byte("schema"))
+	t.i32(5, int32(len(p.columns)))
+	t.endStruct()
+	for _, col := range p.columns {
+		t.beginStruct()
+		if col.timestamp {
+			t.i32(1, parquetInt64)
+		} else {
+			t.i32(1, parquetByteArray)
+		}
+		t.i32(3, parquetOptional)
+		t.binary(4, []byte(col.name))
+		// Both the legacy converted type and the logical type, for older
+		// and newer readers
+		if col.timestamp {
+			t.i32(6, parquetConvertedTimestampMillis)
+			t.structField(10)
+			t.structField(8)
+			t.bool(1, true)
+			t.structField(2)
+			t.structField(1)
+			t.endStruct()
+			t.endStruct()
+			t.endStruct()
+			t.endStruct()
+		} else {
+			t.i32(6, parquetConvertedUTF8)
+			t.structField(10)
+			t.structField(1)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.endStruct()
+	}
+
+	t.i64(3, p.numRows)
+
+	t.listField(4, thriftStruct, len(p.groups))
+	for _, group := range p.groups {
+		t.beginStruct()
+		t.listField(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			physical := int32(parquetByteArray)
+			if p.columns[i].timestamp {
+				physical = parquetInt64
+			}
+			t.beginStruct()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, physical)
+			t.listField(2, thriftI32, 2)
+			t.listI32(parquetPlain)
+			t.listI32(parquetRLE)
+			t.listField(3, thriftBinary, 1)
+			t.listBinary([]byte(p.columns[i].name))
+			t.i32(4, 0) // uncompressed
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, group.size)
+		t.i64(3, group.numRows)
+		t.endStruct()
+	}
+
+	t.binary(6, []byte("EMPLOYEE_APP"))
+	t.endStruct()
+	return t.buf.Bytes()
+}
+
+// encodeDefinitionLevels encodes 0/1 definition levels with the RLE /
+// bit-packing hybrid, using RLE runs only
+func encodeDefinitionLevels(defined []bool) []byte {
+	var out []byte
+	for i := 0; i < len(defined); {
+		j := i + 1
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		if defined[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// Thrift compact protocol types
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs in the Thrift compact protocol, which is what
+// Parquet metadata is written in. Fields must be written in increasing ID
+// order within each struct.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// The last field ID written in each open struct
+	lastID []int16
+}
+
+func (t *thriftWriter) beginStruct() {
+	t.lastID = append(t.lastID, 0)
+}
+
+func (t *thriftWriter) endStruct() {
+	t.buf.WriteByte(0)
+	t.lastID = t.lastID[:len(t.lastID)-1]
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &t.lastID[len(t.lastID)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) varint(v int64) {
+	t.buf.Write(binary.AppendUvarint(nil, uint64((v<<1)^(v>>63))))
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) bool(id int16, v bool) {
+	if v {
+		t.fieldHeader(id, thriftTrue)
+	} else {
+		t.fieldHeader(id, thriftFalse)
+	}
+}
+
+func (t *thriftWriter) binary(id int16, v []byte) {
+	t.fieldHeader(id, thriftBinary)
+	t.listBinary(v)
+}
+
+// structField starts a struct-valued field; close it with endStruct
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.beginStruct()
+}
+
+// listField starts a list of n elements, which follow as listI32 and
+// listBinary values or as beginStruct/endStruct pairs
+func (t *thriftWriter) listField(id int16, elem byte, n int) {
+	t.fieldHeader(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elem)
+	} else {
+		t.buf.WriteByte(0xf0 | elem)
+		t.buf.Write(binary.AppendUvarint(nil, uint64(n)))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) listBinary(v []byte) {
+	t.buf.Write(binary.AppendUvarint(nil, uint64(len(v))))
+	t.buf.Write(v)
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// The files are read back with a decoder written from the Parquet and
+// Thrift compact protocol specs rather than from the writer, so that a
+// mistake in the writer is not mirrored by the reader.
+
+// thriftDecoder reads Thrift compact protocol structs into maps from field
+// ID to value
+type thriftDecoder struct {
+	b   []byte
+	pos int
+}
+
+func (d *thriftDecoder) readByte() byte {
+	c := d.b[d.pos]
+	d.pos++
+	return c
+}
+
+func (d *thriftDecoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.b[d.pos:])
+	if n <= 0 {
+		panic("bad varint")
+	}
+	d.pos += n
+	return v
+}
+
+func (d *thriftDecoder) zigzag() int64 {
+	v := d.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (d *thriftDecoder) structValue() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		h := d.readByte()
+		if h == 0 {
+			return fields
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(d.zigzag())
+		}
+		last = id
+		fields[id] = d.value(h & 0x0f)
+	}
+}
+
+func (d *thriftDecoder) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 3:
+		return int8(d.readByte())
+	case 4, 5, 6:
+		return d.zigzag()
+	case 7:
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.b[d.pos:]))
+		d.pos += 8
+		return v
+	case 8:
+		n := int(d.uvarint())
+		v := d.b[d.pos : d.pos+n]
+		d.pos += n
+		return v
+	case 9, 10:
+		h := d.readByte()
+		n := int(h >> 4)
+		if n == 15 {
+			n = int(d.uvarint())
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			if elem := h & 0x0f; elem == 1 || elem == 2 {
+				list[i] = d.readByte() == 1
+			} else {
+				list[i] = d.value(elem)
+			}
+		}
+		return list
+	case 12:
+		return d.structValue()
+	}
+	panic(fmt.Sprintf("unsupported thrift type %d", typ))
+}
+
+type parquetStruct = map[int16]interface{}
+
+// rleHybrid decodes n levels of bit width 1 in the RLE / bit-packing hybrid
+// encoding
+func rleHybrid(b []byte, n int) []int {
+	d := thriftDecoder{b: b}
+	var levels []int
+	for len(levels) < n {
+		header := d.uvarint()
+		if header&1 == 0 {
+			v := int(d.readByte())
+			for i := 0; i < int(header>>1); i++ {
+				levels = append(levels, v)
+			}
+			continue
+		}
+		for i := 0; i < int(header>>1); i++ {
+			c := d.readByte()
+			for bit := 0; bit < 8; bit++ {
+				levels = append(levels, int(c>>bit)&1)
+			}
+		}
+	}
+	return levels[:n]
+}
+
+type parquetFile struct {
+	meta parquetStruct
+	// Values per column, nil for nulls
+	columns [][]interface{}
+}
+
+// readParquet parses a whole file
+func readParquet(t *testing.T, data []byte) parquetFile {
+	t.Helper()
+	if len(data) < 12 || string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		t.Fatalf("missing PAR1 magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := thriftDecoder{b: data[len(data)-8-footerLen : len(data)-8]}
+	file := parquetFile{meta: footer.structValue()}
+	if footer.pos != footerLen {
+		t.Fatalf("footer is %d bytes, decoded %d", footerLen, footer.pos)
+	}
+
+	schema := file.meta[2].([]interface{})
+	file.columns = make([][]interface{}, len(schema)-1)
+	for _, g := range file.meta[4].([]interface{}) {
+		group := g.(parquetStruct)
+		numRows := int(group[3].(int64))
+		for i, c := range group[1].([]interface{}) {
+			meta := c.(parquetStruct)[3].(parquetStruct)
+			physical := meta[1].(int64)
+			if codec := meta[4].(int64); codec != 0 {
+				t.Fatalf("column %d is compressed with codec %d", i, codec)
+			}
+			if int(meta[5].(int64)) != numRows {
+				t.Fatalf("column %d has %d values in a group of %d rows", i, meta[5], numRows)
+			}
+
+			pages := thriftDecoder{b: data, pos: int(meta[9].(int64))}
+			for read := 0; read < numRows; {
+				header := pages.structValue()
+				if header[1].(int64) != 0 {
+					t.Fatalf("unexpected page type %d", header[1])
+				}
+				page := data[pages.pos : pages.pos+int(header[3].(int64))]
+				pages.pos += len(page)
+				n := int(header[5].(parquetStruct)[1].(int64))
+
+				levelsLen := int(binary.LittleEndian.Uint32(page))
+				levels := rleHybrid(page[4:4+levelsLen], n)
+				values := page[4+levelsLen:]
+				for _, defined := range levels {
+					if defined == 0 {
+						file.columns[i] = append(file.columns[i], nil)
+						continue
+					}
+					switch physical {
+					case 2:
+						file.columns[i] = append(file.columns[i], int64(binary.LittleEndian.Uint64(values)))
+						values = values[8:]
+					case 6:
+						size := int(binary.LittleEndian.Uint32(values))
+						file.columns[i] = append(file.columns[i], string(values[4:4+size]))
+						values = values[4+size:]
+					default:
+						t.Fatalf("unexpected physical type %d", physical)
+					}
+				}
+				if len(values) != 0 {
+					t.Fatalf("column %d has %d bytes left over in a page", i, len(values))
+				}
+				read += n
+			}
+		}
+	}
+	return file
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	columns := []parquetColumn{
+		{name: "email"},
+		{name: "department"},
+		{name: "hire_date", timestamp: true},
+	}
+	base := time.Date(2024, 2, 29, 9, 30, 0, 123456789, time.UTC)
+
+	// Three row groups, the last one partly filled
+	n := 2*parquetRowGroupSize + 17
+	want := make([][]interface{}, n)
+	var buf bytes.Buffer
+	w := newParquetWriter(&buf, columns)
+	for i := 0; i < n; i++ {
+		email := fmt.Sprintf("employee%d@example.com", i)
+		var department interface{}
+		switch i % 4 {
+		case 0:
+			department = ""
+		case 1:
+			department = nil
+		case 2:
+			department = "Recherche & Développement"
+		default:
+			department = fmt.Sprintf("Dept %d", i)
+		}
+		var hired *time.Time
+		if i%3 != 0 {
+			at := base.Add(time.Duration(i) * time.Hour).In(time.FixedZone("CET", 3600))
+			hired = &at
+		}
+		if err := w.WriteRow([]interface{}{email, department, hired}); err != nil {
+			t.Fatalf("WriteRow %d: %v", i, err)
+		}
+
+		// Empty strings are written as nulls and timestamps as UTC
+		// milliseconds
+		want[i] = []interface{}{email, department, nil}
+		if department == "" {
+			want[i][1] = nil
+		}
+		if hired != nil {
+			want[i][2] = hired.UnixMilli()
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	file := readParquet(t, buf.Bytes())
+	if rows := file.meta[3].(int64); rows != int64(n) {
+		t.Errorf("num_rows = %d, want %d", rows, n)
+	}
+	if groups := len(file.meta[4].([]interface{})); groups != 3 {
+		t.Errorf("%d row groups, want 3", groups)
+	}
+
+	schema := file.meta[2].([]interface{})
+	if len(schema) != len(columns)+1 {
+		t.Fatalf("schema has %d elements, want %d", len(schema), len(columns)+1)
+	}
+	if children := schema[0].(parquetStruct)[5].(int64); children != int64(len(columns)) {
+		t.Errorf("root has %d children, want %d", children, len(columns))
+	}
+	for i, col := range columns {
+		el := schema[i+1].(parquetStruct)
+		physical, converted := int64(6), int64(0) // BYTE_ARRAY, UTF8
+		if col.timestamp {
+			physical, converted = 2, 9 // INT64, TIMESTAMP_MILLIS
+		}
+		if name := string(el[4].([]byte)); name != col.name {
+			t.Errorf("column %d is named %q, want %q", i, name, col.name)
+		}
+		if el[1].(int64) != physical || el[3].(int64) != 1 || el[6].(int64) != converted {
+			t.Errorf("column %s has type %d, repetition %d, converted type %d", col.name, el[1], el[3], el[6])
+		}
+	}
+
+	for c := range columns {
+		if len(file.columns[c]) != n {
+			t.Fatalf("column %d has %d values, want %d", c, len(file.columns[c]), n)
+		}
+		for i := 0; i < n; i++ {
+			if got := file.columns[c][i]; got != want[i][c] {
+				t.Fatalf("row %d column %s = %#v, want %#v", i, columns[c].name, got, want[i][c])
+			}
+		}
+	}
+}
+
+func TestParquetEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := newParquetWriter(&buf, []parquetColumn{{name: "email"}})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	file := readParquet(t, buf.Bytes())
+	if rows := file.meta[3].(int64); rows != 0 {
+		t.Errorf("num_rows = %d, want 0", rows)
+	}
+	if groups := len(file.meta[4].([]interface{})); groups != 0 {
+		t.Errorf("%d row groups, want 0", groups)
+	}
+}
+
+func TestParquetRejectsBadRows(t *testing.T) {
+	tests := []struct {
+		name string
+		row  []interface{}
+	}{
+		{name: "too few values", row: []interface{}{"a"}},
+		{name: "too many values", row: []interface{}{"a", nil, "b"}},
+		{name: "unsupported type", row: []interface{}{42, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newParquetWriter(&bytes.Buffer{}, []parquetColumn{{name: "email"}, {name: "hire_date", timestamp: true}})
+			if err := w.WriteRow(tt.row); err == nil {
+				t.Errorf("WriteRow(%v) succeeded", tt.row)
+			}
+		})
+	}
+}
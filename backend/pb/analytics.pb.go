@@ -0,0 +1,1691 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: analytics.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HeadcountRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	GroupBy []string               `protobuf:"bytes,1,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// Count as it was (or is scheduled to be) at this time
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountRequest) Reset() {
+	*x = HeadcountRequest{}
+	mi := &file_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountRequest) ProtoMessage() {}
+
+func (x *HeadcountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountRequest.ProtoReflect.Descriptor instead.
+func (*HeadcountRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HeadcountRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *HeadcountRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type HeadcountGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value of each group_by dimension; empty when the employee has none
+	Dimensions    map[string]string `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Count         int64             `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountGroup) Reset() {
+	*x = HeadcountGroup{}
+	mi := &file_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountGroup) ProtoMessage() {}
+
+func (x *HeadcountGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountGroup.ProtoReflect.Descriptor instead.
+func (*HeadcountGroup) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HeadcountGroup) GetDimensions() map[string]string {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *HeadcountGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type HeadcountReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Total int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Largest first
+	Groups        []*HeadcountGroup `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountReport) Reset() {
+	*x = HeadcountReport{}
+	mi := &file_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountReport) ProtoMessage() {}
+
+func (x *HeadcountReport) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountReport.ProtoReflect.Descriptor instead.
+func (*HeadcountReport) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HeadcountReport) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *HeadcountReport) GetGroups() []*HeadcountGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HeadcountTrendRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to a year before end
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Defaults to now
+	End *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// day, week (starting on Monday), month (default), quarter or year
+	Interval      string   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	GroupBy       []string `protobuf:"bytes,4,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountTrendRequest) Reset() {
+	*x = HeadcountTrendRequest{}
+	mi := &file_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountTrendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountTrendRequest) ProtoMessage() {}
+
+func (x *HeadcountTrendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountTrendRequest.ProtoReflect.Descriptor instead.
+func (*HeadcountTrendRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HeadcountTrendRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *HeadcountTrendRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *HeadcountTrendRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HeadcountTrendRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+type HeadcountPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Groups        []*HeadcountGroup      `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountPoint) Reset() {
+	*x = HeadcountPoint{}
+	mi := &file_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountPoint) ProtoMessage() {}
+
+func (x *HeadcountPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountPoint.ProtoReflect.Descriptor instead.
+func (*HeadcountPoint) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HeadcountPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HeadcountPoint) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *HeadcountPoint) GetGroups() []*HeadcountGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HeadcountTrend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*HeadcountPoint      `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadcountTrend) Reset() {
+	*x = HeadcountTrend{}
+	mi := &file_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadcountTrend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadcountTrend) ProtoMessage() {}
+
+func (x *HeadcountTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadcountTrend.ProtoReflect.Descriptor instead.
+func (*HeadcountTrend) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HeadcountTrend) GetPoints() []*HeadcountPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type HiresAndTerminationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to a year before end
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Defaults to now
+	End *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// day, week (starting on Monday), month (default), quarter or year
+	Interval      string   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	GroupBy       []string `protobuf:"bytes,4,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HiresAndTerminationsRequest) Reset() {
+	*x = HiresAndTerminationsRequest{}
+	mi := &file_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HiresAndTerminationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiresAndTerminationsRequest) ProtoMessage() {}
+
+func (x *HiresAndTerminationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiresAndTerminationsRequest.ProtoReflect.Descriptor instead.
+func (*HiresAndTerminationsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HiresAndTerminationsRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *HiresAndTerminationsRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *HiresAndTerminationsRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *HiresAndTerminationsRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+type MovementGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dimensions    map[string]string      `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Hires         int64                  `protobuf:"varint,2,opt,name=hires,proto3" json:"hires,omitempty"`
+	Terminations  int64                  `protobuf:"varint,3,opt,name=terminations,proto3" json:"terminations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovementGroup) Reset() {
+	*x = MovementGroup{}
+	mi := &file_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovementGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovementGroup) ProtoMessage() {}
+
+func (x *MovementGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovementGroup.ProtoReflect.Descriptor instead.
+func (*MovementGroup) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MovementGroup) GetDimensions() map[string]string {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *MovementGroup) GetHires() int64 {
+	if x != nil {
+		return x.Hires
+	}
+	return 0
+}
+
+func (x *MovementGroup) GetTerminations() int64 {
+	if x != nil {
+		return x.Terminations
+	}
+	return 0
+}
+
+type MovementPeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Hires         int64                  `protobuf:"varint,2,opt,name=hires,proto3" json:"hires,omitempty"`
+	Terminations  int64                  `protobuf:"varint,3,opt,name=terminations,proto3" json:"terminations,omitempty"`
+	Groups        []*MovementGroup       `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovementPeriod) Reset() {
+	*x = MovementPeriod{}
+	mi := &file_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovementPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovementPeriod) ProtoMessage() {}
+
+func (x *MovementPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovementPeriod.ProtoReflect.Descriptor instead.
+func (*MovementPeriod) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MovementPeriod) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *MovementPeriod) GetHires() int64 {
+	if x != nil {
+		return x.Hires
+	}
+	return 0
+}
+
+func (x *MovementPeriod) GetTerminations() int64 {
+	if x != nil {
+		return x.Terminations
+	}
+	return 0
+}
+
+func (x *MovementPeriod) GetGroups() []*MovementGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type HiresAndTerminations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every period from start to end, including the ones without movements
+	Periods       []*MovementPeriod `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HiresAndTerminations) Reset() {
+	*x = HiresAndTerminations{}
+	mi := &file_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HiresAndTerminations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HiresAndTerminations) ProtoMessage() {}
+
+func (x *HiresAndTerminations) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HiresAndTerminations.ProtoReflect.Descriptor instead.
+func (*HiresAndTerminations) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HiresAndTerminations) GetPeriods() []*MovementPeriod {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+type SpanOfControlRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	GroupBy []string               `protobuf:"bytes,1,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// Managers with the most reports to list, defaults to 10
+	Top           int32 `protobuf:"varint,2,opt,name=top,proto3" json:"top,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpanOfControlRequest) Reset() {
+	*x = SpanOfControlRequest{}
+	mi := &file_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpanOfControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanOfControlRequest) ProtoMessage() {}
+
+func (x *SpanOfControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanOfControlRequest.ProtoReflect.Descriptor instead.
+func (*SpanOfControlRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SpanOfControlRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *SpanOfControlRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+type SpanBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DirectReports int32                  `protobuf:"varint,1,opt,name=direct_reports,json=directReports,proto3" json:"direct_reports,omitempty"`
+	Managers      int64                  `protobuf:"varint,2,opt,name=managers,proto3" json:"managers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpanBucket) Reset() {
+	*x = SpanBucket{}
+	mi := &file_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpanBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanBucket) ProtoMessage() {}
+
+func (x *SpanBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanBucket.ProtoReflect.Descriptor instead.
+func (*SpanBucket) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SpanBucket) GetDirectReports() int32 {
+	if x != nil {
+		return x.DirectReports
+	}
+	return 0
+}
+
+func (x *SpanBucket) GetManagers() int64 {
+	if x != nil {
+		return x.Managers
+	}
+	return 0
+}
+
+type SpanGroup struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Dimensions        map[string]string      `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Managers          int64                  `protobuf:"varint,2,opt,name=managers,proto3" json:"managers,omitempty"`
+	MeanDirectReports float64                `protobuf:"fixed64,3,opt,name=mean_direct_reports,json=meanDirectReports,proto3" json:"mean_direct_reports,omitempty"`
+	MaxDirectReports  int32                  `protobuf:"varint,4,opt,name=max_direct_reports,json=maxDirectReports,proto3" json:"max_direct_reports,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SpanGroup) Reset() {
+	*x = SpanGroup{}
+	mi := &file_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpanGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanGroup) ProtoMessage() {}
+
+func (x *SpanGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanGroup.ProtoReflect.Descriptor instead.
+func (*SpanGroup) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SpanGroup) GetDimensions() map[string]string {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *SpanGroup) GetManagers() int64 {
+	if x != nil {
+		return x.Managers
+	}
+	return 0
+}
+
+func (x *SpanGroup) GetMeanDirectReports() float64 {
+	if x != nil {
+		return x.MeanDirectReports
+	}
+	return 0
+}
+
+func (x *SpanGroup) GetMaxDirectReports() int32 {
+	if x != nil {
+		return x.MaxDirectReports
+	}
+	return 0
+}
+
+type ManagerSpan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId    string                 `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DirectReports int32                  `protobuf:"varint,3,opt,name=direct_reports,json=directReports,proto3" json:"direct_reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManagerSpan) Reset() {
+	*x = ManagerSpan{}
+	mi := &file_analytics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManagerSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManagerSpan) ProtoMessage() {}
+
+func (x *ManagerSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManagerSpan.ProtoReflect.Descriptor instead.
+func (*ManagerSpan) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ManagerSpan) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *ManagerSpan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManagerSpan) GetDirectReports() int32 {
+	if x != nil {
+		return x.DirectReports
+	}
+	return 0
+}
+
+type SpanOfControl struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Employees with at least one direct report
+	Managers               int64   `protobuf:"varint,1,opt,name=managers,proto3" json:"managers,omitempty"`
+	IndividualContributors int64   `protobuf:"varint,2,opt,name=individual_contributors,json=individualContributors,proto3" json:"individual_contributors,omitempty"`
+	MeanDirectReports      float64 `protobuf:"fixed64,3,opt,name=mean_direct_reports,json=meanDirectReports,proto3" json:"mean_direct_reports,omitempty"`
+	MedianDirectReports    float64 `protobuf:"fixed64,4,opt,name=median_direct_reports,json=medianDirectReports,proto3" json:"median_direct_reports,omitempty"`
+	MaxDirectReports       int32   `protobuf:"varint,5,opt,name=max_direct_reports,json=maxDirectReports,proto3" json:"max_direct_reports,omitempty"`
+	// Managers by number of direct reports
+	Distribution []*SpanBucket `protobuf:"bytes,6,rep,name=distribution,proto3" json:"distribution,omitempty"`
+	Groups       []*SpanGroup  `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Widest spans first
+	TopManagers   []*ManagerSpan `protobuf:"bytes,8,rep,name=top_managers,json=topManagers,proto3" json:"top_managers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpanOfControl) Reset() {
+	*x = SpanOfControl{}
+	mi := &file_analytics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpanOfControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanOfControl) ProtoMessage() {}
+
+func (x *SpanOfControl) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanOfControl.ProtoReflect.Descriptor instead.
+func (*SpanOfControl) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SpanOfControl) GetManagers() int64 {
+	if x != nil {
+		return x.Managers
+	}
+	return 0
+}
+
+func (x *SpanOfControl) GetIndividualContributors() int64 {
+	if x != nil {
+		return x.IndividualContributors
+	}
+	return 0
+}
+
+func (x *SpanOfControl) GetMeanDirectReports() float64 {
+	if x != nil {
+		return x.MeanDirectReports
+	}
+	return 0
+}
+
+func (x *SpanOfControl) GetMedianDirectReports() float64 {
+	if x != nil {
+		return x.MedianDirectReports
+	}
+	return 0
+}
+
+func (x *SpanOfControl) GetMaxDirectReports() int32 {
+	if x != nil {
+		return x.MaxDirectReports
+	}
+	return 0
+}
+
+func (x *SpanOfControl) GetDistribution() []*SpanBucket {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+func (x *SpanOfControl) GetGroups() []*SpanGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SpanOfControl) GetTopManagers() []*ManagerSpan {
+	if x != nil {
+		return x.TopManagers
+	}
+	return nil
+}
+
+type EmployeeQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fields to group rows by. Dates need a period: day, week, month, quarter
+	// or year, e.g. hire_date:month.
+	GroupBy []string `protobuf:"bytes,1,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// Spread the values of this field into columns, one per value and
+	// aggregate, e.g. department rows by position columns
+	PivotBy string `protobuf:"bytes,2,opt,name=pivot_by,json=pivotBy,proto3" json:"pivot_by,omitempty"`
+	// Defaults to a count
+	Aggregates []*QueryAggregate `protobuf:"bytes,3,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	// Conditions that must all hold
+	Filter            []*QueryCondition `protobuf:"bytes,4,rep,name=filter,proto3" json:"filter,omitempty"`
+	IncludeTerminated bool              `protobuf:"varint,5,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	// Query employees as they were (or are scheduled to be) at this time
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Rows to return, defaults to 1000, at most 10000
+	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeQuery) Reset() {
+	*x = EmployeeQuery{}
+	mi := &file_analytics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeQuery) ProtoMessage() {}
+
+func (x *EmployeeQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeQuery.ProtoReflect.Descriptor instead.
+func (*EmployeeQuery) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EmployeeQuery) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *EmployeeQuery) GetPivotBy() string {
+	if x != nil {
+		return x.PivotBy
+	}
+	return ""
+}
+
+func (x *EmployeeQuery) GetAggregates() []*QueryAggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *EmployeeQuery) GetFilter() []*QueryCondition {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *EmployeeQuery) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+func (x *EmployeeQuery) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *EmployeeQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryAggregate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count, count_distinct, min or max
+	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// Optional for count, which then counts rows; min and max take dates and
+	// numbers
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// Column name, defaults to e.g. count or max_hire_date
+	Alias         string `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAggregate) Reset() {
+	*x = QueryAggregate{}
+	mi := &file_analytics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAggregate) ProtoMessage() {}
+
+func (x *QueryAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAggregate.ProtoReflect.Descriptor instead.
+func (*QueryAggregate) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *QueryAggregate) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *QueryAggregate) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *QueryAggregate) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+type QueryCondition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// eq (default), ne, in, not_in, lt, lte, gt, gte, exists or missing
+	Op string `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	// Dates as RFC 3339 or YYYY-MM-DD
+	Values        []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCondition) Reset() {
+	*x = QueryCondition{}
+	mi := &file_analytics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCondition) ProtoMessage() {}
+
+func (x *QueryCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCondition.ProtoReflect.Descriptor instead.
+func (*QueryCondition) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QueryCondition) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *QueryCondition) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *QueryCondition) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type QueryColumn struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// string, date, number or boolean
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryColumn) Reset() {
+	*x = QueryColumn{}
+	mi := &file_analytics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryColumn) ProtoMessage() {}
+
+func (x *QueryColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryColumn.ProtoReflect.Descriptor instead.
+func (*QueryColumn) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *QueryColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryColumn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type QueryRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One per column; null where there is no value
+	Values        []*structpb.Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRow) Reset() {
+	*x = QueryRow{}
+	mi := &file_analytics_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRow) ProtoMessage() {}
+
+func (x *QueryRow) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRow.ProtoReflect.Descriptor instead.
+func (*QueryRow) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *QueryRow) GetValues() []*structpb.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type QueryResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Columns []*QueryColumn         `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows    []*QueryRow            `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Set when there were more rows than the limit
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResult) Reset() {
+	*x = QueryResult{}
+	mi := &file_analytics_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResult) ProtoMessage() {}
+
+func (x *QueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
+func (*QueryResult) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QueryResult) GetColumns() []*QueryColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryResult) GetRows() []*QueryRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QueryResult) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type QueryField struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// string, id, status, date, number or boolean. Custom fields are named
+	// custom.<name>.
+	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Groupable  bool   `protobuf:"varint,3,opt,name=groupable,proto3" json:"groupable,omitempty"`
+	Filterable bool   `protobuf:"varint,4,opt,name=filterable,proto3" json:"filterable,omitempty"`
+	// Aggregate functions that take the field
+	Aggregates    []string `protobuf:"bytes,5,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryField) Reset() {
+	*x = QueryField{}
+	mi := &file_analytics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryField) ProtoMessage() {}
+
+func (x *QueryField) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryField.ProtoReflect.Descriptor instead.
+func (*QueryField) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QueryField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryField) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QueryField) GetGroupable() bool {
+	if x != nil {
+		return x.Groupable
+	}
+	return false
+}
+
+func (x *QueryField) GetFilterable() bool {
+	if x != nil {
+		return x.Filterable
+	}
+	return false
+}
+
+func (x *QueryField) GetAggregates() []string {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+type QueryFieldList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*QueryField          `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryFieldList) Reset() {
+	*x = QueryFieldList{}
+	mi := &file_analytics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryFieldList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryFieldList) ProtoMessage() {}
+
+func (x *QueryFieldList) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryFieldList.ProtoReflect.Descriptor instead.
+func (*QueryFieldList) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueryFieldList) GetFields() []*QueryField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_analytics_proto protoreflect.FileDescriptor
+
+const file_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x0fanalytics.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eemployee.proto\"^\n" +
+	"\x10HeadcountRequest\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x03(\tR\agroupBy\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xaf\x01\n" +
+	"\x0eHeadcountGroup\x12H\n" +
+	"\n" +
+	"dimensions\x18\x01 \x03(\v2(.employee.HeadcountGroup.DimensionsEntryR\n" +
+	"dimensions\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x1a=\n" +
+	"\x0fDimensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
+	"\x0fHeadcountReport\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x120\n" +
+	"\x06groups\x18\x02 \x03(\v2\x18.employee.HeadcountGroupR\x06groups\"\xae\x01\n" +
+	"\x15HeadcountTrendRequest\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x03(\tR\agroupBy\"\x88\x01\n" +
+	"\x0eHeadcountPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x120\n" +
+	"\x06groups\x18\x03 \x03(\v2\x18.employee.HeadcountGroupR\x06groups\"B\n" +
+	"\x0eHeadcountTrend\x120\n" +
+	"\x06points\x18\x01 \x03(\v2\x18.employee.HeadcountPointR\x06points\"\xb4\x01\n" +
+	"\x1bHiresAndTerminationsRequest\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\tR\binterval\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x03(\tR\agroupBy\"\xd1\x01\n" +
+	"\rMovementGroup\x12G\n" +
+	"\n" +
+	"dimensions\x18\x01 \x03(\v2'.employee.MovementGroup.DimensionsEntryR\n" +
+	"dimensions\x12\x14\n" +
+	"\x05hires\x18\x02 \x01(\x03R\x05hires\x12\"\n" +
+	"\fterminations\x18\x03 \x01(\x03R\fterminations\x1a=\n" +
+	"\x0fDimensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xba\x01\n" +
+	"\x0eMovementPeriod\x12=\n" +
+	"\fperiod_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12\x14\n" +
+	"\x05hires\x18\x02 \x01(\x03R\x05hires\x12\"\n" +
+	"\fterminations\x18\x03 \x01(\x03R\fterminations\x12/\n" +
+	"\x06groups\x18\x04 \x03(\v2\x17.employee.MovementGroupR\x06groups\"J\n" +
+	"\x14HiresAndTerminations\x122\n" +
+	"\aperiods\x18\x01 \x03(\v2\x18.employee.MovementPeriodR\aperiods\"C\n" +
+	"\x14SpanOfControlRequest\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x03(\tR\agroupBy\x12\x10\n" +
+	"\x03top\x18\x02 \x01(\x05R\x03top\"O\n" +
+	"\n" +
+	"SpanBucket\x12%\n" +
+	"\x0edirect_reports\x18\x01 \x01(\x05R\rdirectReports\x12\x1a\n" +
+	"\bmanagers\x18\x02 \x01(\x03R\bmanagers\"\x89\x02\n" +
+	"\tSpanGroup\x12C\n" +
+	"\n" +
+	"dimensions\x18\x01 \x03(\v2#.employee.SpanGroup.DimensionsEntryR\n" +
+	"dimensions\x12\x1a\n" +
+	"\bmanagers\x18\x02 \x01(\x03R\bmanagers\x12.\n" +
+	"\x13mean_direct_reports\x18\x03 \x01(\x01R\x11meanDirectReports\x12,\n" +
+	"\x12max_direct_reports\x18\x04 \x01(\x05R\x10maxDirectReports\x1a=\n" +
+	"\x0fDimensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
+	"\vManagerSpan\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0edirect_reports\x18\x03 \x01(\x05R\rdirectReports\"\x97\x03\n" +
+	"\rSpanOfControl\x12\x1a\n" +
+	"\bmanagers\x18\x01 \x01(\x03R\bmanagers\x127\n" +
+	"\x17individual_contributors\x18\x02 \x01(\x03R\x16individualContributors\x12.\n" +
+	"\x13mean_direct_reports\x18\x03 \x01(\x01R\x11meanDirectReports\x122\n" +
+	"\x15median_direct_reports\x18\x04 \x01(\x01R\x13medianDirectReports\x12,\n" +
+	"\x12max_direct_reports\x18\x05 \x01(\x05R\x10maxDirectReports\x128\n" +
+	"\fdistribution\x18\x06 \x03(\v2\x14.employee.SpanBucketR\fdistribution\x12+\n" +
+	"\x06groups\x18\a \x03(\v2\x13.employee.SpanGroupR\x06groups\x128\n" +
+	"\ftop_managers\x18\b \x03(\v2\x15.employee.ManagerSpanR\vtopManagers\"\xa7\x02\n" +
+	"\rEmployeeQuery\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x03(\tR\agroupBy\x12\x19\n" +
+	"\bpivot_by\x18\x02 \x01(\tR\apivotBy\x128\n" +
+	"\n" +
+	"aggregates\x18\x03 \x03(\v2\x18.employee.QueryAggregateR\n" +
+	"aggregates\x120\n" +
+	"\x06filter\x18\x04 \x03(\v2\x18.employee.QueryConditionR\x06filter\x12-\n" +
+	"\x12include_terminated\x18\x05 \x01(\bR\x11includeTerminated\x12/\n" +
+	"\x05as_of\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"X\n" +
+	"\x0eQueryAggregate\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\tR\x05alias\"N\n" +
+	"\x0eQueryCondition\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\"5\n" +
+	"\vQueryColumn\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\":\n" +
+	"\bQueryRow\x12.\n" +
+	"\x06values\x18\x01 \x03(\v2\x16.google.protobuf.ValueR\x06values\"\x84\x01\n" +
+	"\vQueryResult\x12/\n" +
+	"\acolumns\x18\x01 \x03(\v2\x15.employee.QueryColumnR\acolumns\x12&\n" +
+	"\x04rows\x18\x02 \x03(\v2\x12.employee.QueryRowR\x04rows\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"\x92\x01\n" +
+	"\n" +
+	"QueryField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\tgroupable\x18\x03 \x01(\bR\tgroupable\x12\x1e\n" +
+	"\n" +
+	"filterable\x18\x04 \x01(\bR\n" +
+	"filterable\x12\x1e\n" +
+	"\n" +
+	"aggregates\x18\x05 \x03(\tR\n" +
+	"aggregates\">\n" +
+	"\x0eQueryFieldList\x12,\n" +
+	"\x06fields\x18\x01 \x03(\v2\x14.employee.QueryFieldR\x06fields2\xb4\x05\n" +
+	"\x10AnalyticsService\x12f\n" +
+	"\fGetHeadcount\x12\x1a.employee.HeadcountRequest\x1a\x19.employee.HeadcountReport\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/analytics/headcount\x12u\n" +
+	"\x11GetHeadcountTrend\x12\x1f.employee.HeadcountTrendRequest\x1a\x18.employee.HeadcountTrend\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/analytics/headcount:trend\x12\x8e\x01\n" +
+	"\x17GetHiresAndTerminations\x12%.employee.HiresAndTerminationsRequest\x1a\x1e.employee.HiresAndTerminations\",\x82\xd3\xe4\x93\x02&\x12$/v1/analytics/hires-and-terminations\x12r\n" +
+	"\x10GetSpanOfControl\x12\x1e.employee.SpanOfControlRequest\x1a\x17.employee.SpanOfControl\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/analytics/span-of-control\x12`\n" +
+	"\x0eQueryEmployees\x12\x17.employee.EmployeeQuery\x1a\x15.employee.QueryResult\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/analytics:query\x12Z\n" +
+	"\x0fListQueryFields\x12\x0f.employee.Empty\x1a\x18.employee.QueryFieldList\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/analytics/fieldsB\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_analytics_proto_rawDescOnce sync.Once
+	file_analytics_proto_rawDescData []byte
+)
+
+func file_analytics_proto_rawDescGZIP() []byte {
+	file_analytics_proto_rawDescOnce.Do(func() {
+		file_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)))
+	})
+	return file_analytics_proto_rawDescData
+}
+
+var file_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_analytics_proto_goTypes = []any{
+	(*HeadcountRequest)(nil),            // 0: employee.HeadcountRequest
+	(*HeadcountGroup)(nil),              // 1: employee.HeadcountGroup
+	(*HeadcountReport)(nil),             // 2: employee.HeadcountReport
+	(*HeadcountTrendRequest)(nil),       // 3: employee.HeadcountTrendRequest
+	(*HeadcountPoint)(nil),              // 4: employee.HeadcountPoint
+	(*HeadcountTrend)(nil),              // 5: employee.HeadcountTrend
+	(*HiresAndTerminationsRequest)(nil), // 6: employee.HiresAndTerminationsRequest
+	(*MovementGroup)(nil),               // 7: employee.MovementGroup
+	(*MovementPeriod)(nil),              // 8: employee.MovementPeriod
+	(*HiresAndTerminations)(nil),        // 9: employee.HiresAndTerminations
+	(*SpanOfControlRequest)(nil),        // 10: employee.SpanOfControlRequest
+	(*SpanBucket)(nil),                  // 11: employee.SpanBucket
+	(*SpanGroup)(nil),                   // 12: employee.SpanGroup
+	(*ManagerSpan)(nil),                 // 13: employee.ManagerSpan
+	(*SpanOfControl)(nil),               // 14: employee.SpanOfControl
+	(*EmployeeQuery)(nil),               // 15: employee.EmployeeQuery
+	(*QueryAggregate)(nil),              // 16: employee.QueryAggregate
+	(*QueryCondition)(nil),              // 17: employee.QueryCondition
+	(*QueryColumn)(nil),                 // 18: employee.QueryColumn
+	(*QueryRow)(nil),                    // 19: employee.QueryRow
+	(*QueryResult)(nil),                 // 20: employee.QueryResult
+	(*QueryField)(nil),                  // 21: employee.QueryField
+	(*QueryFieldList)(nil),              // 22: employee.QueryFieldList
+	nil,                                 // 23: employee.HeadcountGroup.DimensionsEntry
+	nil,                                 // 24: employee.MovementGroup.DimensionsEntry
+	nil,                                 // 25: employee.SpanGroup.DimensionsEntry
+	(*timestamppb.Timestamp)(nil),       // 26: google.protobuf.Timestamp
+	(*structpb.Value)(nil),              // 27: google.protobuf.Value
+	(*Empty)(nil),                       // 28: employee.Empty
+}
+var file_analytics_proto_depIdxs = []int32{
+	26, // 0: employee.HeadcountRequest.as_of:type_name -> google.protobuf.Timestamp
+	23, // 1: employee.HeadcountGroup.dimensions:type_name -> employee.HeadcountGroup.DimensionsEntry
+	1,  // 2: employee.HeadcountReport.groups:type_name -> employee.HeadcountGroup
+	26, // 3: employee.HeadcountTrendRequest.start:type_name -> google.protobuf.Timestamp
+	26, // 4: employee.HeadcountTrendRequest.end:type_name -> google.protobuf.Timestamp
+	26, // 5: employee.HeadcountPoint.time:type_name -> google.protobuf.Timestamp
+	1,  // 6: employee.HeadcountPoint.groups:type_name -> employee.HeadcountGroup
+	4,  // 7: employee.HeadcountTrend.points:type_name -> employee.HeadcountPoint
+	26, // 8: employee.HiresAndTerminationsRequest.start:type_name -> google.protobuf.Timestamp
+	26, // 9: employee.HiresAndTerminationsRequest.end:type_name -> google.protobuf.Timestamp
+	24, // 10: employee.MovementGroup.dimensions:type_name -> employee.MovementGroup.DimensionsEntry
+	26, // 11: employee.MovementPeriod.period_start:type_name -> google.protobuf.Timestamp
+	7,  // 12: employee.MovementPeriod.groups:type_name -> employee.MovementGroup
+	8,  // 13: employee.HiresAndTerminations.periods:type_name -> employee.MovementPeriod
+	25, // 14: employee.SpanGroup.dimensions:type_name -> employee.SpanGroup.DimensionsEntry
+	11, // 15: employee.SpanOfControl.distribution:type_name -> employee.SpanBucket
+	12, // 16: employee.SpanOfControl.groups:type_name -> employee.SpanGroup
+	13, // 17: employee.SpanOfControl.top_managers:type_name -> employee.ManagerSpan
+	16, // 18: employee.EmployeeQuery.aggregates:type_name -> employee.QueryAggregate
+	17, // 19: employee.EmployeeQuery.filter:type_name -> employee.QueryCondition
+	26, // 20: employee.EmployeeQuery.as_of:type_name -> google.protobuf.Timestamp
+	27, // 21: employee.QueryRow.values:type_name -> google.protobuf.Value
+	18, // 22: employee.QueryResult.columns:type_name -> employee.QueryColumn
+	19, // 23: employee.QueryResult.rows:type_name -> employee.QueryRow
+	21, // 24: employee.QueryFieldList.fields:type_name -> employee.QueryField
+	0,  // 25: employee.AnalyticsService.GetHeadcount:input_type -> employee.HeadcountRequest
+	3,  // 26: employee.AnalyticsService.GetHeadcountTrend:input_type -> employee.HeadcountTrendRequest
+	6,  // 27: employee.AnalyticsService.GetHiresAndTerminations:input_type -> employee.HiresAndTerminationsRequest
+	10, // 28: employee.AnalyticsService.GetSpanOfControl:input_type -> employee.SpanOfControlRequest
+	15, // 29: employee.AnalyticsService.QueryEmployees:input_type -> employee.EmployeeQuery
+	28, // 30: employee.AnalyticsService.ListQueryFields:input_type -> employee.Empty
+	2,  // 31: employee.AnalyticsService.GetHeadcount:output_type -> employee.HeadcountReport
+	5,  // 32: employee.AnalyticsService.GetHeadcountTrend:output_type -> employee.HeadcountTrend
+	9,  // 33: employee.AnalyticsService.GetHiresAndTerminations:output_type -> employee.HiresAndTerminations
+	14, // 34: employee.AnalyticsService.GetSpanOfControl:output_type -> employee.SpanOfControl
+	20, // 35: employee.AnalyticsService.QueryEmployees:output_type -> employee.QueryResult
+	22, // 36: employee.AnalyticsService.ListQueryFields:output_type -> employee.QueryFieldList
+	31, // [31:37] is the sub-list for method output_type
+	25, // [25:31] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_analytics_proto_init() }
+func file_analytics_proto_init() {
+	if File_analytics_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analytics_proto_goTypes,
+		DependencyIndexes: file_analytics_proto_depIdxs,
+		MessageInfos:      file_analytics_proto_msgTypes,
+	}.Build()
+	File_analytics_proto = out.File
+	file_analytics_proto_goTypes = nil
+	file_analytics_proto_depIdxs = nil
+}
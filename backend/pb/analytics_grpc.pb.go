@@ -0,0 +1,345 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: analytics.proto
+
+package employee
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetHeadcount_FullMethodName            = "/employee.AnalyticsService/GetHeadcount"
+	AnalyticsService_GetHeadcountTrend_FullMethodName       = "/employee.AnalyticsService/GetHeadcountTrend"
+	AnalyticsService_GetHiresAndTerminations_FullMethodName = "/employee.AnalyticsService/GetHiresAndTerminations"
+	AnalyticsService_GetSpanOfControl_FullMethodName        = "/employee.AnalyticsService/GetSpanOfControl"
+	AnalyticsService_QueryEmployees_FullMethodName          = "/employee.AnalyticsService/QueryEmployees"
+	AnalyticsService_ListQueryFields_FullMethodName         = "/employee.AnalyticsService/ListQueryFields"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalyticsService aggregates employees for dashboards. Counts can be
+// grouped by any field ListQueryFields lists as groupable, such as
+// department, position_id or status; dates are grouped by a period, as in
+// hire_date:year. Terminated employees are never counted in headcounts.
+type AnalyticsServiceClient interface {
+	// Headcount now, or at as_of
+	GetHeadcount(ctx context.Context, in *HeadcountRequest, opts ...grpc.CallOption) (*HeadcountReport, error)
+	// Headcount at the start of each period from start to end, from the
+	// employee history
+	GetHeadcountTrend(ctx context.Context, in *HeadcountTrendRequest, opts ...grpc.CallOption) (*HeadcountTrend, error)
+	// Hires (including rehires) and terminations per period, by their
+	// effective date. Groups use the employees' current values; deleted
+	// employees are not counted.
+	GetHiresAndTerminations(ctx context.Context, in *HiresAndTerminationsRequest, opts ...grpc.CallOption) (*HiresAndTerminations, error)
+	// How many direct reports managers have. Groups use the managers'
+	// values, e.g. their department.
+	GetSpanOfControl(ctx context.Context, in *SpanOfControlRequest, opts ...grpc.CallOption) (*SpanOfControl, error)
+	// Ad-hoc group-by and pivot queries, checked against the fields
+	// ListQueryFields returns. Over REST, send Accept: text/csv to get the
+	// table as CSV.
+	QueryEmployees(ctx context.Context, in *EmployeeQuery, opts ...grpc.CallOption) (*QueryResult, error)
+	// The fields queries can use, and how
+	ListQueryFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QueryFieldList, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetHeadcount(ctx context.Context, in *HeadcountRequest, opts ...grpc.CallOption) (*HeadcountReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeadcountReport)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetHeadcount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetHeadcountTrend(ctx context.Context, in *HeadcountTrendRequest, opts ...grpc.CallOption) (*HeadcountTrend, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeadcountTrend)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetHeadcountTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetHiresAndTerminations(ctx context.Context, in *HiresAndTerminationsRequest, opts ...grpc.CallOption) (*HiresAndTerminations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HiresAndTerminations)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetHiresAndTerminations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetSpanOfControl(ctx context.Context, in *SpanOfControlRequest, opts ...grpc.CallOption) (*SpanOfControl, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpanOfControl)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetSpanOfControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) QueryEmployees(ctx context.Context, in *EmployeeQuery, opts ...grpc.CallOption) (*QueryResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResult)
+	err := c.cc.Invoke(ctx, AnalyticsService_QueryEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListQueryFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*QueryFieldList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryFieldList)
+	err := c.cc.Invoke(ctx, AnalyticsService_ListQueryFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// AnalyticsService aggregates employees for dashboards. Counts can be
+// grouped by any field ListQueryFields lists as groupable, such as
+// department, position_id or status; dates are grouped by a period, as in
+// hire_date:year. Terminated employees are never counted in headcounts.
+type AnalyticsServiceServer interface {
+	// Headcount now, or at as_of
+	GetHeadcount(context.Context, *HeadcountRequest) (*HeadcountReport, error)
+	// Headcount at the start of each period from start to end, from the
+	// employee history
+	GetHeadcountTrend(context.Context, *HeadcountTrendRequest) (*HeadcountTrend, error)
+	// Hires (including rehires) and terminations per period, by their
+	// effective date. Groups use the employees' current values; deleted
+	// employees are not counted.
+	GetHiresAndTerminations(context.Context, *HiresAndTerminationsRequest) (*HiresAndTerminations, error)
+	// How many direct reports managers have. Groups use the managers'
+	// values, e.g. their department.
+	GetSpanOfControl(context.Context, *SpanOfControlRequest) (*SpanOfControl, error)
+	// Ad-hoc group-by and pivot queries, checked against the fields
+	// ListQueryFields returns. Over REST, send Accept: text/csv to get the
+	// table as CSV.
+	QueryEmployees(context.Context, *EmployeeQuery) (*QueryResult, error)
+	// The fields queries can use, and how
+	ListQueryFields(context.Context, *Empty) (*QueryFieldList, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetHeadcount(context.Context, *HeadcountRequest) (*HeadcountReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeadcount not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetHeadcountTrend(context.Context, *HeadcountTrendRequest) (*HeadcountTrend, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeadcountTrend not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetHiresAndTerminations(context.Context, *HiresAndTerminationsRequest) (*HiresAndTerminations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHiresAndTerminations not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetSpanOfControl(context.Context, *SpanOfControlRequest) (*SpanOfControl, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpanOfControl not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) QueryEmployees(context.Context, *EmployeeQuery) (*QueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryEmployees not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListQueryFields(context.Context, *Empty) (*QueryFieldList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueryFields not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetHeadcount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadcountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetHeadcount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetHeadcount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetHeadcount(ctx, req.(*HeadcountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetHeadcountTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadcountTrendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetHeadcountTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetHeadcountTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetHeadcountTrend(ctx, req.(*HeadcountTrendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetHiresAndTerminations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HiresAndTerminationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetHiresAndTerminations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetHiresAndTerminations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetHiresAndTerminations(ctx, req.(*HiresAndTerminationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetSpanOfControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpanOfControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetSpanOfControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetSpanOfControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetSpanOfControl(ctx, req.(*SpanOfControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_QueryEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).QueryEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_QueryEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).QueryEmployees(ctx, req.(*EmployeeQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListQueryFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListQueryFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ListQueryFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListQueryFields(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeadcount",
+			Handler:    _AnalyticsService_GetHeadcount_Handler,
+		},
+		{
+			MethodName: "GetHeadcountTrend",
+			Handler:    _AnalyticsService_GetHeadcountTrend_Handler,
+		},
+		{
+			MethodName: "GetHiresAndTerminations",
+			Handler:    _AnalyticsService_GetHiresAndTerminations_Handler,
+		},
+		{
+			MethodName: "GetSpanOfControl",
+			Handler:    _AnalyticsService_GetSpanOfControl_Handler,
+		},
+		{
+			MethodName: "QueryEmployees",
+			Handler:    _AnalyticsService_QueryEmployees_Handler,
+		},
+		{
+			MethodName: "ListQueryFields",
+			Handler:    _AnalyticsService_ListQueryFields_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "analytics.proto",
+}
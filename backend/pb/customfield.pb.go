@@ -0,0 +1,371 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: customfield.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CustomFieldType int32
+
+const (
+	CustomFieldType_CUSTOM_FIELD_TYPE_UNSPECIFIED CustomFieldType = 0
+	CustomFieldType_FIELD_STRING                  CustomFieldType = 1
+	CustomFieldType_FIELD_NUMBER                  CustomFieldType = 2
+	CustomFieldType_FIELD_BOOLEAN                 CustomFieldType = 3
+	// Given as RFC 3339 or YYYY-MM-DD, returned as RFC 3339
+	CustomFieldType_FIELD_DATE CustomFieldType = 4
+	// One of enum_values
+	CustomFieldType_FIELD_ENUM CustomFieldType = 5
+)
+
+// Enum value maps for CustomFieldType.
+var (
+	CustomFieldType_name = map[int32]string{
+		0: "CUSTOM_FIELD_TYPE_UNSPECIFIED",
+		1: "FIELD_STRING",
+		2: "FIELD_NUMBER",
+		3: "FIELD_BOOLEAN",
+		4: "FIELD_DATE",
+		5: "FIELD_ENUM",
+	}
+	CustomFieldType_value = map[string]int32{
+		"CUSTOM_FIELD_TYPE_UNSPECIFIED": 0,
+		"FIELD_STRING":                  1,
+		"FIELD_NUMBER":                  2,
+		"FIELD_BOOLEAN":                 3,
+		"FIELD_DATE":                    4,
+		"FIELD_ENUM":                    5,
+	}
+)
+
+func (x CustomFieldType) Enum() *CustomFieldType {
+	p := new(CustomFieldType)
+	*p = x
+	return p
+}
+
+func (x CustomFieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomFieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_customfield_proto_enumTypes[0].Descriptor()
+}
+
+func (CustomFieldType) Type() protoreflect.EnumType {
+	return &file_customfield_proto_enumTypes[0]
+}
+
+func (x CustomFieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomFieldType.Descriptor instead.
+func (CustomFieldType) EnumDescriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{0}
+}
+
+type CustomFieldName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldName) Reset() {
+	*x = CustomFieldName{}
+	mi := &file_customfield_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldName) ProtoMessage() {}
+
+func (x *CustomFieldName) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldName.ProtoReflect.Descriptor instead.
+func (*CustomFieldName) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomFieldName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CustomField struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key in custom_fields, e.g. badge_number: lower case letters, digits and
+	// underscores, starting with a letter. Queries refer to it as
+	// custom.badge_number.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Display name, e.g. Badge number
+	Label string          `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Type  CustomFieldType `protobuf:"varint,3,opt,name=type,proto3,enum=employee.CustomFieldType" json:"type,omitempty"`
+	// Employees cannot be saved without a value
+	Required bool `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	// The allowed values of an enum field
+	EnumValues []string `protobuf:"bytes,5,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	// Regular expression the whole of a string or enum value must match
+	Pattern       string `protobuf:"bytes,6,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomField) Reset() {
+	*x = CustomField{}
+	mi := &file_customfield_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomField) ProtoMessage() {}
+
+func (x *CustomField) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomField.ProtoReflect.Descriptor instead.
+func (*CustomField) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CustomField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomField) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *CustomField) GetType() CustomFieldType {
+	if x != nil {
+		return x.Type
+	}
+	return CustomFieldType_CUSTOM_FIELD_TYPE_UNSPECIFIED
+}
+
+func (x *CustomField) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *CustomField) GetEnumValues() []string {
+	if x != nil {
+		return x.EnumValues
+	}
+	return nil
+}
+
+func (x *CustomField) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *CustomField) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CustomFieldList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*CustomField         `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldList) Reset() {
+	*x = CustomFieldList{}
+	mi := &file_customfield_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldList) ProtoMessage() {}
+
+func (x *CustomFieldList) ProtoReflect() protoreflect.Message {
+	mi := &file_customfield_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldList.ProtoReflect.Descriptor instead.
+func (*CustomFieldList) Descriptor() ([]byte, []int) {
+	return file_customfield_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CustomFieldList) GetFields() []*CustomField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_customfield_proto protoreflect.FileDescriptor
+
+const file_customfield_proto_rawDesc = "" +
+	"\n" +
+	"\x11customfield.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x0eemployee.proto\"%\n" +
+	"\x0fCustomFieldName\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xdf\x01\n" +
+	"\vCustomField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.employee.CustomFieldTypeR\x04type\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12\x1f\n" +
+	"\venum_values\x18\x05 \x03(\tR\n" +
+	"enumValues\x12\x18\n" +
+	"\apattern\x18\x06 \x01(\tR\apattern\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"@\n" +
+	"\x0fCustomFieldList\x12-\n" +
+	"\x06fields\x18\x01 \x03(\v2\x15.employee.CustomFieldR\x06fields*\x8b\x01\n" +
+	"\x0fCustomFieldType\x12!\n" +
+	"\x1dCUSTOM_FIELD_TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fFIELD_STRING\x10\x01\x12\x10\n" +
+	"\fFIELD_NUMBER\x10\x02\x12\x11\n" +
+	"\rFIELD_BOOLEAN\x10\x03\x12\x0e\n" +
+	"\n" +
+	"FIELD_DATE\x10\x04\x12\x0e\n" +
+	"\n" +
+	"FIELD_ENUM\x10\x052\x81\x04\n" +
+	"\x12CustomFieldService\x12Y\n" +
+	"\x10ListCustomFields\x12\x0f.employee.Empty\x1a\x19.employee.CustomFieldList\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/custom-fields\x12d\n" +
+	"\x0eGetCustomField\x12\x19.employee.CustomFieldName\x1a\x15.employee.CustomField\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/custom-fields/{name}\x12_\n" +
+	"\x11CreateCustomField\x12\x15.employee.CustomField\x1a\x15.employee.CustomField\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/custom-fields\x12f\n" +
+	"\x11UpdateCustomField\x12\x15.employee.CustomField\x1a\x15.employee.CustomField\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/v1/custom-fields/{name}\x12a\n" +
+	"\x11DeleteCustomField\x12\x19.employee.CustomFieldName\x1a\x0f.employee.Empty\" \x82\xd3\xe4\x93\x02\x1a*\x18/v1/custom-fields/{name}B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_customfield_proto_rawDescOnce sync.Once
+	file_customfield_proto_rawDescData []byte
+)
+
+func file_customfield_proto_rawDescGZIP() []byte {
+	file_customfield_proto_rawDescOnce.Do(func() {
+		file_customfield_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_customfield_proto_rawDesc), len(file_customfield_proto_rawDesc)))
+	})
+	return file_customfield_proto_rawDescData
+}
+
+var file_customfield_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_customfield_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_customfield_proto_goTypes = []any{
+	(CustomFieldType)(0),    // 0: employee.CustomFieldType
+	(*CustomFieldName)(nil), // 1: employee.CustomFieldName
+	(*CustomField)(nil),     // 2: employee.CustomField
+	(*CustomFieldList)(nil), // 3: employee.CustomFieldList
+	(*Empty)(nil),           // 4: employee.Empty
+}
+var file_customfield_proto_depIdxs = []int32{
+	0, // 0: employee.CustomField.type:type_name -> employee.CustomFieldType
+	2, // 1: employee.CustomFieldList.fields:type_name -> employee.CustomField
+	4, // 2: employee.CustomFieldService.ListCustomFields:input_type -> employee.Empty
+	1, // 3: employee.CustomFieldService.GetCustomField:input_type -> employee.CustomFieldName
+	2, // 4: employee.CustomFieldService.CreateCustomField:input_type -> employee.CustomField
+	2, // 5: employee.CustomFieldService.UpdateCustomField:input_type -> employee.CustomField
+	1, // 6: employee.CustomFieldService.DeleteCustomField:input_type -> employee.CustomFieldName
+	3, // 7: employee.CustomFieldService.ListCustomFields:output_type -> employee.CustomFieldList
+	2, // 8: employee.CustomFieldService.GetCustomField:output_type -> employee.CustomField
+	2, // 9: employee.CustomFieldService.CreateCustomField:output_type -> employee.CustomField
+	2, // 10: employee.CustomFieldService.UpdateCustomField:output_type -> employee.CustomField
+	4, // 11: employee.CustomFieldService.DeleteCustomField:output_type -> employee.Empty
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_customfield_proto_init() }
+func file_customfield_proto_init() {
+	if File_customfield_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_customfield_proto_rawDesc), len(file_customfield_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_customfield_proto_goTypes,
+		DependencyIndexes: file_customfield_proto_depIdxs,
+		EnumInfos:         file_customfield_proto_enumTypes,
+		MessageInfos:      file_customfield_proto_msgTypes,
+	}.Build()
+	File_customfield_proto = out.File
+	file_customfield_proto_goTypes = nil
+	file_customfield_proto_depIdxs = nil
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: customfield.proto
+
+package employee
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CustomFieldService_ListCustomFields_FullMethodName  = "/employee.CustomFieldService/ListCustomFields"
+	CustomFieldService_GetCustomField_FullMethodName    = "/employee.CustomFieldService/GetCustomField"
+	CustomFieldService_CreateCustomField_FullMethodName = "/employee.CustomFieldService/CreateCustomField"
+	CustomFieldService_UpdateCustomField_FullMethodName = "/employee.CustomFieldService/UpdateCustomField"
+	CustomFieldService_DeleteCustomField_FullMethodName = "/employee.CustomFieldService/DeleteCustomField"
+)
+
+// CustomFieldServiceClient is the client API for CustomFieldService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CustomFieldService defines the extra attributes employees can carry in
+// custom_fields, such as a badge number or shirt size. Values are checked
+// against their definition whenever an employee is written.
+type CustomFieldServiceClient interface {
+	ListCustomFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CustomFieldList, error)
+	GetCustomField(ctx context.Context, in *CustomFieldName, opts ...grpc.CallOption) (*CustomField, error)
+	CreateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error)
+	// The type cannot be changed. Stored values are checked against the new
+	// definition the next time their employee is written.
+	UpdateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error)
+	// Only fields no employee has a value for can be deleted
+	DeleteCustomField(ctx context.Context, in *CustomFieldName, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type customFieldServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCustomFieldServiceClient(cc grpc.ClientConnInterface) CustomFieldServiceClient {
+	return &customFieldServiceClient{cc}
+}
+
+func (c *customFieldServiceClient) ListCustomFields(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CustomFieldList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomFieldList)
+	err := c.cc.Invoke(ctx, CustomFieldService_ListCustomFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customFieldServiceClient) GetCustomField(ctx context.Context, in *CustomFieldName, opts ...grpc.CallOption) (*CustomField, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomField)
+	err := c.cc.Invoke(ctx, CustomFieldService_GetCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customFieldServiceClient) CreateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomField)
+	err := c.cc.Invoke(ctx, CustomFieldService_CreateCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customFieldServiceClient) UpdateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomField)
+	err := c.cc.Invoke(ctx, CustomFieldService_UpdateCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customFieldServiceClient) DeleteCustomField(ctx context.Context, in *CustomFieldName, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, CustomFieldService_DeleteCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomFieldServiceServer is the server API for CustomFieldService service.
+// All implementations must embed UnimplementedCustomFieldServiceServer
+// for forward compatibility.
+//
+// CustomFieldService defines the extra attributes employees can carry in
+// custom_fields, such as a badge number or shirt size. Values are checked
+// against their definition whenever an employee is written.
+type CustomFieldServiceServer interface {
+	ListCustomFields(context.Context, *Empty) (*CustomFieldList, error)
+	GetCustomField(context.Context, *CustomFieldName) (*CustomField, error)
+	CreateCustomField(context.Context, *CustomField) (*CustomField, error)
+	// The type cannot be changed. Stored values are checked against the new
+	// definition the next time their employee is written.
+	UpdateCustomField(context.Context, *CustomField) (*CustomField, error)
+	// Only fields no employee has a value for can be deleted
+	DeleteCustomField(context.Context, *CustomFieldName) (*Empty, error)
+	mustEmbedUnimplementedCustomFieldServiceServer()
+}
+
+// UnimplementedCustomFieldServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCustomFieldServiceServer struct{}
+
+func (UnimplementedCustomFieldServiceServer) ListCustomFields(context.Context, *Empty) (*CustomFieldList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomFields not implemented")
+}
+func (UnimplementedCustomFieldServiceServer) GetCustomField(context.Context, *CustomFieldName) (*CustomField, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomField not implemented")
+}
+func (UnimplementedCustomFieldServiceServer) CreateCustomField(context.Context, *CustomField) (*CustomField, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomField not implemented")
+}
+func (UnimplementedCustomFieldServiceServer) UpdateCustomField(context.Context, *CustomField) (*CustomField, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomField not implemented")
+}
+func (UnimplementedCustomFieldServiceServer) DeleteCustomField(context.Context, *CustomFieldName) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomField not implemented")
+}
+func (UnimplementedCustomFieldServiceServer) mustEmbedUnimplementedCustomFieldServiceServer() {}
+func (UnimplementedCustomFieldServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCustomFieldServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CustomFieldServiceServer will
+// result in compilation errors.
+type UnsafeCustomFieldServiceServer interface {
+	mustEmbedUnimplementedCustomFieldServiceServer()
+}
+
+func RegisterCustomFieldServiceServer(s grpc.ServiceRegistrar, srv CustomFieldServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCustomFieldServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CustomFieldService_ServiceDesc, srv)
+}
+
+func _CustomFieldService_ListCustomFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomFieldServiceServer).ListCustomFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomFieldService_ListCustomFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomFieldServiceServer).ListCustomFields(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomFieldService_GetCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomFieldName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomFieldServiceServer).GetCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomFieldService_GetCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomFieldServiceServer).GetCustomField(ctx, req.(*CustomFieldName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomFieldService_CreateCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomField)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomFieldServiceServer).CreateCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomFieldService_CreateCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomFieldServiceServer).CreateCustomField(ctx, req.(*CustomField))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomFieldService_UpdateCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomField)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomFieldServiceServer).UpdateCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomFieldService_UpdateCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomFieldServiceServer).UpdateCustomField(ctx, req.(*CustomField))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomFieldService_DeleteCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomFieldName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomFieldServiceServer).DeleteCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomFieldService_DeleteCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomFieldServiceServer).DeleteCustomField(ctx, req.(*CustomFieldName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CustomFieldService_ServiceDesc is the grpc.ServiceDesc for CustomFieldService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CustomFieldService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.CustomFieldService",
+	HandlerType: (*CustomFieldServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCustomFields",
+			Handler:    _CustomFieldService_ListCustomFields_Handler,
+		},
+		{
+			MethodName: "GetCustomField",
+			Handler:    _CustomFieldService_GetCustomField_Handler,
+		},
+		{
+			MethodName: "CreateCustomField",
+			Handler:    _CustomFieldService_CreateCustomField_Handler,
+		},
+		{
+			MethodName: "UpdateCustomField",
+			Handler:    _CustomFieldService_UpdateCustomField_Handler,
+		},
+		{
+			MethodName: "DeleteCustomField",
+			Handler:    _CustomFieldService_DeleteCustomField_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "customfield.proto",
+}
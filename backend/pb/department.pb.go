@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: department.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DepartmentID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepartmentID) Reset() {
+	*x = DepartmentID{}
+	mi := &file_department_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepartmentID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepartmentID) ProtoMessage() {}
+
+func (x *DepartmentID) ProtoReflect() protoreflect.Message {
+	mi := &file_department_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepartmentID.ProtoReflect.Descriptor instead.
+func (*DepartmentID) Descriptor() ([]byte, []int) {
+	return file_department_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DepartmentID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Department struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Short unique code, e.g. ENG
+	Code     string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId string `protobuf:"bytes,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Employee ID of the department head
+	HeadId     string `protobuf:"bytes,5,opt,name=head_id,json=headId,proto3" json:"head_id,omitempty"`
+	CostCenter string `protobuf:"bytes,6,opt,name=cost_center,json=costCenter,proto3" json:"cost_center,omitempty"`
+	// ID of the department in another system, such as the group in the
+	// identity provider that provisions it through SCIM
+	ExternalId    string `protobuf:"bytes,7,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Department) Reset() {
+	*x = Department{}
+	mi := &file_department_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Department) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Department) ProtoMessage() {}
+
+func (x *Department) ProtoReflect() protoreflect.Message {
+	mi := &file_department_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Department.ProtoReflect.Descriptor instead.
+func (*Department) Descriptor() ([]byte, []int) {
+	return file_department_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Department) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Department) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Department) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Department) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Department) GetHeadId() string {
+	if x != nil {
+		return x.HeadId
+	}
+	return ""
+}
+
+func (x *Department) GetCostCenter() string {
+	if x != nil {
+		return x.CostCenter
+	}
+	return ""
+}
+
+func (x *Department) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type DepartmentList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Departments   []*Department          `protobuf:"bytes,1,rep,name=departments,proto3" json:"departments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepartmentList) Reset() {
+	*x = DepartmentList{}
+	mi := &file_department_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepartmentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepartmentList) ProtoMessage() {}
+
+func (x *DepartmentList) ProtoReflect() protoreflect.Message {
+	mi := &file_department_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepartmentList.ProtoReflect.Descriptor instead.
+func (*DepartmentList) Descriptor() ([]byte, []int) {
+	return file_department_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DepartmentList) GetDepartments() []*Department {
+	if x != nil {
+		return x.Departments
+	}
+	return nil
+}
+
+var File_department_proto protoreflect.FileDescriptor
+
+const file_department_proto_rawDesc = "" +
+	"\n" +
+	"\x10department.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x0eemployee.proto\"\x1e\n" +
+	"\fDepartmentID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbc\x01\n" +
+	"\n" +
+	"Department\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x04 \x01(\tR\bparentId\x12\x17\n" +
+	"\ahead_id\x18\x05 \x01(\tR\x06headId\x12\x1f\n" +
+	"\vcost_center\x18\x06 \x01(\tR\n" +
+	"costCenter\x12\x1f\n" +
+	"\vexternal_id\x18\a \x01(\tR\n" +
+	"externalId\"H\n" +
+	"\x0eDepartmentList\x126\n" +
+	"\vdepartments\x18\x01 \x03(\v2\x14.employee.DepartmentR\vdepartments2\xdf\x03\n" +
+	"\x11DepartmentService\x12U\n" +
+	"\x0fListDepartments\x12\x0f.employee.Empty\x1a\x18.employee.DepartmentList\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/departments\x12[\n" +
+	"\rGetDepartment\x12\x16.employee.DepartmentID\x1a\x14.employee.Department\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/departments/{id}\x12Z\n" +
+	"\x10CreateDepartment\x12\x14.employee.Department\x1a\x14.employee.Department\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/departments\x12_\n" +
+	"\x10UpdateDepartment\x12\x14.employee.Department\x1a\x14.employee.Department\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\x1a\x14/v1/departments/{id}\x12Y\n" +
+	"\x10DeleteDepartment\x12\x16.employee.DepartmentID\x1a\x0f.employee.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/departments/{id}B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_department_proto_rawDescOnce sync.Once
+	file_department_proto_rawDescData []byte
+)
+
+func file_department_proto_rawDescGZIP() []byte {
+	file_department_proto_rawDescOnce.Do(func() {
+		file_department_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_department_proto_rawDesc), len(file_department_proto_rawDesc)))
+	})
+	return file_department_proto_rawDescData
+}
+
+var file_department_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_department_proto_goTypes = []any{
+	(*DepartmentID)(nil),   // 0: employee.DepartmentID
+	(*Department)(nil),     // 1: employee.Department
+	(*DepartmentList)(nil), // 2: employee.DepartmentList
+	(*Empty)(nil),          // 3: employee.Empty
+}
+var file_department_proto_depIdxs = []int32{
+	1, // 0: employee.DepartmentList.departments:type_name -> employee.Department
+	3, // 1: employee.DepartmentService.ListDepartments:input_type -> employee.Empty
+	0, // 2: employee.DepartmentService.GetDepartment:input_type -> employee.DepartmentID
+	1, // 3: employee.DepartmentService.CreateDepartment:input_type -> employee.Department
+	1, // 4: employee.DepartmentService.UpdateDepartment:input_type -> employee.Department
+	0, // 5: employee.DepartmentService.DeleteDepartment:input_type -> employee.DepartmentID
+	2, // 6: employee.DepartmentService.ListDepartments:output_type -> employee.DepartmentList
+	1, // 7: employee.DepartmentService.GetDepartment:output_type -> employee.Department
+	1, // 8: employee.DepartmentService.CreateDepartment:output_type -> employee.Department
+	1, // 9: employee.DepartmentService.UpdateDepartment:output_type -> employee.Department
+	3, // 10: employee.DepartmentService.DeleteDepartment:output_type -> employee.Empty
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_department_proto_init() }
+func file_department_proto_init() {
+	if File_department_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_department_proto_rawDesc), len(file_department_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_department_proto_goTypes,
+		DependencyIndexes: file_department_proto_depIdxs,
+		MessageInfos:      file_department_proto_msgTypes,
+	}.Build()
+	File_department_proto = out.File
+	file_department_proto_goTypes = nil
+	file_department_proto_depIdxs = nil
+}
@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: department.proto
+
+package employee
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DepartmentService_ListDepartments_FullMethodName  = "/employee.DepartmentService/ListDepartments"
+	DepartmentService_GetDepartment_FullMethodName    = "/employee.DepartmentService/GetDepartment"
+	DepartmentService_CreateDepartment_FullMethodName = "/employee.DepartmentService/CreateDepartment"
+	DepartmentService_UpdateDepartment_FullMethodName = "/employee.DepartmentService/UpdateDepartment"
+	DepartmentService_DeleteDepartment_FullMethodName = "/employee.DepartmentService/DeleteDepartment"
+)
+
+// DepartmentServiceClient is the client API for DepartmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DepartmentServiceClient interface {
+	ListDepartments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DepartmentList, error)
+	GetDepartment(ctx context.Context, in *DepartmentID, opts ...grpc.CallOption) (*Department, error)
+	CreateDepartment(ctx context.Context, in *Department, opts ...grpc.CallOption) (*Department, error)
+	UpdateDepartment(ctx context.Context, in *Department, opts ...grpc.CallOption) (*Department, error)
+	DeleteDepartment(ctx context.Context, in *DepartmentID, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type departmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDepartmentServiceClient(cc grpc.ClientConnInterface) DepartmentServiceClient {
+	return &departmentServiceClient{cc}
+}
+
+func (c *departmentServiceClient) ListDepartments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DepartmentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepartmentList)
+	err := c.cc.Invoke(ctx, DepartmentService_ListDepartments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *departmentServiceClient) GetDepartment(ctx context.Context, in *DepartmentID, opts ...grpc.CallOption) (*Department, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Department)
+	err := c.cc.Invoke(ctx, DepartmentService_GetDepartment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *departmentServiceClient) CreateDepartment(ctx context.Context, in *Department, opts ...grpc.CallOption) (*Department, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Department)
+	err := c.cc.Invoke(ctx, DepartmentService_CreateDepartment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *departmentServiceClient) UpdateDepartment(ctx context.Context, in *Department, opts ...grpc.CallOption) (*Department, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Department)
+	err := c.cc.Invoke(ctx, DepartmentService_UpdateDepartment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *departmentServiceClient) DeleteDepartment(ctx context.Context, in *DepartmentID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, DepartmentService_DeleteDepartment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DepartmentServiceServer is the server API for DepartmentService service.
+// All implementations must embed UnimplementedDepartmentServiceServer
+// for forward compatibility.
+type DepartmentServiceServer interface {
+	ListDepartments(context.Context, *Empty) (*DepartmentList, error)
+	GetDepartment(context.Context, *DepartmentID) (*Department, error)
+	CreateDepartment(context.Context, *Department) (*Department, error)
+	UpdateDepartment(context.Context, *Department) (*Department, error)
+	DeleteDepartment(context.Context, *DepartmentID) (*Empty, error)
+	mustEmbedUnimplementedDepartmentServiceServer()
+}
+
+// UnimplementedDepartmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDepartmentServiceServer struct{}
+
+func (UnimplementedDepartmentServiceServer) ListDepartments(context.Context, *Empty) (*DepartmentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDepartments not implemented")
+}
+func (UnimplementedDepartmentServiceServer) GetDepartment(context.Context, *DepartmentID) (*Department, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepartment not implemented")
+}
+func (UnimplementedDepartmentServiceServer) CreateDepartment(context.Context, *Department) (*Department, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDepartment not implemented")
+}
+func (UnimplementedDepartmentServiceServer) UpdateDepartment(context.Context, *Department) (*Department, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDepartment not implemented")
+}
+func (UnimplementedDepartmentServiceServer) DeleteDepartment(context.Context, *DepartmentID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDepartment not implemented")
+}
+func (UnimplementedDepartmentServiceServer) mustEmbedUnimplementedDepartmentServiceServer() {}
+func (UnimplementedDepartmentServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDepartmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DepartmentServiceServer will
+// result in compilation errors.
+type UnsafeDepartmentServiceServer interface {
+	mustEmbedUnimplementedDepartmentServiceServer()
+}
+
+func RegisterDepartmentServiceServer(s grpc.ServiceRegistrar, srv DepartmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDepartmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DepartmentService_ServiceDesc, srv)
+}
+
+func _DepartmentService_ListDepartments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DepartmentServiceServer).ListDepartments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DepartmentService_ListDepartments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DepartmentServiceServer).ListDepartments(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DepartmentService_GetDepartment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepartmentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DepartmentServiceServer).GetDepartment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DepartmentService_GetDepartment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DepartmentServiceServer).GetDepartment(ctx, req.(*DepartmentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DepartmentService_CreateDepartment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Department)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DepartmentServiceServer).CreateDepartment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DepartmentService_CreateDepartment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DepartmentServiceServer).CreateDepartment(ctx, req.(*Department))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DepartmentService_UpdateDepartment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Department)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DepartmentServiceServer).UpdateDepartment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DepartmentService_UpdateDepartment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DepartmentServiceServer).UpdateDepartment(ctx, req.(*Department))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DepartmentService_DeleteDepartment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepartmentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DepartmentServiceServer).DeleteDepartment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DepartmentService_DeleteDepartment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DepartmentServiceServer).DeleteDepartment(ctx, req.(*DepartmentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DepartmentService_ServiceDesc is the grpc.ServiceDesc for DepartmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DepartmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.DepartmentService",
+	HandlerType: (*DepartmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDepartments",
+			Handler:    _DepartmentService_ListDepartments_Handler,
+		},
+		{
+			MethodName: "GetDepartment",
+			Handler:    _DepartmentService_GetDepartment_Handler,
+		},
+		{
+			MethodName: "CreateDepartment",
+			Handler:    _DepartmentService_CreateDepartment_Handler,
+		},
+		{
+			MethodName: "UpdateDepartment",
+			Handler:    _DepartmentService_UpdateDepartment_Handler,
+		},
+		{
+			MethodName: "DeleteDepartment",
+			Handler:    _DepartmentService_DeleteDepartment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "department.proto",
+}
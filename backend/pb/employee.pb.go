@@ -8,8 +8,11 @@ package employee
 
 import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +25,179 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EmploymentStatus int32
+
+const (
+	// Records created before statuses existed read as ACTIVE
+	EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED EmploymentStatus = 0
+	EmploymentStatus_CANDIDATE                     EmploymentStatus = 1
+	EmploymentStatus_PRE_BOARDING                  EmploymentStatus = 2
+	EmploymentStatus_ACTIVE                        EmploymentStatus = 3
+	EmploymentStatus_ON_LEAVE                      EmploymentStatus = 4
+	EmploymentStatus_SUSPENDED                     EmploymentStatus = 5
+	EmploymentStatus_TERMINATED                    EmploymentStatus = 6
+)
+
+// Enum value maps for EmploymentStatus.
+var (
+	EmploymentStatus_name = map[int32]string{
+		0: "EMPLOYMENT_STATUS_UNSPECIFIED",
+		1: "CANDIDATE",
+		2: "PRE_BOARDING",
+		3: "ACTIVE",
+		4: "ON_LEAVE",
+		5: "SUSPENDED",
+		6: "TERMINATED",
+	}
+	EmploymentStatus_value = map[string]int32{
+		"EMPLOYMENT_STATUS_UNSPECIFIED": 0,
+		"CANDIDATE":                     1,
+		"PRE_BOARDING":                  2,
+		"ACTIVE":                        3,
+		"ON_LEAVE":                      4,
+		"SUSPENDED":                     5,
+		"TERMINATED":                    6,
+	}
+)
+
+func (x EmploymentStatus) Enum() *EmploymentStatus {
+	p := new(EmploymentStatus)
+	*p = x
+	return p
+}
+
+func (x EmploymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmploymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_employee_proto_enumTypes[0].Descriptor()
+}
+
+func (EmploymentStatus) Type() protoreflect.EnumType {
+	return &file_employee_proto_enumTypes[0]
+}
+
+func (x EmploymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmploymentStatus.Descriptor instead.
+func (EmploymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{0}
+}
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CREATED                 ChangeType = 1
+	ChangeType_UPDATED                 ChangeType = 2
+	ChangeType_DELETED                 ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CREATED":                 1,
+		"UPDATED":                 2,
+		"DELETED":                 3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_employee_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_employee_proto_enumTypes[1]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{1}
+}
+
+// How a merge picks a field's value
+type MergePolicy int32
+
+const (
+	// MERGE_PREFER_NON_EMPTY
+	MergePolicy_MERGE_POLICY_UNSPECIFIED MergePolicy = 0
+	MergePolicy_MERGE_KEEP_SURVIVOR      MergePolicy = 1
+	MergePolicy_MERGE_KEEP_DUPLICATE     MergePolicy = 2
+	// The survivor's value, or the duplicate's if the survivor has none
+	MergePolicy_MERGE_PREFER_NON_EMPTY MergePolicy = 3
+	// The value of the record changed last, or the other's if it has none
+	MergePolicy_MERGE_PREFER_NEWER MergePolicy = 4
+)
+
+// Enum value maps for MergePolicy.
+var (
+	MergePolicy_name = map[int32]string{
+		0: "MERGE_POLICY_UNSPECIFIED",
+		1: "MERGE_KEEP_SURVIVOR",
+		2: "MERGE_KEEP_DUPLICATE",
+		3: "MERGE_PREFER_NON_EMPTY",
+		4: "MERGE_PREFER_NEWER",
+	}
+	MergePolicy_value = map[string]int32{
+		"MERGE_POLICY_UNSPECIFIED": 0,
+		"MERGE_KEEP_SURVIVOR":      1,
+		"MERGE_KEEP_DUPLICATE":     2,
+		"MERGE_PREFER_NON_EMPTY":   3,
+		"MERGE_PREFER_NEWER":       4,
+	}
+)
+
+func (x MergePolicy) Enum() *MergePolicy {
+	p := new(MergePolicy)
+	*p = x
+	return p
+}
+
+func (x MergePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_employee_proto_enumTypes[2].Descriptor()
+}
+
+func (MergePolicy) Type() protoreflect.EnumType {
+	return &file_employee_proto_enumTypes[2]
+}
+
+func (x MergePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergePolicy.Descriptor instead.
+func (MergePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{2}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -102,32 +278,29 @@ func (x *EmployeeID) GetId() string {
 	return ""
 }
 
-type Employee struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	Position      string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
-	Department    string                 `protobuf:"bytes,6,opt,name=department,proto3" json:"department,omitempty"`
+type GetEmployeeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Return the record as it was (or is scheduled to be) at this time
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Employee) Reset() {
-	*x = Employee{}
+func (x *GetEmployeeRequest) Reset() {
+	*x = GetEmployeeRequest{}
 	mi := &file_employee_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Employee) String() string {
+func (x *GetEmployeeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Employee) ProtoMessage() {}
+func (*GetEmployeeRequest) ProtoMessage() {}
 
-func (x *Employee) ProtoReflect() protoreflect.Message {
+func (x *GetEmployeeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_employee_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -139,75 +312,190 @@ func (x *Employee) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Employee.ProtoReflect.Descriptor instead.
-func (*Employee) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*GetEmployeeRequest) Descriptor() ([]byte, []int) {
 	return file_employee_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Employee) GetId() string {
+func (x *GetEmployeeRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Employee) GetFirstName() string {
+func (x *GetEmployeeRequest) GetAsOf() *timestamppb.Timestamp {
 	if x != nil {
-		return x.FirstName
+		return x.AsOf
 	}
-	return ""
+	return nil
 }
 
-func (x *Employee) GetLastName() string {
+type ListEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Terminated employees are left out unless this is set
+	IncludeTerminated bool `protobuf:"varint,1,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	// List the organization as it was (or is scheduled to be) at this time
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Conditions that must all hold, as field:op:value, e.g.
+	// custom.shirt_size:eq:L or hire_date:gte:2024-01-01. The fields and
+	// operators are those of analytics queries; in and not_in take
+	// comma-separated values, exists and missing none.
+	Filter []string `protobuf:"bytes,3,rep,name=filter,proto3" json:"filter,omitempty"`
+	// Comma-separated fields to sort by, each optionally followed by desc,
+	// e.g. "custom.badge_number desc, last_name"
+	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeesRequest) Reset() {
+	*x = ListEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeesRequest) ProtoMessage() {}
+
+func (x *ListEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[3]
 	if x != nil {
-		return x.LastName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Employee) GetEmail() string {
+// Deprecated: Use ListEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ListEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEmployeesRequest) GetIncludeTerminated() bool {
 	if x != nil {
-		return x.Email
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+func (x *ListEmployeesRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ListEmployeesRequest) GetFilter() []string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListEmployeesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
 	}
 	return ""
 }
 
-func (x *Employee) GetPosition() string {
+type EmploymentChangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When the change takes effect, defaults to now
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmploymentChangeRequest) Reset() {
+	*x = EmploymentChangeRequest{}
+	mi := &file_employee_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmploymentChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmploymentChangeRequest) ProtoMessage() {}
+
+func (x *EmploymentChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[4]
 	if x != nil {
-		return x.Position
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmploymentChangeRequest.ProtoReflect.Descriptor instead.
+func (*EmploymentChangeRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EmploymentChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Employee) GetDepartment() string {
+func (x *EmploymentChangeRequest) GetEffectiveDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Department
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *EmploymentChangeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-type EmployeeList struct {
+// One status transition, recorded with who made it and why
+type EmploymentEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Employees     []*Employee            `protobuf:"bytes,1,rep,name=employees,proto3" json:"employees,omitempty"`
+	From          EmploymentStatus       `protobuf:"varint,1,opt,name=from,proto3,enum=employee.EmploymentStatus" json:"from,omitempty"`
+	To            EmploymentStatus       `protobuf:"varint,2,opt,name=to,proto3,enum=employee.EmploymentStatus" json:"to,omitempty"`
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmployeeList) Reset() {
-	*x = EmployeeList{}
-	mi := &file_employee_proto_msgTypes[3]
+func (x *EmploymentEvent) Reset() {
+	*x = EmploymentEvent{}
+	mi := &file_employee_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmployeeList) String() string {
+func (x *EmploymentEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmployeeList) ProtoMessage() {}
+func (*EmploymentEvent) ProtoMessage() {}
 
-func (x *EmployeeList) ProtoReflect() protoreflect.Message {
-	mi := &file_employee_proto_msgTypes[3]
+func (x *EmploymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,98 +506,2576 @@ func (x *EmployeeList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmployeeList.ProtoReflect.Descriptor instead.
-func (*EmployeeList) Descriptor() ([]byte, []int) {
-	return file_employee_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use EmploymentEvent.ProtoReflect.Descriptor instead.
+func (*EmploymentEvent) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *EmployeeList) GetEmployees() []*Employee {
+func (x *EmploymentEvent) GetFrom() EmploymentStatus {
 	if x != nil {
-		return x.Employees
+		return x.From
+	}
+	return EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *EmploymentEvent) GetTo() EmploymentStatus {
+	if x != nil {
+		return x.To
+	}
+	return EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *EmploymentEvent) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
 	}
 	return nil
 }
 
-var File_employee_proto protoreflect.FileDescriptor
+func (x *EmploymentEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
 
-const file_employee_proto_rawDesc = "" +
-	"\n" +
-	"\x0eemployee.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\"\a\n" +
-	"\x05Empty\"\x1c\n" +
-	"\n" +
-	"EmployeeID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa8\x01\n" +
-	"\bEmployee\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
-	"\n" +
-	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
-	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1a\n" +
-	"\bposition\x18\x05 \x01(\tR\bposition\x12\x1e\n" +
-	"\n" +
-	"department\x18\x06 \x01(\tR\n" +
-	"department\"@\n" +
-	"\fEmployeeList\x120\n" +
-	"\temployees\x18\x01 \x03(\v2\x12.employee.EmployeeR\temployees2\xe3\x02\n" +
-	"\x0fEmployeeService\x12N\n" +
-	"\fGetEmployees\x12\x0f.employee.Empty\x1a\x16.employee.EmployeeList\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/employees\x12R\n" +
-	"\x0eCreateEmployee\x12\x12.employee.Employee\x1a\x12.employee.Employee\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/employees\x12W\n" +
-	"\x0eUpdateEmployee\x12\x12.employee.Employee\x1a\x12.employee.Employee\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/employees/{id}\x12S\n" +
-	"\x0eDeleteEmployee\x12\x14.employee.EmployeeID\x1a\x0f.employee.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/employees/{id}B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+func (x *EmploymentEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
 
-var (
-	file_employee_proto_rawDescOnce sync.Once
-	file_employee_proto_rawDescData []byte
-)
+func (x *EmploymentEvent) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
 
-func file_employee_proto_rawDescGZIP() []byte {
-	file_employee_proto_rawDescOnce.Do(func() {
-		file_employee_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_employee_proto_rawDesc), len(file_employee_proto_rawDesc)))
-	})
-	return file_employee_proto_rawDescData
+type Employee struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email     string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	// Job title, filled in from the referenced position
+	Position string `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
+	// Department name, filled in from the referenced department
+	Department   string `protobuf:"bytes,6,opt,name=department,proto3" json:"department,omitempty"`
+	ManagerId    string `protobuf:"bytes,7,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"`
+	DepartmentId string `protobuf:"bytes,8,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	PositionId   string `protobuf:"bytes,9,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	// Set on create (CANDIDATE, PRE_BOARDING or ACTIVE), then read-only
+	Status          EmploymentStatus       `protobuf:"varint,10,opt,name=status,proto3,enum=employee.EmploymentStatus" json:"status,omitempty"`
+	HireDate        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=hire_date,json=hireDate,proto3" json:"hire_date,omitempty"`
+	TerminationDate *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=termination_date,json=terminationDate,proto3" json:"termination_date,omitempty"`
+	// Read-only
+	StatusHistory []*EmploymentEvent `protobuf:"bytes,13,rep,name=status_history,json=statusHistory,proto3" json:"status_history,omitempty"`
+	// On create/update, when the change takes effect (defaults to now). Future
+	// changes become current automatically. On as-of reads, when the returned
+	// version took effect.
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	// Values of the fields defined with the CustomFieldService, by name.
+	// Replaced as a whole on update.
+	CustomFields *structpb.Struct `protobuf:"bytes,15,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	// ID of the employee in another system, such as the identity provider
+	// that provisions it through SCIM
+	ExternalId    string `protobuf:"bytes,16,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var file_employee_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_employee_proto_goTypes = []any{
-	(*Empty)(nil),        // 0: employee.Empty
-	(*EmployeeID)(nil),   // 1: employee.EmployeeID
-	(*Employee)(nil),     // 2: employee.Employee
-	(*EmployeeList)(nil), // 3: employee.EmployeeList
+func (x *Employee) Reset() {
+	*x = Employee{}
+	mi := &file_employee_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-var file_employee_proto_depIdxs = []int32{
-	2, // 0: employee.EmployeeList.employees:type_name -> employee.Employee
-	0, // 1: employee.EmployeeService.GetEmployees:input_type -> employee.Empty
-	2, // 2: employee.EmployeeService.CreateEmployee:input_type -> employee.Employee
-	2, // 3: employee.EmployeeService.UpdateEmployee:input_type -> employee.Employee
-	1, // 4: employee.EmployeeService.DeleteEmployee:input_type -> employee.EmployeeID
-	3, // 5: employee.EmployeeService.GetEmployees:output_type -> employee.EmployeeList
-	2, // 6: employee.EmployeeService.CreateEmployee:output_type -> employee.Employee
-	2, // 7: employee.EmployeeService.UpdateEmployee:output_type -> employee.Employee
-	0, // 8: employee.EmployeeService.DeleteEmployee:output_type -> employee.Empty
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *Employee) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_employee_proto_init() }
-func file_employee_proto_init() {
-	if File_employee_proto != nil {
-		return
+func (*Employee) ProtoMessage() {}
+
+func (x *Employee) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Employee.ProtoReflect.Descriptor instead.
+func (*Employee) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Employee) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Employee) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Employee) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Employee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Employee) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *Employee) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Employee) GetManagerId() string {
+	if x != nil {
+		return x.ManagerId
+	}
+	return ""
+}
+
+func (x *Employee) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *Employee) GetPositionId() string {
+	if x != nil {
+		return x.PositionId
+	}
+	return ""
+}
+
+func (x *Employee) GetStatus() EmploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Employee) GetHireDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HireDate
+	}
+	return nil
+}
+
+func (x *Employee) GetTerminationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminationDate
+	}
+	return nil
+}
+
+func (x *Employee) GetStatusHistory() []*EmploymentEvent {
+	if x != nil {
+		return x.StatusHistory
+	}
+	return nil
+}
+
+func (x *Employee) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *Employee) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Employee) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type EmployeeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Employees     []*Employee            `protobuf:"bytes,1,rep,name=employees,proto3" json:"employees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeList) Reset() {
+	*x = EmployeeList{}
+	mi := &file_employee_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeList) ProtoMessage() {}
+
+func (x *EmployeeList) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeList.ProtoReflect.Descriptor instead.
+func (*EmployeeList) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EmployeeList) GetEmployees() []*Employee {
+	if x != nil {
+		return x.Employees
+	}
+	return nil
+}
+
+type OrgChartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start the chart at this employee instead of at every top-level manager
+	RootId string `protobuf:"bytes,1,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	// Only include employees from this department (ID, code or name)
+	Department string `protobuf:"bytes,2,opt,name=department,proto3" json:"department,omitempty"`
+	// Levels to include below the roots, 0 for no limit
+	MaxDepth int32 `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// json (default), dot or svg
+	Format        string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgChartRequest) Reset() {
+	*x = OrgChartRequest{}
+	mi := &file_employee_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgChartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgChartRequest) ProtoMessage() {}
+
+func (x *OrgChartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgChartRequest.ProtoReflect.Descriptor instead.
+func (*OrgChartRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OrgChartRequest) GetRootId() string {
+	if x != nil {
+		return x.RootId
+	}
+	return ""
+}
+
+func (x *OrgChartRequest) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *OrgChartRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *OrgChartRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type OrgChartNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Employee      *Employee              `protobuf:"bytes,1,opt,name=employee,proto3" json:"employee,omitempty"`
+	Reports       []*OrgChartNode        `protobuf:"bytes,2,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgChartNode) Reset() {
+	*x = OrgChartNode{}
+	mi := &file_employee_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgChartNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgChartNode) ProtoMessage() {}
+
+func (x *OrgChartNode) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgChartNode.ProtoReflect.Descriptor instead.
+func (*OrgChartNode) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrgChartNode) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *OrgChartNode) GetReports() []*OrgChartNode {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type OrgChart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roots         []*OrgChartNode        `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgChart) Reset() {
+	*x = OrgChart{}
+	mi := &file_employee_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgChart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgChart) ProtoMessage() {}
+
+func (x *OrgChart) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgChart.ProtoReflect.Descriptor instead.
+func (*OrgChart) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrgChart) GetRoots() []*OrgChartNode {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+type ListEmployeeRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId    string                 `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeeRevisionsRequest) Reset() {
+	*x = ListEmployeeRevisionsRequest{}
+	mi := &file_employee_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeeRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeeRevisionsRequest) ProtoMessage() {}
+
+func (x *ListEmployeeRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmployeeRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListEmployeeRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListEmployeeRevisionsRequest) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+type EmployeeRevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId    string                 `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeRevisionRequest) Reset() {
+	*x = EmployeeRevisionRequest{}
+	mi := &file_employee_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeRevisionRequest) ProtoMessage() {}
+
+func (x *EmployeeRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeRevisionRequest.ProtoReflect.Descriptor instead.
+func (*EmployeeRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EmployeeRevisionRequest) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *EmployeeRevisionRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Unset when the field did not exist before / after the change
+	Before        *structpb.Value `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Value `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_employee_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type EmployeeRevision struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EmployeeId string                 `protobuf:"bytes,2,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	// Numbered from 1 per employee
+	Revision int64 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// create, update, status_change, delete, restore or merge
+	Action    string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Actor     string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// When the change takes effect, for effective-dated changes
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The employee after the change, or before it for deletions
+	Snapshot *Employee `protobuf:"bytes,9,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Set on the revisions written by a merge
+	Merge         *EmployeeMerge `protobuf:"bytes,10,opt,name=merge,proto3" json:"merge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeRevision) Reset() {
+	*x = EmployeeRevision{}
+	mi := &file_employee_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeRevision) ProtoMessage() {}
+
+func (x *EmployeeRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeRevision.ProtoReflect.Descriptor instead.
+func (*EmployeeRevision) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EmployeeRevision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmployeeRevision) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *EmployeeRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *EmployeeRevision) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *EmployeeRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EmployeeRevision) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *EmployeeRevision) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *EmployeeRevision) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *EmployeeRevision) GetSnapshot() *Employee {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *EmployeeRevision) GetMerge() *EmployeeMerge {
+	if x != nil {
+		return x.Merge
+	}
+	return nil
+}
+
+type EmployeeMerge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SurvivorId    string                 `protobuf:"bytes,1,opt,name=survivor_id,json=survivorId,proto3" json:"survivor_id,omitempty"`
+	DuplicateId   string                 `protobuf:"bytes,2,opt,name=duplicate_id,json=duplicateId,proto3" json:"duplicate_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeMerge) Reset() {
+	*x = EmployeeMerge{}
+	mi := &file_employee_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeMerge) ProtoMessage() {}
+
+func (x *EmployeeMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeMerge.ProtoReflect.Descriptor instead.
+func (*EmployeeMerge) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EmployeeMerge) GetSurvivorId() string {
+	if x != nil {
+		return x.SurvivorId
+	}
+	return ""
+}
+
+func (x *EmployeeMerge) GetDuplicateId() string {
+	if x != nil {
+		return x.DuplicateId
+	}
+	return ""
+}
+
+type EmployeeRevisionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*EmployeeRevision    `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeRevisionList) Reset() {
+	*x = EmployeeRevisionList{}
+	mi := &file_employee_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeRevisionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeRevisionList) ProtoMessage() {}
+
+func (x *EmployeeRevisionList) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeRevisionList.ProtoReflect.Descriptor instead.
+func (*EmployeeRevisionList) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EmployeeRevisionList) GetRevisions() []*EmployeeRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type WatchEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pick up after the event carrying this token instead of from now. SSE
+	// clients reconnect with it in the Last-Event-ID header.
+	ResumeToken   string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEmployeesRequest) Reset() {
+	*x = WatchEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEmployeesRequest) ProtoMessage() {}
+
+func (x *WatchEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*WatchEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchEmployeesRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type EmployeeEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=employee.ChangeType" json:"type,omitempty"`
+	EmployeeId string                 `protobuf:"bytes,2,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	// The employee after the change, unset for deletions
+	Employee *Employee `protobuf:"bytes,3,opt,name=employee,proto3" json:"employee,omitempty"`
+	// Pass back as resume_token to continue after this event
+	ResumeToken   string                 `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeEvent) Reset() {
+	*x = EmployeeEvent{}
+	mi := &file_employee_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeEvent) ProtoMessage() {}
+
+func (x *EmployeeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeEvent.ProtoReflect.Descriptor instead.
+func (*EmployeeEvent) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EmployeeEvent) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *EmployeeEvent) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *EmployeeEvent) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *EmployeeEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *EmployeeEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ImportEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Part:
+	//
+	//	*ImportEmployeesRequest_Options
+	//	*ImportEmployeesRequest_Chunk
+	Part          isImportEmployeesRequest_Part `protobuf_oneof:"part"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEmployeesRequest) Reset() {
+	*x = ImportEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEmployeesRequest) ProtoMessage() {}
+
+func (x *ImportEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ImportEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportEmployeesRequest) GetPart() isImportEmployeesRequest_Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *ImportEmployeesRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Part.(*ImportEmployeesRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportEmployeesRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Part.(*ImportEmployeesRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportEmployeesRequest_Part interface {
+	isImportEmployeesRequest_Part()
+}
+
+type ImportEmployeesRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportEmployeesRequest_Chunk struct {
+	// The next piece of the CSV file
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportEmployeesRequest_Options) isImportEmployeesRequest_Part() {}
+
+func (*ImportEmployeesRequest_Chunk) isImportEmployeesRequest_Part() {}
+
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Header to employee field, e.g. "Given Name" -> first_name. Headers
+	// that already name a field need no mapping; map a header to "" to skip
+	// it. Fields: first_name, last_name, email, position, position_id,
+	// department, department_id, manager_id, manager_email, status,
+	// effective_date.
+	HeaderMapping map[string]string `protobuf:"bytes,1,rep,name=header_mapping,json=headerMapping,proto3" json:"header_mapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Validate and report without saving anything
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Update the employee with the same email instead of failing the row.
+	// Empty cells keep the current value.
+	UpsertByEmail bool `protobuf:"varint,3,opt,name=upsert_by_email,json=upsertByEmail,proto3" json:"upsert_by_email,omitempty"`
+	// csv or xlsx, detected from the file when empty
+	Format string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	// XLSX only: the sheet to import, defaults to the first one
+	Sheet         string `protobuf:"bytes,5,opt,name=sheet,proto3" json:"sheet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_employee_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportOptions) GetHeaderMapping() map[string]string {
+	if x != nil {
+		return x.HeaderMapping
+	}
+	return nil
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetUpsertByEmail() bool {
+	if x != nil {
+		return x.UpsertByEmail
+	}
+	return false
+}
+
+func (x *ImportOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportOptions) GetSheet() string {
+	if x != nil {
+		return x.Sheet
+	}
+	return ""
+}
+
+type ImportRowResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Line of the CSV file, or row of the sheet, the row starts on
+	Line  int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// create, update or error
+	Action        string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	EmployeeId    string   `protobuf:"bytes,4,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	Errors        []string `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_employee_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowResult) ProtoMessage() {}
+
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportRowResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ImportReport struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DryRun    bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	TotalRows int32                  `protobuf:"varint,2,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	Created   int32                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Updated   int32                  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed    int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Headers that matched no field
+	IgnoredColumns []string           `protobuf:"bytes,6,rep,name=ignored_columns,json=ignoredColumns,proto3" json:"ignored_columns,omitempty"`
+	Rows           []*ImportRowResult `protobuf:"bytes,7,rep,name=rows,proto3" json:"rows,omitempty"`
+	// XLSX imports: a copy of the uploaded workbook with the errors of each
+	// row in an "Import errors" column
+	AnnotatedWorkbook []byte `protobuf:"bytes,8,opt,name=annotated_workbook,json=annotatedWorkbook,proto3" json:"annotated_workbook,omitempty"`
+	// Dry runs: what the check could not cover
+	Note          string `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportReport) Reset() {
+	*x = ImportReport{}
+	mi := &file_employee_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReport) ProtoMessage() {}
+
+func (x *ImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReport.ProtoReflect.Descriptor instead.
+func (*ImportReport) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportReport) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportReport) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *ImportReport) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportReport) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportReport) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportReport) GetIgnoredColumns() []string {
+	if x != nil {
+		return x.IgnoredColumns
+	}
+	return nil
+}
+
+func (x *ImportReport) GetRows() []*ImportRowResult {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *ImportReport) GetAnnotatedWorkbook() []byte {
+	if x != nil {
+		return x.AnnotatedWorkbook
+	}
+	return nil
+}
+
+func (x *ImportReport) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ExportEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// csv (default), jsonl, parquet or xlsx. Parquet columns are nullable;
+	// empty values are exported as null.
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Columns to export, in order. Defaults to all of them. Each entry may
+	// also be a comma-separated list.
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Terminated employees are left out unless this is set or they are asked
+	// for in statuses
+	IncludeTerminated bool `protobuf:"varint,3,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	// Export the organization as it was (or is scheduled to be) at this time
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Only employees in this department (ID, code or name)
+	Department string `protobuf:"bytes,5,opt,name=department,proto3" json:"department,omitempty"`
+	// Only employees with one of these statuses
+	Statuses []EmploymentStatus `protobuf:"varint,6,rep,packed,name=statuses,proto3,enum=employee.EmploymentStatus" json:"statuses,omitempty"`
+	// Only the direct reports of this manager
+	ManagerId string `protobuf:"bytes,7,opt,name=manager_id,json=managerId,proto3" json:"manager_id,omitempty"`
+	// XLSX only: one sheet per department instead of a single sheet
+	SheetPerDepartment bool `protobuf:"varint,8,opt,name=sheet_per_department,json=sheetPerDepartment,proto3" json:"sheet_per_department,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportEmployeesRequest) Reset() {
+	*x = ExportEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportEmployeesRequest) ProtoMessage() {}
+
+func (x *ExportEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ExportEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportEmployeesRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportEmployeesRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ExportEmployeesRequest) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+func (x *ExportEmployeesRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ExportEmployeesRequest) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *ExportEmployeesRequest) GetStatuses() []EmploymentStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ExportEmployeesRequest) GetManagerId() string {
+	if x != nil {
+		return x.ManagerId
+	}
+	return ""
+}
+
+func (x *ExportEmployeesRequest) GetSheetPerDepartment() bool {
+	if x != nil {
+		return x.SheetPerDepartment
+	}
+	return false
+}
+
+type SearchEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words to look for; an employee must match all of them
+	Q string `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	// Defaults to 20, at most 100
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Fields to search, all by default: first_name, last_name, email,
+	// position and department
+	Fields            []string `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	IncludeTerminated bool     `protobuf:"varint,5,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SearchEmployeesRequest) Reset() {
+	*x = SearchEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchEmployeesRequest) ProtoMessage() {}
+
+func (x *SearchEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*SearchEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchEmployeesRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchEmployeesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchEmployeesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchEmployeesRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SearchEmployeesRequest) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+type SearchHit struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Employee *Employee              `protobuf:"bytes,1,opt,name=employee,proto3" json:"employee,omitempty"`
+	Score    float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// The matching fields, HTML-escaped, with the matching words wrapped in
+	// <em> tags
+	Highlights    map[string]string `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_employee_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchHit) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetHighlights() map[string]string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type SearchEmployeesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hits  []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	// Matches across all pages
+	TotalSize     int32  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchEmployeesResponse) Reset() {
+	*x = SearchEmployeesResponse{}
+	mi := &file_employee_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchEmployeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchEmployeesResponse) ProtoMessage() {}
+
+func (x *SearchEmployeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchEmployeesResponse.ProtoReflect.Descriptor instead.
+func (*SearchEmployeesResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchEmployeesResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchEmployeesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *SearchEmployeesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SuggestEmployeesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Defaults to 10, at most 50
+	Limit             int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeTerminated bool  `protobuf:"varint,3,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SuggestEmployeesRequest) Reset() {
+	*x = SuggestEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestEmployeesRequest) ProtoMessage() {}
+
+func (x *SuggestEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*SuggestEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SuggestEmployeesRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestEmployeesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestEmployeesRequest) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+type EmployeeSuggestion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// First and last name
+	DisplayName   string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Department    string `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeSuggestion) Reset() {
+	*x = EmployeeSuggestion{}
+	mi := &file_employee_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeSuggestion) ProtoMessage() {}
+
+func (x *EmployeeSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeSuggestion.ProtoReflect.Descriptor instead.
+func (*EmployeeSuggestion) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EmployeeSuggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmployeeSuggestion) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *EmployeeSuggestion) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *EmployeeSuggestion) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+type SuggestEmployeesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*EmployeeSuggestion  `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestEmployeesResponse) Reset() {
+	*x = SuggestEmployeesResponse{}
+	mi := &file_employee_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestEmployeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestEmployeesResponse) ProtoMessage() {}
+
+func (x *SuggestEmployeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestEmployeesResponse.ProtoReflect.Descriptor instead.
+func (*SuggestEmployeesResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SuggestEmployeesResponse) GetSuggestions() []*EmployeeSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type FindDuplicateEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only pairs with this employee
+	EmployeeId string `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	// From 0 to 1, defaults to 0.6
+	MinScore float64 `protobuf:"fixed64,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// Defaults to 50, at most 500
+	Limit             int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeTerminated bool  `protobuf:"varint,4,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FindDuplicateEmployeesRequest) Reset() {
+	*x = FindDuplicateEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicateEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateEmployeesRequest) ProtoMessage() {}
+
+func (x *FindDuplicateEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicateEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FindDuplicateEmployeesRequest) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *FindDuplicateEmployeesRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *FindDuplicateEmployeesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindDuplicateEmployeesRequest) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+type DuplicateCandidate struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	First  *Employee              `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second *Employee              `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	// From 0 to 1
+	Score float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	// What the two have in common, e.g. "same email after normalization"
+	Reasons       []string `protobuf:"bytes,4,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateCandidate) Reset() {
+	*x = DuplicateCandidate{}
+	mi := &file_employee_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCandidate) ProtoMessage() {}
+
+func (x *DuplicateCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCandidate.ProtoReflect.Descriptor instead.
+func (*DuplicateCandidate) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DuplicateCandidate) GetFirst() *Employee {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *DuplicateCandidate) GetSecond() *Employee {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
+func (x *DuplicateCandidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DuplicateCandidate) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type DuplicateCandidateList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidates    []*DuplicateCandidate  `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateCandidateList) Reset() {
+	*x = DuplicateCandidateList{}
+	mi := &file_employee_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCandidateList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCandidateList) ProtoMessage() {}
+
+func (x *DuplicateCandidateList) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCandidateList.ProtoReflect.Descriptor instead.
+func (*DuplicateCandidateList) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DuplicateCandidateList) GetCandidates() []*DuplicateCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type MergeEmployeesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The employee that is kept
+	SurvivorId string `protobuf:"bytes,1,opt,name=survivor_id,json=survivorId,proto3" json:"survivor_id,omitempty"`
+	// The employee that is folded into the survivor and deleted
+	DuplicateId   string      `protobuf:"bytes,2,opt,name=duplicate_id,json=duplicateId,proto3" json:"duplicate_id,omitempty"`
+	DefaultPolicy MergePolicy `protobuf:"varint,3,opt,name=default_policy,json=defaultPolicy,proto3,enum=employee.MergePolicy" json:"default_policy,omitempty"`
+	// Policies of single fields, overriding default_policy: first_name,
+	// last_name, email, department, position and manager_id
+	FieldPolicies map[string]MergePolicy `protobuf:"bytes,4,rep,name=field_policies,json=fieldPolicies,proto3" json:"field_policies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=employee.MergePolicy"`
+	// Check and return the result without saving it
+	ValidateOnly  bool `protobuf:"varint,5,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeEmployeesRequest) Reset() {
+	*x = MergeEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeEmployeesRequest) ProtoMessage() {}
+
+func (x *MergeEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*MergeEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MergeEmployeesRequest) GetSurvivorId() string {
+	if x != nil {
+		return x.SurvivorId
+	}
+	return ""
+}
+
+func (x *MergeEmployeesRequest) GetDuplicateId() string {
+	if x != nil {
+		return x.DuplicateId
+	}
+	return ""
+}
+
+func (x *MergeEmployeesRequest) GetDefaultPolicy() MergePolicy {
+	if x != nil {
+		return x.DefaultPolicy
+	}
+	return MergePolicy_MERGE_POLICY_UNSPECIFIED
+}
+
+func (x *MergeEmployeesRequest) GetFieldPolicies() map[string]MergePolicy {
+	if x != nil {
+		return x.FieldPolicies
+	}
+	return nil
+}
+
+func (x *MergeEmployeesRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type MergeEmployeesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The survivor after the merge
+	Employee *Employee `protobuf:"bytes,1,opt,name=employee,proto3" json:"employee,omitempty"`
+	// Fields that took the duplicate's value
+	FieldsFromDuplicate []string `protobuf:"bytes,2,rep,name=fields_from_duplicate,json=fieldsFromDuplicate,proto3" json:"fields_from_duplicate,omitempty"`
+	// Direct reports of the duplicate that now report to the survivor
+	MovedReportIds []string `protobuf:"bytes,3,rep,name=moved_report_ids,json=movedReportIds,proto3" json:"moved_report_ids,omitempty"`
+	// Departments headed by the duplicate that the survivor now heads
+	DepartmentsUpdated int32 `protobuf:"varint,4,opt,name=departments_updated,json=departmentsUpdated,proto3" json:"departments_updated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MergeEmployeesResponse) Reset() {
+	*x = MergeEmployeesResponse{}
+	mi := &file_employee_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeEmployeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeEmployeesResponse) ProtoMessage() {}
+
+func (x *MergeEmployeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeEmployeesResponse.ProtoReflect.Descriptor instead.
+func (*MergeEmployeesResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MergeEmployeesResponse) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *MergeEmployeesResponse) GetFieldsFromDuplicate() []string {
+	if x != nil {
+		return x.FieldsFromDuplicate
+	}
+	return nil
+}
+
+func (x *MergeEmployeesResponse) GetMovedReportIds() []string {
+	if x != nil {
+		return x.MovedReportIds
+	}
+	return nil
+}
+
+func (x *MergeEmployeesResponse) GetDepartmentsUpdated() int32 {
+	if x != nil {
+		return x.DepartmentsUpdated
+	}
+	return 0
+}
+
+var File_employee_proto protoreflect.FileDescriptor
+
+const file_employee_proto_rawDesc = "" +
+	"\n" +
+	"\x0eemployee.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
+	"\x05Empty\"\x1c\n" +
+	"\n" +
+	"EmployeeID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"U\n" +
+	"\x12GetEmployeeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xa9\x01\n" +
+	"\x14ListEmployeesRequest\x12-\n" +
+	"\x12include_terminated\x18\x01 \x01(\bR\x11includeTerminated\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x16\n" +
+	"\x06filter\x18\x03 \x03(\tR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\"\x84\x01\n" +
+	"\x17EmploymentChangeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12A\n" +
+	"\x0eeffective_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x9b\x02\n" +
+	"\x0fEmploymentEvent\x12.\n" +
+	"\x04from\x18\x01 \x01(\x0e2\x1a.employee.EmploymentStatusR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\x0e2\x1a.employee.EmploymentStatusR\x02to\x12A\n" +
+	"\x0eeffective_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12;\n" +
+	"\vrecorded_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\"\xa5\x05\n" +
+	"\bEmployee\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\tR\bposition\x12\x1e\n" +
+	"\n" +
+	"department\x18\x06 \x01(\tR\n" +
+	"department\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\a \x01(\tR\tmanagerId\x12#\n" +
+	"\rdepartment_id\x18\b \x01(\tR\fdepartmentId\x12\x1f\n" +
+	"\vposition_id\x18\t \x01(\tR\n" +
+	"positionId\x122\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1a.employee.EmploymentStatusR\x06status\x127\n" +
+	"\thire_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bhireDate\x12E\n" +
+	"\x10termination_date\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0fterminationDate\x12@\n" +
+	"\x0estatus_history\x18\r \x03(\v2\x19.employee.EmploymentEventR\rstatusHistory\x12A\n" +
+	"\x0eeffective_date\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12<\n" +
+	"\rcustom_fields\x18\x0f \x01(\v2\x17.google.protobuf.StructR\fcustomFields\x12\x1f\n" +
+	"\vexternal_id\x18\x10 \x01(\tR\n" +
+	"externalId\"@\n" +
+	"\fEmployeeList\x120\n" +
+	"\temployees\x18\x01 \x03(\v2\x12.employee.EmployeeR\temployees\"\x7f\n" +
+	"\x0fOrgChartRequest\x12\x17\n" +
+	"\aroot_id\x18\x01 \x01(\tR\x06rootId\x12\x1e\n" +
+	"\n" +
+	"department\x18\x02 \x01(\tR\n" +
+	"department\x12\x1b\n" +
+	"\tmax_depth\x18\x03 \x01(\x05R\bmaxDepth\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"p\n" +
+	"\fOrgChartNode\x12.\n" +
+	"\bemployee\x18\x01 \x01(\v2\x12.employee.EmployeeR\bemployee\x120\n" +
+	"\areports\x18\x02 \x03(\v2\x16.employee.OrgChartNodeR\areports\"8\n" +
+	"\bOrgChart\x12,\n" +
+	"\x05roots\x18\x01 \x03(\v2\x16.employee.OrgChartNodeR\x05roots\"?\n" +
+	"\x1cListEmployeeRevisionsRequest\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\"V\n" +
+	"\x17EmployeeRevisionRequest\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\"\x81\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\x9a\x03\n" +
+	"\x10EmployeeRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vemployee_id\x18\x02 \x01(\tR\n" +
+	"employeeId\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x03R\brevision\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12A\n" +
+	"\x0eeffective_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12/\n" +
+	"\achanges\x18\b \x03(\v2\x15.employee.FieldChangeR\achanges\x12.\n" +
+	"\bsnapshot\x18\t \x01(\v2\x12.employee.EmployeeR\bsnapshot\x12-\n" +
+	"\x05merge\x18\n" +
+	" \x01(\v2\x17.employee.EmployeeMergeR\x05merge\"S\n" +
+	"\rEmployeeMerge\x12\x1f\n" +
+	"\vsurvivor_id\x18\x01 \x01(\tR\n" +
+	"survivorId\x12!\n" +
+	"\fduplicate_id\x18\x02 \x01(\tR\vduplicateId\"P\n" +
+	"\x14EmployeeRevisionList\x128\n" +
+	"\trevisions\x18\x01 \x03(\v2\x1a.employee.EmployeeRevisionR\trevisions\":\n" +
+	"\x15WatchEmployeesRequest\x12!\n" +
+	"\fresume_token\x18\x01 \x01(\tR\vresumeToken\"\xe7\x01\n" +
+	"\rEmployeeEvent\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.employee.ChangeTypeR\x04type\x12\x1f\n" +
+	"\vemployee_id\x18\x02 \x01(\tR\n" +
+	"employeeId\x12.\n" +
+	"\bemployee\x18\x03 \x01(\v2\x12.employee.EmployeeR\bemployee\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"m\n" +
+	"\x16ImportEmployeesRequest\x123\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.employee.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04part\"\x93\x02\n" +
+	"\rImportOptions\x12Q\n" +
+	"\x0eheader_mapping\x18\x01 \x03(\v2*.employee.ImportOptions.HeaderMappingEntryR\rheaderMapping\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12&\n" +
+	"\x0fupsert_by_email\x18\x03 \x01(\bR\rupsertByEmail\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x12\x14\n" +
+	"\x05sheet\x18\x05 \x01(\tR\x05sheet\x1a@\n" +
+	"\x12HeaderMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8c\x01\n" +
+	"\x0fImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1f\n" +
+	"\vemployee_id\x18\x04 \x01(\tR\n" +
+	"employeeId\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"\xad\x02\n" +
+	"\fImportReport\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x02 \x01(\x05R\ttotalRows\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12'\n" +
+	"\x0fignored_columns\x18\x06 \x03(\tR\x0eignoredColumns\x12-\n" +
+	"\x04rows\x18\a \x03(\v2\x19.employee.ImportRowResultR\x04rows\x12-\n" +
+	"\x12annotated_workbook\x18\b \x01(\fR\x11annotatedWorkbook\x12\x12\n" +
+	"\x04note\x18\t \x01(\tR\x04note\"\xd1\x02\n" +
+	"\x16ExportEmployeesRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\x12-\n" +
+	"\x12include_terminated\x18\x03 \x01(\bR\x11includeTerminated\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x1e\n" +
+	"\n" +
+	"department\x18\x05 \x01(\tR\n" +
+	"department\x126\n" +
+	"\bstatuses\x18\x06 \x03(\x0e2\x1a.employee.EmploymentStatusR\bstatuses\x12\x1d\n" +
+	"\n" +
+	"manager_id\x18\a \x01(\tR\tmanagerId\x120\n" +
+	"\x14sheet_per_department\x18\b \x01(\bR\x12sheetPerDepartment\"\xa9\x01\n" +
+	"\x16SearchEmployeesRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12-\n" +
+	"\x12include_terminated\x18\x05 \x01(\bR\x11includeTerminated\"\xd5\x01\n" +
+	"\tSearchHit\x12.\n" +
+	"\bemployee\x18\x01 \x01(\v2\x12.employee.EmployeeR\bemployee\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12C\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2#.employee.SearchHit.HighlightsEntryR\n" +
+	"highlights\x1a=\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
+	"\x17SearchEmployeesResponse\x12'\n" +
+	"\x04hits\x18\x01 \x03(\v2\x13.employee.SearchHitR\x04hits\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x02 \x01(\x05R\ttotalSize\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"v\n" +
+	"\x17SuggestEmployeesRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12-\n" +
+	"\x12include_terminated\x18\x03 \x01(\bR\x11includeTerminated\"}\n" +
+	"\x12EmployeeSuggestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"department\x18\x04 \x01(\tR\n" +
+	"department\"Z\n" +
+	"\x18SuggestEmployeesResponse\x12>\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1c.employee.EmployeeSuggestionR\vsuggestions\"\xa2\x01\n" +
+	"\x1dFindDuplicateEmployeesRequest\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\x12\x1b\n" +
+	"\tmin_score\x18\x02 \x01(\x01R\bminScore\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12-\n" +
+	"\x12include_terminated\x18\x04 \x01(\bR\x11includeTerminated\"\x9a\x01\n" +
+	"\x12DuplicateCandidate\x12(\n" +
+	"\x05first\x18\x01 \x01(\v2\x12.employee.EmployeeR\x05first\x12*\n" +
+	"\x06second\x18\x02 \x01(\v2\x12.employee.EmployeeR\x06second\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x18\n" +
+	"\areasons\x18\x04 \x03(\tR\areasons\"V\n" +
+	"\x16DuplicateCandidateList\x12<\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2\x1c.employee.DuplicateCandidateR\n" +
+	"candidates\"\xf2\x02\n" +
+	"\x15MergeEmployeesRequest\x12\x1f\n" +
+	"\vsurvivor_id\x18\x01 \x01(\tR\n" +
+	"survivorId\x12!\n" +
+	"\fduplicate_id\x18\x02 \x01(\tR\vduplicateId\x12<\n" +
+	"\x0edefault_policy\x18\x03 \x01(\x0e2\x15.employee.MergePolicyR\rdefaultPolicy\x12Y\n" +
+	"\x0efield_policies\x18\x04 \x03(\v22.employee.MergeEmployeesRequest.FieldPoliciesEntryR\rfieldPolicies\x12#\n" +
+	"\rvalidate_only\x18\x05 \x01(\bR\fvalidateOnly\x1aW\n" +
+	"\x12FieldPoliciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x15.employee.MergePolicyR\x05value:\x028\x01\"\xd7\x01\n" +
+	"\x16MergeEmployeesResponse\x12.\n" +
+	"\bemployee\x18\x01 \x01(\v2\x12.employee.EmployeeR\bemployee\x122\n" +
+	"\x15fields_from_duplicate\x18\x02 \x03(\tR\x13fieldsFromDuplicate\x12(\n" +
+	"\x10moved_report_ids\x18\x03 \x03(\tR\x0emovedReportIds\x12/\n" +
+	"\x13departments_updated\x18\x04 \x01(\x05R\x12departmentsUpdated*\x8f\x01\n" +
+	"\x10EmploymentStatus\x12!\n" +
+	"\x1dEMPLOYMENT_STATUS_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tCANDIDATE\x10\x01\x12\x10\n" +
+	"\fPRE_BOARDING\x10\x02\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x03\x12\f\n" +
+	"\bON_LEAVE\x10\x04\x12\r\n" +
+	"\tSUSPENDED\x10\x05\x12\x0e\n" +
+	"\n" +
+	"TERMINATED\x10\x06*P\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\v\n" +
+	"\aUPDATED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03*\x92\x01\n" +
+	"\vMergePolicy\x12\x1c\n" +
+	"\x18MERGE_POLICY_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13MERGE_KEEP_SURVIVOR\x10\x01\x12\x18\n" +
+	"\x14MERGE_KEEP_DUPLICATE\x10\x02\x12\x1a\n" +
+	"\x16MERGE_PREFER_NON_EMPTY\x10\x03\x12\x16\n" +
+	"\x12MERGE_PREFER_NEWER\x10\x042\x9a\x17\n" +
+	"\x0fEmployeeService\x12]\n" +
+	"\fGetEmployees\x12\x1e.employee.ListEmployeesRequest\x1a\x16.employee.EmployeeList\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/employees\x12[\n" +
+	"\vGetEmployee\x12\x1c.employee.GetEmployeeRequest\x1a\x12.employee.Employee\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/employees/{id}\x12R\n" +
+	"\x0eCreateEmployee\x12\x12.employee.Employee\x1a\x12.employee.Employee\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/employees\x12W\n" +
+	"\x0eUpdateEmployee\x12\x12.employee.Employee\x1a\x12.employee.Employee\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/employees/{id}\x12S\n" +
+	"\x0eDeleteEmployee\x12\x14.employee.EmployeeID\x1a\x0f.employee.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/employees/{id}\x12e\n" +
+	"\x11ListDirectReports\x12\x14.employee.EmployeeID\x1a\x16.employee.EmployeeList\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/employees/{id}/reports\x12e\n" +
+	"\x11ListReportingTree\x12\x14.employee.EmployeeID\x1a\x16.employee.EmployeeList\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/employees/{id}/subtree\x12e\n" +
+	"\x13ListManagementChain\x12\x14.employee.EmployeeID\x1a\x16.employee.EmployeeList\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/employees/{id}/chain\x12i\n" +
+	"\fHireEmployee\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/employees/{id}:hire\x12m\n" +
+	"\x0fStartEmployment\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/employees/{id}:start\x12j\n" +
+	"\fPlaceOnLeave\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/employees/{id}:leave\x12n\n" +
+	"\x0fReturnFromLeave\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/employees/{id}:return\x12o\n" +
+	"\x0fSuspendEmployee\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/employees/{id}:suspend\x12s\n" +
+	"\x11ReinstateEmployee\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/employees/{id}:reinstate\x12s\n" +
+	"\x11TerminateEmployee\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/employees/{id}:terminate\x12m\n" +
+	"\x0eRehireEmployee\x12!.employee.EmploymentChangeRequest\x1a\x12.employee.Employee\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/employees/{id}:rehire\x12\x8e\x01\n" +
+	"\x15ListEmployeeRevisions\x12&.employee.ListEmployeeRevisionsRequest\x1a\x1e.employee.EmployeeRevisionList\"-\x82\xd3\xe4\x93\x02'\x12%/v1/employees/{employee_id}/revisions\x12\x8e\x01\n" +
+	"\x13GetEmployeeRevision\x12!.employee.EmployeeRevisionRequest\x1a\x1a.employee.EmployeeRevision\"8\x82\xd3\xe4\x93\x022\x120/v1/employees/{employee_id}/revisions/{revision}\x12\x95\x01\n" +
+	"\x17RestoreEmployeeRevision\x12!.employee.EmployeeRevisionRequest\x1a\x12.employee.Employee\"C\x82\xd3\xe4\x93\x02=:\x01*\"8/v1/employees/{employee_id}/revisions/{revision}:restore\x12W\n" +
+	"\x0eExportOrgChart\x12\x19.employee.OrgChartRequest\x1a\x14.google.api.HttpBody\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/orgchart\x12i\n" +
+	"\x0eWatchEmployees\x12\x1f.employee.WatchEmployeesRequest\x1a\x17.employee.EmployeeEvent\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/employees:watch0\x01\x12M\n" +
+	"\x0fImportEmployees\x12 .employee.ImportEmployeesRequest\x1a\x16.employee.ImportReport(\x01\x12K\n" +
+	"\x0fExportEmployees\x12 .employee.ExportEmployeesRequest\x1a\x14.google.api.HttpBody0\x01\x12t\n" +
+	"\x0fSearchEmployees\x12 .employee.SearchEmployeesRequest\x1a!.employee.SearchEmployeesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/employees:search\x12x\n" +
+	"\x10SuggestEmployees\x12!.employee.SuggestEmployeesRequest\x1a\".employee.SuggestEmployeesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/employees:suggest\x12\x85\x01\n" +
+	"\x16FindDuplicateEmployees\x12'.employee.FindDuplicateEmployeesRequest\x1a .employee.DuplicateCandidateList\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/employees:duplicates\x12\x81\x01\n" +
+	"\x0eMergeEmployees\x12\x1f.employee.MergeEmployeesRequest\x1a .employee.MergeEmployeesResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/employees/{survivor_id}:mergeB\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_employee_proto_rawDescOnce sync.Once
+	file_employee_proto_rawDescData []byte
+)
+
+func file_employee_proto_rawDescGZIP() []byte {
+	file_employee_proto_rawDescOnce.Do(func() {
+		file_employee_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_employee_proto_rawDesc), len(file_employee_proto_rawDesc)))
+	})
+	return file_employee_proto_rawDescData
+}
+
+var file_employee_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_employee_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_employee_proto_goTypes = []any{
+	(EmploymentStatus)(0),                 // 0: employee.EmploymentStatus
+	(ChangeType)(0),                       // 1: employee.ChangeType
+	(MergePolicy)(0),                      // 2: employee.MergePolicy
+	(*Empty)(nil),                         // 3: employee.Empty
+	(*EmployeeID)(nil),                    // 4: employee.EmployeeID
+	(*GetEmployeeRequest)(nil),            // 5: employee.GetEmployeeRequest
+	(*ListEmployeesRequest)(nil),          // 6: employee.ListEmployeesRequest
+	(*EmploymentChangeRequest)(nil),       // 7: employee.EmploymentChangeRequest
+	(*EmploymentEvent)(nil),               // 8: employee.EmploymentEvent
+	(*Employee)(nil),                      // 9: employee.Employee
+	(*EmployeeList)(nil),                  // 10: employee.EmployeeList
+	(*OrgChartRequest)(nil),               // 11: employee.OrgChartRequest
+	(*OrgChartNode)(nil),                  // 12: employee.OrgChartNode
+	(*OrgChart)(nil),                      // 13: employee.OrgChart
+	(*ListEmployeeRevisionsRequest)(nil),  // 14: employee.ListEmployeeRevisionsRequest
+	(*EmployeeRevisionRequest)(nil),       // 15: employee.EmployeeRevisionRequest
+	(*FieldChange)(nil),                   // 16: employee.FieldChange
+	(*EmployeeRevision)(nil),              // 17: employee.EmployeeRevision
+	(*EmployeeMerge)(nil),                 // 18: employee.EmployeeMerge
+	(*EmployeeRevisionList)(nil),          // 19: employee.EmployeeRevisionList
+	(*WatchEmployeesRequest)(nil),         // 20: employee.WatchEmployeesRequest
+	(*EmployeeEvent)(nil),                 // 21: employee.EmployeeEvent
+	(*ImportEmployeesRequest)(nil),        // 22: employee.ImportEmployeesRequest
+	(*ImportOptions)(nil),                 // 23: employee.ImportOptions
+	(*ImportRowResult)(nil),               // 24: employee.ImportRowResult
+	(*ImportReport)(nil),                  // 25: employee.ImportReport
+	(*ExportEmployeesRequest)(nil),        // 26: employee.ExportEmployeesRequest
+	(*SearchEmployeesRequest)(nil),        // 27: employee.SearchEmployeesRequest
+	(*SearchHit)(nil),                     // 28: employee.SearchHit
+	(*SearchEmployeesResponse)(nil),       // 29: employee.SearchEmployeesResponse
+	(*SuggestEmployeesRequest)(nil),       // 30: employee.SuggestEmployeesRequest
+	(*EmployeeSuggestion)(nil),            // 31: employee.EmployeeSuggestion
+	(*SuggestEmployeesResponse)(nil),      // 32: employee.SuggestEmployeesResponse
+	(*FindDuplicateEmployeesRequest)(nil), // 33: employee.FindDuplicateEmployeesRequest
+	(*DuplicateCandidate)(nil),            // 34: employee.DuplicateCandidate
+	(*DuplicateCandidateList)(nil),        // 35: employee.DuplicateCandidateList
+	(*MergeEmployeesRequest)(nil),         // 36: employee.MergeEmployeesRequest
+	(*MergeEmployeesResponse)(nil),        // 37: employee.MergeEmployeesResponse
+	nil,                                   // 38: employee.ImportOptions.HeaderMappingEntry
+	nil,                                   // 39: employee.SearchHit.HighlightsEntry
+	nil,                                   // 40: employee.MergeEmployeesRequest.FieldPoliciesEntry
+	(*timestamppb.Timestamp)(nil),         // 41: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),               // 42: google.protobuf.Struct
+	(*structpb.Value)(nil),                // 43: google.protobuf.Value
+	(*httpbody.HttpBody)(nil),             // 44: google.api.HttpBody
+}
+var file_employee_proto_depIdxs = []int32{
+	41, // 0: employee.GetEmployeeRequest.as_of:type_name -> google.protobuf.Timestamp
+	41, // 1: employee.ListEmployeesRequest.as_of:type_name -> google.protobuf.Timestamp
+	41, // 2: employee.EmploymentChangeRequest.effective_date:type_name -> google.protobuf.Timestamp
+	0,  // 3: employee.EmploymentEvent.from:type_name -> employee.EmploymentStatus
+	0,  // 4: employee.EmploymentEvent.to:type_name -> employee.EmploymentStatus
+	41, // 5: employee.EmploymentEvent.effective_date:type_name -> google.protobuf.Timestamp
+	41, // 6: employee.EmploymentEvent.recorded_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: employee.Employee.status:type_name -> employee.EmploymentStatus
+	41, // 8: employee.Employee.hire_date:type_name -> google.protobuf.Timestamp
+	41, // 9: employee.Employee.termination_date:type_name -> google.protobuf.Timestamp
+	8,  // 10: employee.Employee.status_history:type_name -> employee.EmploymentEvent
+	41, // 11: employee.Employee.effective_date:type_name -> google.protobuf.Timestamp
+	42, // 12: employee.Employee.custom_fields:type_name -> google.protobuf.Struct
+	9,  // 13: employee.EmployeeList.employees:type_name -> employee.Employee
+	9,  // 14: employee.OrgChartNode.employee:type_name -> employee.Employee
+	12, // 15: employee.OrgChartNode.reports:type_name -> employee.OrgChartNode
+	12, // 16: employee.OrgChart.roots:type_name -> employee.OrgChartNode
+	43, // 17: employee.FieldChange.before:type_name -> google.protobuf.Value
+	43, // 18: employee.FieldChange.after:type_name -> google.protobuf.Value
+	41, // 19: employee.EmployeeRevision.timestamp:type_name -> google.protobuf.Timestamp
+	41, // 20: employee.EmployeeRevision.effective_date:type_name -> google.protobuf.Timestamp
+	16, // 21: employee.EmployeeRevision.changes:type_name -> employee.FieldChange
+	9,  // 22: employee.EmployeeRevision.snapshot:type_name -> employee.Employee
+	18, // 23: employee.EmployeeRevision.merge:type_name -> employee.EmployeeMerge
+	17, // 24: employee.EmployeeRevisionList.revisions:type_name -> employee.EmployeeRevision
+	1,  // 25: employee.EmployeeEvent.type:type_name -> employee.ChangeType
+	9,  // 26: employee.EmployeeEvent.employee:type_name -> employee.Employee
+	41, // 27: employee.EmployeeEvent.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 28: employee.ImportEmployeesRequest.options:type_name -> employee.ImportOptions
+	38, // 29: employee.ImportOptions.header_mapping:type_name -> employee.ImportOptions.HeaderMappingEntry
+	24, // 30: employee.ImportReport.rows:type_name -> employee.ImportRowResult
+	41, // 31: employee.ExportEmployeesRequest.as_of:type_name -> google.protobuf.Timestamp
+	0,  // 32: employee.ExportEmployeesRequest.statuses:type_name -> employee.EmploymentStatus
+	9,  // 33: employee.SearchHit.employee:type_name -> employee.Employee
+	39, // 34: employee.SearchHit.highlights:type_name -> employee.SearchHit.HighlightsEntry
+	28, // 35: employee.SearchEmployeesResponse.hits:type_name -> employee.SearchHit
+	31, // 36: employee.SuggestEmployeesResponse.suggestions:type_name -> employee.EmployeeSuggestion
+	9,  // 37: employee.DuplicateCandidate.first:type_name -> employee.Employee
+	9,  // 38: employee.DuplicateCandidate.second:type_name -> employee.Employee
+	34, // 39: employee.DuplicateCandidateList.candidates:type_name -> employee.DuplicateCandidate
+	2,  // 40: employee.MergeEmployeesRequest.default_policy:type_name -> employee.MergePolicy
+	40, // 41: employee.MergeEmployeesRequest.field_policies:type_name -> employee.MergeEmployeesRequest.FieldPoliciesEntry
+	9,  // 42: employee.MergeEmployeesResponse.employee:type_name -> employee.Employee
+	2,  // 43: employee.MergeEmployeesRequest.FieldPoliciesEntry.value:type_name -> employee.MergePolicy
+	6,  // 44: employee.EmployeeService.GetEmployees:input_type -> employee.ListEmployeesRequest
+	5,  // 45: employee.EmployeeService.GetEmployee:input_type -> employee.GetEmployeeRequest
+	9,  // 46: employee.EmployeeService.CreateEmployee:input_type -> employee.Employee
+	9,  // 47: employee.EmployeeService.UpdateEmployee:input_type -> employee.Employee
+	4,  // 48: employee.EmployeeService.DeleteEmployee:input_type -> employee.EmployeeID
+	4,  // 49: employee.EmployeeService.ListDirectReports:input_type -> employee.EmployeeID
+	4,  // 50: employee.EmployeeService.ListReportingTree:input_type -> employee.EmployeeID
+	4,  // 51: employee.EmployeeService.ListManagementChain:input_type -> employee.EmployeeID
+	7,  // 52: employee.EmployeeService.HireEmployee:input_type -> employee.EmploymentChangeRequest
+	7,  // 53: employee.EmployeeService.StartEmployment:input_type -> employee.EmploymentChangeRequest
+	7,  // 54: employee.EmployeeService.PlaceOnLeave:input_type -> employee.EmploymentChangeRequest
+	7,  // 55: employee.EmployeeService.ReturnFromLeave:input_type -> employee.EmploymentChangeRequest
+	7,  // 56: employee.EmployeeService.SuspendEmployee:input_type -> employee.EmploymentChangeRequest
+	7,  // 57: employee.EmployeeService.ReinstateEmployee:input_type -> employee.EmploymentChangeRequest
+	7,  // 58: employee.EmployeeService.TerminateEmployee:input_type -> employee.EmploymentChangeRequest
+	7,  // 59: employee.EmployeeService.RehireEmployee:input_type -> employee.EmploymentChangeRequest
+	14, // 60: employee.EmployeeService.ListEmployeeRevisions:input_type -> employee.ListEmployeeRevisionsRequest
+	15, // 61: employee.EmployeeService.GetEmployeeRevision:input_type -> employee.EmployeeRevisionRequest
+	15, // 62: employee.EmployeeService.RestoreEmployeeRevision:input_type -> employee.EmployeeRevisionRequest
+	11, // 63: employee.EmployeeService.ExportOrgChart:input_type -> employee.OrgChartRequest
+	20, // 64: employee.EmployeeService.WatchEmployees:input_type -> employee.WatchEmployeesRequest
+	22, // 65: employee.EmployeeService.ImportEmployees:input_type -> employee.ImportEmployeesRequest
+	26, // 66: employee.EmployeeService.ExportEmployees:input_type -> employee.ExportEmployeesRequest
+	27, // 67: employee.EmployeeService.SearchEmployees:input_type -> employee.SearchEmployeesRequest
+	30, // 68: employee.EmployeeService.SuggestEmployees:input_type -> employee.SuggestEmployeesRequest
+	33, // 69: employee.EmployeeService.FindDuplicateEmployees:input_type -> employee.FindDuplicateEmployeesRequest
+	36, // 70: employee.EmployeeService.MergeEmployees:input_type -> employee.MergeEmployeesRequest
+	10, // 71: employee.EmployeeService.GetEmployees:output_type -> employee.EmployeeList
+	9,  // 72: employee.EmployeeService.GetEmployee:output_type -> employee.Employee
+	9,  // 73: employee.EmployeeService.CreateEmployee:output_type -> employee.Employee
+	9,  // 74: employee.EmployeeService.UpdateEmployee:output_type -> employee.Employee
+	3,  // 75: employee.EmployeeService.DeleteEmployee:output_type -> employee.Empty
+	10, // 76: employee.EmployeeService.ListDirectReports:output_type -> employee.EmployeeList
+	10, // 77: employee.EmployeeService.ListReportingTree:output_type -> employee.EmployeeList
+	10, // 78: employee.EmployeeService.ListManagementChain:output_type -> employee.EmployeeList
+	9,  // 79: employee.EmployeeService.HireEmployee:output_type -> employee.Employee
+	9,  // 80: employee.EmployeeService.StartEmployment:output_type -> employee.Employee
+	9,  // 81: employee.EmployeeService.PlaceOnLeave:output_type -> employee.Employee
+	9,  // 82: employee.EmployeeService.ReturnFromLeave:output_type -> employee.Employee
+	9,  // 83: employee.EmployeeService.SuspendEmployee:output_type -> employee.Employee
+	9,  // 84: employee.EmployeeService.ReinstateEmployee:output_type -> employee.Employee
+	9,  // 85: employee.EmployeeService.TerminateEmployee:output_type -> employee.Employee
+	9,  // 86: employee.EmployeeService.RehireEmployee:output_type -> employee.Employee
+	19, // 87: employee.EmployeeService.ListEmployeeRevisions:output_type -> employee.EmployeeRevisionList
+	17, // 88: employee.EmployeeService.GetEmployeeRevision:output_type -> employee.EmployeeRevision
+	9,  // 89: employee.EmployeeService.RestoreEmployeeRevision:output_type -> employee.Employee
+	44, // 90: employee.EmployeeService.ExportOrgChart:output_type -> google.api.HttpBody
+	21, // 91: employee.EmployeeService.WatchEmployees:output_type -> employee.EmployeeEvent
+	25, // 92: employee.EmployeeService.ImportEmployees:output_type -> employee.ImportReport
+	44, // 93: employee.EmployeeService.ExportEmployees:output_type -> google.api.HttpBody
+	29, // 94: employee.EmployeeService.SearchEmployees:output_type -> employee.SearchEmployeesResponse
+	32, // 95: employee.EmployeeService.SuggestEmployees:output_type -> employee.SuggestEmployeesResponse
+	35, // 96: employee.EmployeeService.FindDuplicateEmployees:output_type -> employee.DuplicateCandidateList
+	37, // 97: employee.EmployeeService.MergeEmployees:output_type -> employee.MergeEmployeesResponse
+	71, // [71:98] is the sub-list for method output_type
+	44, // [44:71] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
+}
+
+func init() { file_employee_proto_init() }
+func file_employee_proto_init() {
+	if File_employee_proto != nil {
+		return
+	}
+	file_employee_proto_msgTypes[19].OneofWrappers = []any{
+		(*ImportEmployeesRequest_Options)(nil),
+		(*ImportEmployeesRequest_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_employee_proto_rawDesc), len(file_employee_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      3,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_employee_proto_goTypes,
 		DependencyIndexes: file_employee_proto_depIdxs,
+		EnumInfos:         file_employee_proto_enumTypes,
 		MessageInfos:      file_employee_proto_msgTypes,
 	}.Build()
 	File_employee_proto = out.File
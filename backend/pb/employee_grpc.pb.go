@@ -8,6 +8,7 @@ package employee
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,20 +20,105 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EmployeeService_GetEmployees_FullMethodName   = "/employee.EmployeeService/GetEmployees"
-	EmployeeService_CreateEmployee_FullMethodName = "/employee.EmployeeService/CreateEmployee"
-	EmployeeService_UpdateEmployee_FullMethodName = "/employee.EmployeeService/UpdateEmployee"
-	EmployeeService_DeleteEmployee_FullMethodName = "/employee.EmployeeService/DeleteEmployee"
+	EmployeeService_GetEmployees_FullMethodName            = "/employee.EmployeeService/GetEmployees"
+	EmployeeService_GetEmployee_FullMethodName             = "/employee.EmployeeService/GetEmployee"
+	EmployeeService_CreateEmployee_FullMethodName          = "/employee.EmployeeService/CreateEmployee"
+	EmployeeService_UpdateEmployee_FullMethodName          = "/employee.EmployeeService/UpdateEmployee"
+	EmployeeService_DeleteEmployee_FullMethodName          = "/employee.EmployeeService/DeleteEmployee"
+	EmployeeService_ListDirectReports_FullMethodName       = "/employee.EmployeeService/ListDirectReports"
+	EmployeeService_ListReportingTree_FullMethodName       = "/employee.EmployeeService/ListReportingTree"
+	EmployeeService_ListManagementChain_FullMethodName     = "/employee.EmployeeService/ListManagementChain"
+	EmployeeService_HireEmployee_FullMethodName            = "/employee.EmployeeService/HireEmployee"
+	EmployeeService_StartEmployment_FullMethodName         = "/employee.EmployeeService/StartEmployment"
+	EmployeeService_PlaceOnLeave_FullMethodName            = "/employee.EmployeeService/PlaceOnLeave"
+	EmployeeService_ReturnFromLeave_FullMethodName         = "/employee.EmployeeService/ReturnFromLeave"
+	EmployeeService_SuspendEmployee_FullMethodName         = "/employee.EmployeeService/SuspendEmployee"
+	EmployeeService_ReinstateEmployee_FullMethodName       = "/employee.EmployeeService/ReinstateEmployee"
+	EmployeeService_TerminateEmployee_FullMethodName       = "/employee.EmployeeService/TerminateEmployee"
+	EmployeeService_RehireEmployee_FullMethodName          = "/employee.EmployeeService/RehireEmployee"
+	EmployeeService_ListEmployeeRevisions_FullMethodName   = "/employee.EmployeeService/ListEmployeeRevisions"
+	EmployeeService_GetEmployeeRevision_FullMethodName     = "/employee.EmployeeService/GetEmployeeRevision"
+	EmployeeService_RestoreEmployeeRevision_FullMethodName = "/employee.EmployeeService/RestoreEmployeeRevision"
+	EmployeeService_ExportOrgChart_FullMethodName          = "/employee.EmployeeService/ExportOrgChart"
+	EmployeeService_WatchEmployees_FullMethodName          = "/employee.EmployeeService/WatchEmployees"
+	EmployeeService_ImportEmployees_FullMethodName         = "/employee.EmployeeService/ImportEmployees"
+	EmployeeService_ExportEmployees_FullMethodName         = "/employee.EmployeeService/ExportEmployees"
+	EmployeeService_SearchEmployees_FullMethodName         = "/employee.EmployeeService/SearchEmployees"
+	EmployeeService_SuggestEmployees_FullMethodName        = "/employee.EmployeeService/SuggestEmployees"
+	EmployeeService_FindDuplicateEmployees_FullMethodName  = "/employee.EmployeeService/FindDuplicateEmployees"
+	EmployeeService_MergeEmployees_FullMethodName          = "/employee.EmployeeService/MergeEmployees"
 )
 
 // EmployeeServiceClient is the client API for EmployeeService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type EmployeeServiceClient interface {
-	GetEmployees(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EmployeeList, error)
+	GetEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*EmployeeList, error)
+	GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error)
 	CreateEmployee(ctx context.Context, in *Employee, opts ...grpc.CallOption) (*Employee, error)
 	UpdateEmployee(ctx context.Context, in *Employee, opts ...grpc.CallOption) (*Employee, error)
 	DeleteEmployee(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*Empty, error)
+	// Direct reports of a manager
+	ListDirectReports(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error)
+	// Everyone below a manager, at any depth
+	ListReportingTree(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error)
+	// Managers above an employee, nearest first, ending at the top of the org
+	ListManagementChain(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error)
+	// candidate -> pre-boarding (future start date) or active
+	HireEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// pre-boarding -> active
+	StartEmployment(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// active -> on leave
+	PlaceOnLeave(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// on leave -> active
+	ReturnFromLeave(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// active or on leave -> suspended
+	SuspendEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// suspended -> active
+	ReinstateEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// pre-boarding, active, on leave or suspended -> terminated
+	TerminateEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// terminated -> pre-boarding (future start date) or active
+	RehireEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error)
+	// Change history. Every create, update, delete and status change is kept
+	// as an immutable revision.
+	ListEmployeeRevisions(ctx context.Context, in *ListEmployeeRevisionsRequest, opts ...grpc.CallOption) (*EmployeeRevisionList, error)
+	GetEmployeeRevision(ctx context.Context, in *EmployeeRevisionRequest, opts ...grpc.CallOption) (*EmployeeRevision, error)
+	// Put the employee back to the state recorded in a revision, recreating it
+	// if it has been deleted since
+	RestoreEmployeeRevision(ctx context.Context, in *EmployeeRevisionRequest, opts ...grpc.CallOption) (*Employee, error)
+	// Org chart as a JSON tree, Graphviz DOT or SVG
+	ExportOrgChart(ctx context.Context, in *OrgChartRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Stream employee changes as they happen. Over REST this is
+	// newline-delimited JSON, or Server-Sent Events with
+	// Accept: text/event-stream.
+	WatchEmployees(ctx context.Context, in *WatchEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmployeeEvent], error)
+	// Create or update employees from a CSV or XLSX file. The first message
+	// carries the options, the following ones the file in chunks. Over REST
+	// this is a multipart POST /v1/employees:import with the file in a "file"
+	// part.
+	ImportEmployees(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEmployeesRequest, ImportReport], error)
+	// Export employees as CSV, JSON Lines, Parquet or XLSX, streamed in
+	// chunks; the first chunk carries the content type. Over REST this is
+	// GET /v1/employees:export, which downloads the file.
+	ExportEmployees(ctx context.Context, in *ExportEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
+	// Search names, email, position and department. Words match with typos
+	// and the last word also as a prefix, for type-ahead. Best matches come
+	// first, with the matching words highlighted.
+	SearchEmployees(ctx context.Context, in *SearchEmployeesRequest, opts ...grpc.CallOption) (*SearchEmployeesResponse, error)
+	// Up to limit employees whose name, a word of it or email starts with
+	// prefix, as compact entries for people pickers. Full names match first,
+	// then name words, then emails.
+	SuggestEmployees(ctx context.Context, in *SuggestEmployeesRequest, opts ...grpc.CallOption) (*SuggestEmployeesResponse, error)
+	// Pairs of employees that are likely the same person, scored on name
+	// similarity (nicknames and typos included), normalized email and
+	// department. Best matches first.
+	FindDuplicateEmployees(ctx context.Context, in *FindDuplicateEmployeesRequest, opts ...grpc.CallOption) (*DuplicateCandidateList, error)
+	// Fold the duplicate into the survivor: each field is resolved by its
+	// policy, the duplicate's reports, department heads and scheduled changes
+	// move to the survivor, and the duplicate is deleted. Status and its
+	// history stay the survivor's. Every revision written carries the merge.
+	MergeEmployees(ctx context.Context, in *MergeEmployeesRequest, opts ...grpc.CallOption) (*MergeEmployeesResponse, error)
 }
 
 type employeeServiceClient struct {
@@ -43,7 +129,7 @@ func NewEmployeeServiceClient(cc grpc.ClientConnInterface) EmployeeServiceClient
 	return &employeeServiceClient{cc}
 }
 
-func (c *employeeServiceClient) GetEmployees(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EmployeeList, error) {
+func (c *employeeServiceClient) GetEmployees(ctx context.Context, in *ListEmployeesRequest, opts ...grpc.CallOption) (*EmployeeList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EmployeeList)
 	err := c.cc.Invoke(ctx, EmployeeService_GetEmployees_FullMethodName, in, out, cOpts...)
@@ -53,6 +139,16 @@ func (c *employeeServiceClient) GetEmployees(ctx context.Context, in *Empty, opt
 	return out, nil
 }
 
+func (c *employeeServiceClient) GetEmployee(ctx context.Context, in *GetEmployeeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_GetEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *employeeServiceClient) CreateEmployee(ctx context.Context, in *Employee, opts ...grpc.CallOption) (*Employee, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Employee)
@@ -83,14 +179,317 @@ func (c *employeeServiceClient) DeleteEmployee(ctx context.Context, in *Employee
 	return out, nil
 }
 
+func (c *employeeServiceClient) ListDirectReports(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, EmployeeService_ListDirectReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListReportingTree(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, EmployeeService_ListReportingTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListManagementChain(ctx context.Context, in *EmployeeID, opts ...grpc.CallOption) (*EmployeeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeeList)
+	err := c.cc.Invoke(ctx, EmployeeService_ListManagementChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) HireEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_HireEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) StartEmployment(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_StartEmployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) PlaceOnLeave(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_PlaceOnLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ReturnFromLeave(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_ReturnFromLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) SuspendEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_SuspendEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ReinstateEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_ReinstateEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) TerminateEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_TerminateEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) RehireEmployee(ctx context.Context, in *EmploymentChangeRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_RehireEmployee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ListEmployeeRevisions(ctx context.Context, in *ListEmployeeRevisionsRequest, opts ...grpc.CallOption) (*EmployeeRevisionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeeRevisionList)
+	err := c.cc.Invoke(ctx, EmployeeService_ListEmployeeRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) GetEmployeeRevision(ctx context.Context, in *EmployeeRevisionRequest, opts ...grpc.CallOption) (*EmployeeRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmployeeRevision)
+	err := c.cc.Invoke(ctx, EmployeeService_GetEmployeeRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) RestoreEmployeeRevision(ctx context.Context, in *EmployeeRevisionRequest, opts ...grpc.CallOption) (*Employee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Employee)
+	err := c.cc.Invoke(ctx, EmployeeService_RestoreEmployeeRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) ExportOrgChart(ctx context.Context, in *OrgChartRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, EmployeeService_ExportOrgChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) WatchEmployees(ctx context.Context, in *WatchEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmployeeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmployeeService_ServiceDesc.Streams[0], EmployeeService_WatchEmployees_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEmployeesRequest, EmployeeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_WatchEmployeesClient = grpc.ServerStreamingClient[EmployeeEvent]
+
+func (c *employeeServiceClient) ImportEmployees(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEmployeesRequest, ImportReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmployeeService_ServiceDesc.Streams[1], EmployeeService_ImportEmployees_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportEmployeesRequest, ImportReport]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_ImportEmployeesClient = grpc.ClientStreamingClient[ImportEmployeesRequest, ImportReport]
+
+func (c *employeeServiceClient) ExportEmployees(ctx context.Context, in *ExportEmployeesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EmployeeService_ServiceDesc.Streams[2], EmployeeService_ExportEmployees_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportEmployeesRequest, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_ExportEmployeesClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
+func (c *employeeServiceClient) SearchEmployees(ctx context.Context, in *SearchEmployeesRequest, opts ...grpc.CallOption) (*SearchEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_SearchEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) SuggestEmployees(ctx context.Context, in *SuggestEmployeesRequest, opts ...grpc.CallOption) (*SuggestEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_SuggestEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) FindDuplicateEmployees(ctx context.Context, in *FindDuplicateEmployeesRequest, opts ...grpc.CallOption) (*DuplicateCandidateList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DuplicateCandidateList)
+	err := c.cc.Invoke(ctx, EmployeeService_FindDuplicateEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *employeeServiceClient) MergeEmployees(ctx context.Context, in *MergeEmployeesRequest, opts ...grpc.CallOption) (*MergeEmployeesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeEmployeesResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_MergeEmployees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmployeeServiceServer is the server API for EmployeeService service.
 // All implementations must embed UnimplementedEmployeeServiceServer
 // for forward compatibility.
 type EmployeeServiceServer interface {
-	GetEmployees(context.Context, *Empty) (*EmployeeList, error)
+	GetEmployees(context.Context, *ListEmployeesRequest) (*EmployeeList, error)
+	GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error)
 	CreateEmployee(context.Context, *Employee) (*Employee, error)
 	UpdateEmployee(context.Context, *Employee) (*Employee, error)
 	DeleteEmployee(context.Context, *EmployeeID) (*Empty, error)
+	// Direct reports of a manager
+	ListDirectReports(context.Context, *EmployeeID) (*EmployeeList, error)
+	// Everyone below a manager, at any depth
+	ListReportingTree(context.Context, *EmployeeID) (*EmployeeList, error)
+	// Managers above an employee, nearest first, ending at the top of the org
+	ListManagementChain(context.Context, *EmployeeID) (*EmployeeList, error)
+	// candidate -> pre-boarding (future start date) or active
+	HireEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// pre-boarding -> active
+	StartEmployment(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// active -> on leave
+	PlaceOnLeave(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// on leave -> active
+	ReturnFromLeave(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// active or on leave -> suspended
+	SuspendEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// suspended -> active
+	ReinstateEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// pre-boarding, active, on leave or suspended -> terminated
+	TerminateEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// terminated -> pre-boarding (future start date) or active
+	RehireEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error)
+	// Change history. Every create, update, delete and status change is kept
+	// as an immutable revision.
+	ListEmployeeRevisions(context.Context, *ListEmployeeRevisionsRequest) (*EmployeeRevisionList, error)
+	GetEmployeeRevision(context.Context, *EmployeeRevisionRequest) (*EmployeeRevision, error)
+	// Put the employee back to the state recorded in a revision, recreating it
+	// if it has been deleted since
+	RestoreEmployeeRevision(context.Context, *EmployeeRevisionRequest) (*Employee, error)
+	// Org chart as a JSON tree, Graphviz DOT or SVG
+	ExportOrgChart(context.Context, *OrgChartRequest) (*httpbody.HttpBody, error)
+	// Stream employee changes as they happen. Over REST this is
+	// newline-delimited JSON, or Server-Sent Events with
+	// Accept: text/event-stream.
+	WatchEmployees(*WatchEmployeesRequest, grpc.ServerStreamingServer[EmployeeEvent]) error
+	// Create or update employees from a CSV or XLSX file. The first message
+	// carries the options, the following ones the file in chunks. Over REST
+	// this is a multipart POST /v1/employees:import with the file in a "file"
+	// part.
+	ImportEmployees(grpc.ClientStreamingServer[ImportEmployeesRequest, ImportReport]) error
+	// Export employees as CSV, JSON Lines, Parquet or XLSX, streamed in
+	// chunks; the first chunk carries the content type. Over REST this is
+	// GET /v1/employees:export, which downloads the file.
+	ExportEmployees(*ExportEmployeesRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
+	// Search names, email, position and department. Words match with typos
+	// and the last word also as a prefix, for type-ahead. Best matches come
+	// first, with the matching words highlighted.
+	SearchEmployees(context.Context, *SearchEmployeesRequest) (*SearchEmployeesResponse, error)
+	// Up to limit employees whose name, a word of it or email starts with
+	// prefix, as compact entries for people pickers. Full names match first,
+	// then name words, then emails.
+	SuggestEmployees(context.Context, *SuggestEmployeesRequest) (*SuggestEmployeesResponse, error)
+	// Pairs of employees that are likely the same person, scored on name
+	// similarity (nicknames and typos included), normalized email and
+	// department. Best matches first.
+	FindDuplicateEmployees(context.Context, *FindDuplicateEmployeesRequest) (*DuplicateCandidateList, error)
+	// Fold the duplicate into the survivor: each field is resolved by its
+	// policy, the duplicate's reports, department heads and scheduled changes
+	// move to the survivor, and the duplicate is deleted. Status and its
+	// history stay the survivor's. Every revision written carries the merge.
+	MergeEmployees(context.Context, *MergeEmployeesRequest) (*MergeEmployeesResponse, error)
 	mustEmbedUnimplementedEmployeeServiceServer()
 }
 
@@ -101,9 +500,12 @@ type EmployeeServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedEmployeeServiceServer struct{}
 
-func (UnimplementedEmployeeServiceServer) GetEmployees(context.Context, *Empty) (*EmployeeList, error) {
+func (UnimplementedEmployeeServiceServer) GetEmployees(context.Context, *ListEmployeesRequest) (*EmployeeList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEmployees not implemented")
 }
+func (UnimplementedEmployeeServiceServer) GetEmployee(context.Context, *GetEmployeeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmployee not implemented")
+}
 func (UnimplementedEmployeeServiceServer) CreateEmployee(context.Context, *Employee) (*Employee, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEmployee not implemented")
 }
@@ -113,6 +515,72 @@ func (UnimplementedEmployeeServiceServer) UpdateEmployee(context.Context, *Emplo
 func (UnimplementedEmployeeServiceServer) DeleteEmployee(context.Context, *EmployeeID) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEmployee not implemented")
 }
+func (UnimplementedEmployeeServiceServer) ListDirectReports(context.Context, *EmployeeID) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDirectReports not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListReportingTree(context.Context, *EmployeeID) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReportingTree not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListManagementChain(context.Context, *EmployeeID) (*EmployeeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListManagementChain not implemented")
+}
+func (UnimplementedEmployeeServiceServer) HireEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HireEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) StartEmployment(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartEmployment not implemented")
+}
+func (UnimplementedEmployeeServiceServer) PlaceOnLeave(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOnLeave not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ReturnFromLeave(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnFromLeave not implemented")
+}
+func (UnimplementedEmployeeServiceServer) SuspendEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ReinstateEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReinstateEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) TerminateEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) RehireEmployee(context.Context, *EmploymentChangeRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RehireEmployee not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ListEmployeeRevisions(context.Context, *ListEmployeeRevisionsRequest) (*EmployeeRevisionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmployeeRevisions not implemented")
+}
+func (UnimplementedEmployeeServiceServer) GetEmployeeRevision(context.Context, *EmployeeRevisionRequest) (*EmployeeRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmployeeRevision not implemented")
+}
+func (UnimplementedEmployeeServiceServer) RestoreEmployeeRevision(context.Context, *EmployeeRevisionRequest) (*Employee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEmployeeRevision not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ExportOrgChart(context.Context, *OrgChartRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportOrgChart not implemented")
+}
+func (UnimplementedEmployeeServiceServer) WatchEmployees(*WatchEmployeesRequest, grpc.ServerStreamingServer[EmployeeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ImportEmployees(grpc.ClientStreamingServer[ImportEmployeesRequest, ImportReport]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) ExportEmployees(*ExportEmployeesRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) SearchEmployees(context.Context, *SearchEmployeesRequest) (*SearchEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) SuggestEmployees(context.Context, *SuggestEmployeesRequest) (*SuggestEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) FindDuplicateEmployees(context.Context, *FindDuplicateEmployeesRequest) (*DuplicateCandidateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicateEmployees not implemented")
+}
+func (UnimplementedEmployeeServiceServer) MergeEmployees(context.Context, *MergeEmployeesRequest) (*MergeEmployeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeEmployees not implemented")
+}
 func (UnimplementedEmployeeServiceServer) mustEmbedUnimplementedEmployeeServiceServer() {}
 func (UnimplementedEmployeeServiceServer) testEmbeddedByValue()                         {}
 
@@ -135,7 +603,7 @@ func RegisterEmployeeServiceServer(s grpc.ServiceRegistrar, srv EmployeeServiceS
 }
 
 func _EmployeeService_GetEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListEmployeesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -147,7 +615,25 @@ func _EmployeeService_GetEmployees_Handler(srv interface{}, ctx context.Context,
 		FullMethod: EmployeeService_GetEmployees_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EmployeeServiceServer).GetEmployees(ctx, req.(*Empty))
+		return srv.(EmployeeServiceServer).GetEmployees(ctx, req.(*ListEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmployeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetEmployee(ctx, req.(*GetEmployeeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -206,30 +692,497 @@ func _EmployeeService_DeleteEmployee_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
-// EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var EmployeeService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "employee.EmployeeService",
-	HandlerType: (*EmployeeServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetEmployees",
-			Handler:    _EmployeeService_GetEmployees_Handler,
-		},
-		{
-			MethodName: "CreateEmployee",
-			Handler:    _EmployeeService_CreateEmployee_Handler,
-		},
-		{
-			MethodName: "UpdateEmployee",
-			Handler:    _EmployeeService_UpdateEmployee_Handler,
-		},
-		{
-			MethodName: "DeleteEmployee",
-			Handler:    _EmployeeService_DeleteEmployee_Handler,
+func _EmployeeService_ListDirectReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListDirectReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListDirectReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListDirectReports(ctx, req.(*EmployeeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListReportingTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListReportingTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListReportingTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListReportingTree(ctx, req.(*EmployeeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListManagementChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListManagementChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListManagementChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListManagementChain(ctx, req.(*EmployeeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_HireEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).HireEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_HireEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).HireEmployee(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_StartEmployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).StartEmployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_StartEmployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).StartEmployment(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_PlaceOnLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).PlaceOnLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_PlaceOnLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).PlaceOnLeave(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ReturnFromLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ReturnFromLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ReturnFromLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ReturnFromLeave(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_SuspendEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).SuspendEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_SuspendEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).SuspendEmployee(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ReinstateEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ReinstateEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ReinstateEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ReinstateEmployee(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_TerminateEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).TerminateEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_TerminateEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).TerminateEmployee(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_RehireEmployee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmploymentChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).RehireEmployee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_RehireEmployee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).RehireEmployee(ctx, req.(*EmploymentChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ListEmployeeRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmployeeRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ListEmployeeRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ListEmployeeRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ListEmployeeRevisions(ctx, req.(*ListEmployeeRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_GetEmployeeRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetEmployeeRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetEmployeeRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetEmployeeRevision(ctx, req.(*EmployeeRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_RestoreEmployeeRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmployeeRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).RestoreEmployeeRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_RestoreEmployeeRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).RestoreEmployeeRevision(ctx, req.(*EmployeeRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_ExportOrgChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrgChartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).ExportOrgChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_ExportOrgChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).ExportOrgChart(ctx, req.(*OrgChartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_WatchEmployees_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEmployeesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmployeeServiceServer).WatchEmployees(m, &grpc.GenericServerStream[WatchEmployeesRequest, EmployeeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_WatchEmployeesServer = grpc.ServerStreamingServer[EmployeeEvent]
+
+func _EmployeeService_ImportEmployees_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EmployeeServiceServer).ImportEmployees(&grpc.GenericServerStream[ImportEmployeesRequest, ImportReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_ImportEmployeesServer = grpc.ClientStreamingServer[ImportEmployeesRequest, ImportReport]
+
+func _EmployeeService_ExportEmployees_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEmployeesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmployeeServiceServer).ExportEmployees(m, &grpc.GenericServerStream[ExportEmployeesRequest, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EmployeeService_ExportEmployeesServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
+func _EmployeeService_SearchEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).SearchEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_SearchEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).SearchEmployees(ctx, req.(*SearchEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_SuggestEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).SuggestEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_SuggestEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).SuggestEmployees(ctx, req.(*SuggestEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_FindDuplicateEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicateEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).FindDuplicateEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_FindDuplicateEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).FindDuplicateEmployees(ctx, req.(*FindDuplicateEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmployeeService_MergeEmployees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).MergeEmployees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_MergeEmployees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).MergeEmployees(ctx, req.(*MergeEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmployeeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.EmployeeService",
+	HandlerType: (*EmployeeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEmployees",
+			Handler:    _EmployeeService_GetEmployees_Handler,
+		},
+		{
+			MethodName: "GetEmployee",
+			Handler:    _EmployeeService_GetEmployee_Handler,
+		},
+		{
+			MethodName: "CreateEmployee",
+			Handler:    _EmployeeService_CreateEmployee_Handler,
+		},
+		{
+			MethodName: "UpdateEmployee",
+			Handler:    _EmployeeService_UpdateEmployee_Handler,
+		},
+		{
+			MethodName: "DeleteEmployee",
+			Handler:    _EmployeeService_DeleteEmployee_Handler,
+		},
+		{
+			MethodName: "ListDirectReports",
+			Handler:    _EmployeeService_ListDirectReports_Handler,
+		},
+		{
+			MethodName: "ListReportingTree",
+			Handler:    _EmployeeService_ListReportingTree_Handler,
+		},
+		{
+			MethodName: "ListManagementChain",
+			Handler:    _EmployeeService_ListManagementChain_Handler,
+		},
+		{
+			MethodName: "HireEmployee",
+			Handler:    _EmployeeService_HireEmployee_Handler,
+		},
+		{
+			MethodName: "StartEmployment",
+			Handler:    _EmployeeService_StartEmployment_Handler,
+		},
+		{
+			MethodName: "PlaceOnLeave",
+			Handler:    _EmployeeService_PlaceOnLeave_Handler,
+		},
+		{
+			MethodName: "ReturnFromLeave",
+			Handler:    _EmployeeService_ReturnFromLeave_Handler,
+		},
+		{
+			MethodName: "SuspendEmployee",
+			Handler:    _EmployeeService_SuspendEmployee_Handler,
+		},
+		{
+			MethodName: "ReinstateEmployee",
+			Handler:    _EmployeeService_ReinstateEmployee_Handler,
+		},
+		{
+			MethodName: "TerminateEmployee",
+			Handler:    _EmployeeService_TerminateEmployee_Handler,
+		},
+		{
+			MethodName: "RehireEmployee",
+			Handler:    _EmployeeService_RehireEmployee_Handler,
+		},
+		{
+			MethodName: "ListEmployeeRevisions",
+			Handler:    _EmployeeService_ListEmployeeRevisions_Handler,
+		},
+		{
+			MethodName: "GetEmployeeRevision",
+			Handler:    _EmployeeService_GetEmployeeRevision_Handler,
+		},
+		{
+			MethodName: "RestoreEmployeeRevision",
+			Handler:    _EmployeeService_RestoreEmployeeRevision_Handler,
+		},
+		{
+			MethodName: "ExportOrgChart",
+			Handler:    _EmployeeService_ExportOrgChart_Handler,
+		},
+		{
+			MethodName: "SearchEmployees",
+			Handler:    _EmployeeService_SearchEmployees_Handler,
+		},
+		{
+			MethodName: "SuggestEmployees",
+			Handler:    _EmployeeService_SuggestEmployees_Handler,
+		},
+		{
+			MethodName: "FindDuplicateEmployees",
+			Handler:    _EmployeeService_FindDuplicateEmployees_Handler,
+		},
+		{
+			MethodName: "MergeEmployees",
+			Handler:    _EmployeeService_MergeEmployees_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEmployees",
+			Handler:       _EmployeeService_WatchEmployees_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportEmployees",
+			Handler:       _EmployeeService_ImportEmployees_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportEmployees",
+			Handler:       _EmployeeService_ExportEmployees_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "employee.proto",
 }
@@ -0,0 +1,352 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: events.proto
+
+package employee
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An employee was added, or a deleted one was restored
+type EmployeeCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Employee      *Employee              `protobuf:"bytes,1,opt,name=employee,proto3" json:"employee,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	// Revision of the employee this event was recorded as
+	Revision      int64 `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeCreated) Reset() {
+	*x = EmployeeCreated{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeCreated) ProtoMessage() {}
+
+func (x *EmployeeCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeCreated.ProtoReflect.Descriptor instead.
+func (*EmployeeCreated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EmployeeCreated) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *EmployeeCreated) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EmployeeCreated) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *EmployeeCreated) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type EmployeeUpdated struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId string                 `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	// update, status_change, restore or merge
+	Action  string         `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Changes []*FieldChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The employee after the change
+	Employee      *Employee              `protobuf:"bytes,4,opt,name=employee,proto3" json:"employee,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Revision      int64                  `protobuf:"varint,7,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeUpdated) Reset() {
+	*x = EmployeeUpdated{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeUpdated) ProtoMessage() {}
+
+func (x *EmployeeUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeUpdated.ProtoReflect.Descriptor instead.
+func (*EmployeeUpdated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EmployeeUpdated) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *EmployeeUpdated) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *EmployeeUpdated) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *EmployeeUpdated) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *EmployeeUpdated) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EmployeeUpdated) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *EmployeeUpdated) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type EmployeeDeleted struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EmployeeId string                 `protobuf:"bytes,1,opt,name=employee_id,json=employeeId,proto3" json:"employee_id,omitempty"`
+	// The employee as it was when deleted
+	Employee      *Employee              `protobuf:"bytes,2,opt,name=employee,proto3" json:"employee,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Revision      int64                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeDeleted) Reset() {
+	*x = EmployeeDeleted{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeDeleted) ProtoMessage() {}
+
+func (x *EmployeeDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeDeleted.ProtoReflect.Descriptor instead.
+func (*EmployeeDeleted) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EmployeeDeleted) GetEmployeeId() string {
+	if x != nil {
+		return x.EmployeeId
+	}
+	return ""
+}
+
+func (x *EmployeeDeleted) GetEmployee() *Employee {
+	if x != nil {
+		return x.Employee
+	}
+	return nil
+}
+
+func (x *EmployeeDeleted) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EmployeeDeleted) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
+func (x *EmployeeDeleted) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\bemployee\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eemployee.proto\"\xb6\x01\n" +
+	"\x0fEmployeeCreated\x12.\n" +
+	"\bemployee\x18\x01 \x01(\v2\x12.employee.EmployeeR\bemployee\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12A\n" +
+	"\x0eeffective_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\x03R\brevision\"\xa0\x02\n" +
+	"\x0fEmployeeUpdated\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12/\n" +
+	"\achanges\x18\x03 \x03(\v2\x15.employee.FieldChangeR\achanges\x12.\n" +
+	"\bemployee\x18\x04 \x01(\v2\x12.employee.EmployeeR\bemployee\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12A\n" +
+	"\x0eeffective_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12\x1a\n" +
+	"\brevision\x18\a \x01(\x03R\brevision\"\xd7\x01\n" +
+	"\x0fEmployeeDeleted\x12\x1f\n" +
+	"\vemployee_id\x18\x01 \x01(\tR\n" +
+	"employeeId\x12.\n" +
+	"\bemployee\x18\x02 \x01(\v2\x12.employee.EmployeeR\bemployee\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12A\n" +
+	"\x0eeffective_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x03R\brevisionB\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_proto_goTypes = []any{
+	(*EmployeeCreated)(nil),       // 0: employee.EmployeeCreated
+	(*EmployeeUpdated)(nil),       // 1: employee.EmployeeUpdated
+	(*EmployeeDeleted)(nil),       // 2: employee.EmployeeDeleted
+	(*Employee)(nil),              // 3: employee.Employee
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*FieldChange)(nil),           // 5: employee.FieldChange
+}
+var file_events_proto_depIdxs = []int32{
+	3, // 0: employee.EmployeeCreated.employee:type_name -> employee.Employee
+	4, // 1: employee.EmployeeCreated.effective_date:type_name -> google.protobuf.Timestamp
+	5, // 2: employee.EmployeeUpdated.changes:type_name -> employee.FieldChange
+	3, // 3: employee.EmployeeUpdated.employee:type_name -> employee.Employee
+	4, // 4: employee.EmployeeUpdated.effective_date:type_name -> google.protobuf.Timestamp
+	3, // 5: employee.EmployeeDeleted.employee:type_name -> employee.Employee
+	4, // 6: employee.EmployeeDeleted.effective_date:type_name -> google.protobuf.Timestamp
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
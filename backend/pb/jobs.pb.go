@@ -0,0 +1,576 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: jobs.proto
+
+package employee
+
+import (
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JobState int32
+
+const (
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	// Waiting for a worker
+	JobState_JOB_PENDING   JobState = 1
+	JobState_JOB_RUNNING   JobState = 2
+	JobState_JOB_SUCCEEDED JobState = 3
+	JobState_JOB_FAILED    JobState = 4
+	JobState_JOB_CANCELLED JobState = 5
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_STATE_UNSPECIFIED",
+		1: "JOB_PENDING",
+		2: "JOB_RUNNING",
+		3: "JOB_SUCCEEDED",
+		4: "JOB_FAILED",
+		5: "JOB_CANCELLED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_STATE_UNSPECIFIED": 0,
+		"JOB_PENDING":           1,
+		"JOB_RUNNING":           2,
+		"JOB_SUCCEEDED":         3,
+		"JOB_FAILED":            4,
+		"JOB_CANCELLED":         5,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_jobs_proto_enumTypes[0].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_jobs_proto_enumTypes[0]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+type JobMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// import, export or reindex
+	Kind  string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	State JobState `protobuf:"varint,2,opt,name=state,proto3,enum=employee.JobState" json:"state,omitempty"`
+	// Rows or records handled so far
+	Processed int64 `protobuf:"varint,3,opt,name=processed,proto3" json:"processed,omitempty"`
+	// Rows or records to handle, 0 while unknown
+	Total           int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	PercentComplete int32 `protobuf:"varint,5,opt,name=percent_complete,json=percentComplete,proto3" json:"percent_complete,omitempty"`
+	// Times a worker has started the job
+	Attempts        int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	CancelRequested bool                   `protobuf:"varint,7,opt,name=cancel_requested,json=cancelRequested,proto3" json:"cancel_requested,omitempty"`
+	Actor           string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreateTime      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	UpdateTime      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Where to download the job file, once there is one
+	FileUri       string `protobuf:"bytes,13,opt,name=file_uri,json=fileUri,proto3" json:"file_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobMetadata) Reset() {
+	*x = JobMetadata{}
+	mi := &file_jobs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobMetadata) ProtoMessage() {}
+
+func (x *JobMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobMetadata.ProtoReflect.Descriptor instead.
+func (*JobMetadata) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *JobMetadata) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *JobMetadata) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (x *JobMetadata) GetProcessed() int64 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *JobMetadata) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *JobMetadata) GetPercentComplete() int32 {
+	if x != nil {
+		return x.PercentComplete
+	}
+	return 0
+}
+
+func (x *JobMetadata) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *JobMetadata) GetCancelRequested() bool {
+	if x != nil {
+		return x.CancelRequested
+	}
+	return false
+}
+
+func (x *JobMetadata) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *JobMetadata) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *JobMetadata) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *JobMetadata) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *JobMetadata) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *JobMetadata) GetFileUri() string {
+	if x != nil {
+		return x.FileUri
+	}
+	return ""
+}
+
+type ExportJobResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Rows          int64                  `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportJobResult) Reset() {
+	*x = ExportJobResult{}
+	mi := &file_jobs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportJobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobResult) ProtoMessage() {}
+
+func (x *ExportJobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobResult.ProtoReflect.Descriptor instead.
+func (*ExportJobResult) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportJobResult) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportJobResult) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportJobResult) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ExportJobResult) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+type ReindexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Count what is out of date without changing it
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
+	mi := &file_jobs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexRequest) ProtoMessage() {}
+
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReindexRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ReindexResult struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DryRun           bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	EmployeesChecked int64                  `protobuf:"varint,2,opt,name=employees_checked,json=employeesChecked,proto3" json:"employees_checked,omitempty"`
+	// Employees that were out of date, and were updated unless dry_run
+	EmployeesUpdated int64 `protobuf:"varint,3,opt,name=employees_updated,json=employeesUpdated,proto3" json:"employees_updated,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReindexResult) Reset() {
+	*x = ReindexResult{}
+	mi := &file_jobs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexResult) ProtoMessage() {}
+
+func (x *ReindexResult) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexResult.ProtoReflect.Descriptor instead.
+func (*ReindexResult) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReindexResult) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReindexResult) GetEmployeesChecked() int64 {
+	if x != nil {
+		return x.EmployeesChecked
+	}
+	return 0
+}
+
+func (x *ReindexResult) GetEmployeesUpdated() int64 {
+	if x != nil {
+		return x.EmployeesUpdated
+	}
+	return 0
+}
+
+type JobFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operation name (operations/{id}) or ID
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobFileRequest) Reset() {
+	*x = JobFileRequest{}
+	mi := &file_jobs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobFileRequest) ProtoMessage() {}
+
+func (x *JobFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobFileRequest.ProtoReflect.Descriptor instead.
+func (*JobFileRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JobFileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_jobs_proto protoreflect.FileDescriptor
+
+const file_jobs_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"jobs.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a#google/longrunning/operations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eemployee.proto\"\x8e\x04\n" +
+	"\vJobMetadata\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12(\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x12.employee.JobStateR\x05state\x12\x1c\n" +
+	"\tprocessed\x18\x03 \x01(\x03R\tprocessed\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12)\n" +
+	"\x10percent_complete\x18\x05 \x01(\x05R\x0fpercentComplete\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12)\n" +
+	"\x10cancel_requested\x18\a \x01(\bR\x0fcancelRequested\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x129\n" +
+	"\n" +
+	"start_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12;\n" +
+	"\vupdate_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x125\n" +
+	"\bend_time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x19\n" +
+	"\bfile_uri\x18\r \x01(\tR\afileUri\"\x84\x01\n" +
+	"\x0fExportJobResult\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x12\n" +
+	"\x04rows\x18\x04 \x01(\x03R\x04rows\")\n" +
+	"\x0eReindexRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\x82\x01\n" +
+	"\rReindexResult\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12+\n" +
+	"\x11employees_checked\x18\x02 \x01(\x03R\x10employeesChecked\x12+\n" +
+	"\x11employees_updated\x18\x03 \x01(\x03R\x10employeesUpdated\"$\n" +
+	"\x0eJobFileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name*}\n" +
+	"\bJobState\x12\x19\n" +
+	"\x15JOB_STATE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vJOB_PENDING\x10\x01\x12\x0f\n" +
+	"\vJOB_RUNNING\x10\x02\x12\x11\n" +
+	"\rJOB_SUCCEEDED\x10\x03\x12\x0e\n" +
+	"\n" +
+	"JOB_FAILED\x10\x04\x12\x11\n" +
+	"\rJOB_CANCELLED\x10\x052\xfe\x02\n" +
+	"\n" +
+	"JobService\x12S\n" +
+	"\x0eStartImportJob\x12 .employee.ImportEmployeesRequest\x1a\x1d.google.longrunning.Operation(\x01\x12m\n" +
+	"\x0eStartExportJob\x12 .employee.ExportEmployeesRequest\x1a\x1d.google.longrunning.Operation\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/jobs:export\x12g\n" +
+	"\x0fStartReindexJob\x12\x18.employee.ReindexRequest\x1a\x1d.google.longrunning.Operation\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/jobs:reindex\x12C\n" +
+	"\x0fDownloadJobFile\x12\x18.employee.JobFileRequest\x1a\x14.google.api.HttpBody0\x01B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_jobs_proto_rawDescOnce sync.Once
+	file_jobs_proto_rawDescData []byte
+)
+
+func file_jobs_proto_rawDescGZIP() []byte {
+	file_jobs_proto_rawDescOnce.Do(func() {
+		file_jobs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_jobs_proto_rawDesc), len(file_jobs_proto_rawDesc)))
+	})
+	return file_jobs_proto_rawDescData
+}
+
+var file_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_jobs_proto_goTypes = []any{
+	(JobState)(0),                   // 0: employee.JobState
+	(*JobMetadata)(nil),             // 1: employee.JobMetadata
+	(*ExportJobResult)(nil),         // 2: employee.ExportJobResult
+	(*ReindexRequest)(nil),          // 3: employee.ReindexRequest
+	(*ReindexResult)(nil),           // 4: employee.ReindexResult
+	(*JobFileRequest)(nil),          // 5: employee.JobFileRequest
+	(*timestamppb.Timestamp)(nil),   // 6: google.protobuf.Timestamp
+	(*ImportEmployeesRequest)(nil),  // 7: employee.ImportEmployeesRequest
+	(*ExportEmployeesRequest)(nil),  // 8: employee.ExportEmployeesRequest
+	(*longrunningpb.Operation)(nil), // 9: google.longrunning.Operation
+	(*httpbody.HttpBody)(nil),       // 10: google.api.HttpBody
+}
+var file_jobs_proto_depIdxs = []int32{
+	0,  // 0: employee.JobMetadata.state:type_name -> employee.JobState
+	6,  // 1: employee.JobMetadata.create_time:type_name -> google.protobuf.Timestamp
+	6,  // 2: employee.JobMetadata.start_time:type_name -> google.protobuf.Timestamp
+	6,  // 3: employee.JobMetadata.update_time:type_name -> google.protobuf.Timestamp
+	6,  // 4: employee.JobMetadata.end_time:type_name -> google.protobuf.Timestamp
+	7,  // 5: employee.JobService.StartImportJob:input_type -> employee.ImportEmployeesRequest
+	8,  // 6: employee.JobService.StartExportJob:input_type -> employee.ExportEmployeesRequest
+	3,  // 7: employee.JobService.StartReindexJob:input_type -> employee.ReindexRequest
+	5,  // 8: employee.JobService.DownloadJobFile:input_type -> employee.JobFileRequest
+	9,  // 9: employee.JobService.StartImportJob:output_type -> google.longrunning.Operation
+	9,  // 10: employee.JobService.StartExportJob:output_type -> google.longrunning.Operation
+	9,  // 11: employee.JobService.StartReindexJob:output_type -> google.longrunning.Operation
+	10, // 12: employee.JobService.DownloadJobFile:output_type -> google.api.HttpBody
+	9,  // [9:13] is the sub-list for method output_type
+	5,  // [5:9] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_jobs_proto_init() }
+func file_jobs_proto_init() {
+	if File_jobs_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jobs_proto_rawDesc), len(file_jobs_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_jobs_proto_goTypes,
+		DependencyIndexes: file_jobs_proto_depIdxs,
+		EnumInfos:         file_jobs_proto_enumTypes,
+		MessageInfos:      file_jobs_proto_msgTypes,
+	}.Build()
+	File_jobs_proto = out.File
+	file_jobs_proto_goTypes = nil
+	file_jobs_proto_depIdxs = nil
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: jobs.proto
+
+package employee
+
+import (
+	longrunningpb "cloud.google.com/go/longrunning/autogen/longrunningpb"
+	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JobService_StartImportJob_FullMethodName  = "/employee.JobService/StartImportJob"
+	JobService_StartExportJob_FullMethodName  = "/employee.JobService/StartExportJob"
+	JobService_StartReindexJob_FullMethodName = "/employee.JobService/StartReindexJob"
+	JobService_DownloadJobFile_FullMethodName = "/employee.JobService/DownloadJobFile"
+)
+
+// JobServiceClient is the client API for JobService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JobService starts jobs that run in the background. Each start returns a
+// google.longrunning.Operation, which is followed, cancelled and deleted
+// through the Operations service at /v1/operations. The operation metadata
+// is a JobMetadata; once done, the response is the job's result. Jobs are
+// stored, so they carry on after a server restart: imports and reindexes
+// resume where they stopped, exports start over, and a job interrupted too
+// often fails.
+type JobServiceClient interface {
+	// Import a CSV or XLSX file in the background, with the same messages as
+	// ImportEmployees. Over REST this is a multipart POST /v1/jobs:import.
+	// The response is an ImportReport that lists the failed rows only; an
+	// annotated XLSX workbook becomes the job file.
+	StartImportJob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEmployeesRequest, longrunningpb.Operation], error)
+	// Write an export file in the background. The response is an
+	// ExportJobResult and the file is the job file.
+	StartExportJob(ctx context.Context, in *ExportEmployeesRequest, opts ...grpc.CallOption) (*longrunningpb.Operation, error)
+	// Bring the data copied between records up to date: the department names
+	// and job titles stored on employees, and the search index. The response
+	// is a ReindexResult.
+	StartReindexJob(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*longrunningpb.Operation, error)
+	// Download the file a job produced, in chunks; the first chunk carries
+	// the content type. Over REST this is GET /v1/jobs/{id}/file, with the
+	// operation ID.
+	DownloadJobFile(ctx context.Context, in *JobFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
+}
+
+type jobServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobServiceClient(cc grpc.ClientConnInterface) JobServiceClient {
+	return &jobServiceClient{cc}
+}
+
+func (c *jobServiceClient) StartImportJob(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEmployeesRequest, longrunningpb.Operation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[0], JobService_StartImportJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportEmployeesRequest, longrunningpb.Operation]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_StartImportJobClient = grpc.ClientStreamingClient[ImportEmployeesRequest, longrunningpb.Operation]
+
+func (c *jobServiceClient) StartExportJob(ctx context.Context, in *ExportEmployeesRequest, opts ...grpc.CallOption) (*longrunningpb.Operation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(longrunningpb.Operation)
+	err := c.cc.Invoke(ctx, JobService_StartExportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) StartReindexJob(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*longrunningpb.Operation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(longrunningpb.Operation)
+	err := c.cc.Invoke(ctx, JobService_StartReindexJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DownloadJobFile(ctx context.Context, in *JobFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[1], JobService_DownloadJobFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobFileRequest, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_DownloadJobFileClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
+// JobServiceServer is the server API for JobService service.
+// All implementations must embed UnimplementedJobServiceServer
+// for forward compatibility.
+//
+// JobService starts jobs that run in the background. Each start returns a
+// google.longrunning.Operation, which is followed, cancelled and deleted
+// through the Operations service at /v1/operations. The operation metadata
+// is a JobMetadata; once done, the response is the job's result. Jobs are
+// stored, so they carry on after a server restart: imports and reindexes
+// resume where they stopped, exports start over, and a job interrupted too
+// often fails.
+type JobServiceServer interface {
+	// Import a CSV or XLSX file in the background, with the same messages as
+	// ImportEmployees. Over REST this is a multipart POST /v1/jobs:import.
+	// The response is an ImportReport that lists the failed rows only; an
+	// annotated XLSX workbook becomes the job file.
+	StartImportJob(grpc.ClientStreamingServer[ImportEmployeesRequest, longrunningpb.Operation]) error
+	// Write an export file in the background. The response is an
+	// ExportJobResult and the file is the job file.
+	StartExportJob(context.Context, *ExportEmployeesRequest) (*longrunningpb.Operation, error)
+	// Bring the data copied between records up to date: the department names
+	// and job titles stored on employees, and the search index. The response
+	// is a ReindexResult.
+	StartReindexJob(context.Context, *ReindexRequest) (*longrunningpb.Operation, error)
+	// Download the file a job produced, in chunks; the first chunk carries
+	// the content type. Over REST this is GET /v1/jobs/{id}/file, with the
+	// operation ID.
+	DownloadJobFile(*JobFileRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
+	mustEmbedUnimplementedJobServiceServer()
+}
+
+// UnimplementedJobServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJobServiceServer struct{}
+
+func (UnimplementedJobServiceServer) StartImportJob(grpc.ClientStreamingServer[ImportEmployeesRequest, longrunningpb.Operation]) error {
+	return status.Errorf(codes.Unimplemented, "method StartImportJob not implemented")
+}
+func (UnimplementedJobServiceServer) StartExportJob(context.Context, *ExportEmployeesRequest) (*longrunningpb.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExportJob not implemented")
+}
+func (UnimplementedJobServiceServer) StartReindexJob(context.Context, *ReindexRequest) (*longrunningpb.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReindexJob not implemented")
+}
+func (UnimplementedJobServiceServer) DownloadJobFile(*JobFileRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadJobFile not implemented")
+}
+func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
+func (UnimplementedJobServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeJobServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobServiceServer will
+// result in compilation errors.
+type UnsafeJobServiceServer interface {
+	mustEmbedUnimplementedJobServiceServer()
+}
+
+func RegisterJobServiceServer(s grpc.ServiceRegistrar, srv JobServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJobServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JobService_ServiceDesc, srv)
+}
+
+func _JobService_StartImportJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).StartImportJob(&grpc.GenericServerStream[ImportEmployeesRequest, longrunningpb.Operation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_StartImportJobServer = grpc.ClientStreamingServer[ImportEmployeesRequest, longrunningpb.Operation]
+
+func _JobService_StartExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEmployeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).StartExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_StartExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).StartExportJob(ctx, req.(*ExportEmployeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_StartReindexJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).StartReindexJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_StartReindexJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).StartReindexJob(ctx, req.(*ReindexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DownloadJobFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JobFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).DownloadJobFile(m, &grpc.GenericServerStream[JobFileRequest, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobService_DownloadJobFileServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
+// JobService_ServiceDesc is the grpc.ServiceDesc for JobService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JobService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.JobService",
+	HandlerType: (*JobServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartExportJob",
+			Handler:    _JobService_StartExportJob_Handler,
+		},
+		{
+			MethodName: "StartReindexJob",
+			Handler:    _JobService_StartReindexJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StartImportJob",
+			Handler:       _JobService_StartImportJob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadJobFile",
+			Handler:       _JobService_DownloadJobFile_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jobs.proto",
+}
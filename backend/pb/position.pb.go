@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: position.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PositionID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionID) Reset() {
+	*x = PositionID{}
+	mi := &file_position_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionID) ProtoMessage() {}
+
+func (x *PositionID) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionID.ProtoReflect.Descriptor instead.
+func (*PositionID) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PositionID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Position struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Job title, e.g. Senior Software Engineer
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Job family, e.g. Engineering or Sales
+	JobFamily string `protobuf:"bytes,3,opt,name=job_family,json=jobFamily,proto3" json:"job_family,omitempty"`
+	// Numeric level within the family, higher is more senior
+	Level int32 `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	// Grade label used by HR, e.g. P3 or M1
+	Grade         string      `protobuf:"bytes,5,opt,name=grade,proto3" json:"grade,omitempty"`
+	SalaryBand    *SalaryBand `protobuf:"bytes,6,opt,name=salary_band,json=salaryBand,proto3" json:"salary_band,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_position_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Position) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Position) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Position) GetJobFamily() string {
+	if x != nil {
+		return x.JobFamily
+	}
+	return ""
+}
+
+func (x *Position) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Position) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *Position) GetSalaryBand() *SalaryBand {
+	if x != nil {
+		return x.SalaryBand
+	}
+	return nil
+}
+
+// Yearly salary range in whole units of currency
+type SalaryBand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Min   int64                  `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max   int64                  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	// ISO 4217 code, e.g. EUR
+	Currency      string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SalaryBand) Reset() {
+	*x = SalaryBand{}
+	mi := &file_position_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalaryBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalaryBand) ProtoMessage() {}
+
+func (x *SalaryBand) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalaryBand.ProtoReflect.Descriptor instead.
+func (*SalaryBand) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SalaryBand) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *SalaryBand) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *SalaryBand) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PositionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positions     []*Position            `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionList) Reset() {
+	*x = PositionList{}
+	mi := &file_position_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionList) ProtoMessage() {}
+
+func (x *PositionList) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionList.ProtoReflect.Descriptor instead.
+func (*PositionList) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PositionList) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type PositionHeadcountReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*PositionHeadcount   `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionHeadcountReport) Reset() {
+	*x = PositionHeadcountReport{}
+	mi := &file_position_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionHeadcountReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionHeadcountReport) ProtoMessage() {}
+
+func (x *PositionHeadcountReport) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionHeadcountReport.ProtoReflect.Descriptor instead.
+func (*PositionHeadcountReport) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PositionHeadcountReport) GetRows() []*PositionHeadcount {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Employees without a position are reported with an empty job family
+type PositionHeadcount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobFamily     string                 `protobuf:"bytes,1,opt,name=job_family,json=jobFamily,proto3" json:"job_family,omitempty"`
+	Level         int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Headcount     int64                  `protobuf:"varint,3,opt,name=headcount,proto3" json:"headcount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionHeadcount) Reset() {
+	*x = PositionHeadcount{}
+	mi := &file_position_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionHeadcount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionHeadcount) ProtoMessage() {}
+
+func (x *PositionHeadcount) ProtoReflect() protoreflect.Message {
+	mi := &file_position_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionHeadcount.ProtoReflect.Descriptor instead.
+func (*PositionHeadcount) Descriptor() ([]byte, []int) {
+	return file_position_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PositionHeadcount) GetJobFamily() string {
+	if x != nil {
+		return x.JobFamily
+	}
+	return ""
+}
+
+func (x *PositionHeadcount) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *PositionHeadcount) GetHeadcount() int64 {
+	if x != nil {
+		return x.Headcount
+	}
+	return 0
+}
+
+var File_position_proto protoreflect.FileDescriptor
+
+const file_position_proto_rawDesc = "" +
+	"\n" +
+	"\x0eposition.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x0eemployee.proto\"\x1c\n" +
+	"\n" +
+	"PositionID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb2\x01\n" +
+	"\bPosition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"job_family\x18\x03 \x01(\tR\tjobFamily\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x05R\x05level\x12\x14\n" +
+	"\x05grade\x18\x05 \x01(\tR\x05grade\x125\n" +
+	"\vsalary_band\x18\x06 \x01(\v2\x14.employee.SalaryBandR\n" +
+	"salaryBand\"L\n" +
+	"\n" +
+	"SalaryBand\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x03R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x03R\x03max\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"@\n" +
+	"\fPositionList\x120\n" +
+	"\tpositions\x18\x01 \x03(\v2\x12.employee.PositionR\tpositions\"J\n" +
+	"\x17PositionHeadcountReport\x12/\n" +
+	"\x04rows\x18\x01 \x03(\v2\x1b.employee.PositionHeadcountR\x04rows\"f\n" +
+	"\x11PositionHeadcount\x12\x1d\n" +
+	"\n" +
+	"job_family\x18\x01 \x01(\tR\tjobFamily\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x12\x1c\n" +
+	"\theadcount\x18\x03 \x01(\x03R\theadcount2\xa6\x04\n" +
+	"\x0fPositionService\x12O\n" +
+	"\rListPositions\x12\x0f.employee.Empty\x1a\x16.employee.PositionList\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/positions\x12S\n" +
+	"\vGetPosition\x12\x14.employee.PositionID\x1a\x12.employee.Position\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/positions/{id}\x12R\n" +
+	"\x0eCreatePosition\x12\x12.employee.Position\x1a\x12.employee.Position\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/positions\x12W\n" +
+	"\x0eUpdatePosition\x12\x12.employee.Position\x1a\x12.employee.Position\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/positions/{id}\x12S\n" +
+	"\x0eDeletePosition\x12\x14.employee.PositionID\x1a\x0f.employee.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/positions/{id}\x12k\n" +
+	"\x14GetPositionHeadcount\x12\x0f.employee.Empty\x1a!.employee.PositionHeadcountReport\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/positions:headcountB\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_position_proto_rawDescOnce sync.Once
+	file_position_proto_rawDescData []byte
+)
+
+func file_position_proto_rawDescGZIP() []byte {
+	file_position_proto_rawDescOnce.Do(func() {
+		file_position_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_position_proto_rawDesc), len(file_position_proto_rawDesc)))
+	})
+	return file_position_proto_rawDescData
+}
+
+var file_position_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_position_proto_goTypes = []any{
+	(*PositionID)(nil),              // 0: employee.PositionID
+	(*Position)(nil),                // 1: employee.Position
+	(*SalaryBand)(nil),              // 2: employee.SalaryBand
+	(*PositionList)(nil),            // 3: employee.PositionList
+	(*PositionHeadcountReport)(nil), // 4: employee.PositionHeadcountReport
+	(*PositionHeadcount)(nil),       // 5: employee.PositionHeadcount
+	(*Empty)(nil),                   // 6: employee.Empty
+}
+var file_position_proto_depIdxs = []int32{
+	2, // 0: employee.Position.salary_band:type_name -> employee.SalaryBand
+	1, // 1: employee.PositionList.positions:type_name -> employee.Position
+	5, // 2: employee.PositionHeadcountReport.rows:type_name -> employee.PositionHeadcount
+	6, // 3: employee.PositionService.ListPositions:input_type -> employee.Empty
+	0, // 4: employee.PositionService.GetPosition:input_type -> employee.PositionID
+	1, // 5: employee.PositionService.CreatePosition:input_type -> employee.Position
+	1, // 6: employee.PositionService.UpdatePosition:input_type -> employee.Position
+	0, // 7: employee.PositionService.DeletePosition:input_type -> employee.PositionID
+	6, // 8: employee.PositionService.GetPositionHeadcount:input_type -> employee.Empty
+	3, // 9: employee.PositionService.ListPositions:output_type -> employee.PositionList
+	1, // 10: employee.PositionService.GetPosition:output_type -> employee.Position
+	1, // 11: employee.PositionService.CreatePosition:output_type -> employee.Position
+	1, // 12: employee.PositionService.UpdatePosition:output_type -> employee.Position
+	6, // 13: employee.PositionService.DeletePosition:output_type -> employee.Empty
+	4, // 14: employee.PositionService.GetPositionHeadcount:output_type -> employee.PositionHeadcountReport
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_position_proto_init() }
+func file_position_proto_init() {
+	if File_position_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_position_proto_rawDesc), len(file_position_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_position_proto_goTypes,
+		DependencyIndexes: file_position_proto_depIdxs,
+		MessageInfos:      file_position_proto_msgTypes,
+	}.Build()
+	File_position_proto = out.File
+	file_position_proto_goTypes = nil
+	file_position_proto_depIdxs = nil
+}
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: position.proto
+
+package employee
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PositionService_ListPositions_FullMethodName        = "/employee.PositionService/ListPositions"
+	PositionService_GetPosition_FullMethodName          = "/employee.PositionService/GetPosition"
+	PositionService_CreatePosition_FullMethodName       = "/employee.PositionService/CreatePosition"
+	PositionService_UpdatePosition_FullMethodName       = "/employee.PositionService/UpdatePosition"
+	PositionService_DeletePosition_FullMethodName       = "/employee.PositionService/DeletePosition"
+	PositionService_GetPositionHeadcount_FullMethodName = "/employee.PositionService/GetPositionHeadcount"
+)
+
+// PositionServiceClient is the client API for PositionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PositionServiceClient interface {
+	ListPositions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PositionList, error)
+	GetPosition(ctx context.Context, in *PositionID, opts ...grpc.CallOption) (*Position, error)
+	CreatePosition(ctx context.Context, in *Position, opts ...grpc.CallOption) (*Position, error)
+	UpdatePosition(ctx context.Context, in *Position, opts ...grpc.CallOption) (*Position, error)
+	DeletePosition(ctx context.Context, in *PositionID, opts ...grpc.CallOption) (*Empty, error)
+	// Headcount per job family and level
+	GetPositionHeadcount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PositionHeadcountReport, error)
+}
+
+type positionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPositionServiceClient(cc grpc.ClientConnInterface) PositionServiceClient {
+	return &positionServiceClient{cc}
+}
+
+func (c *positionServiceClient) ListPositions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PositionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PositionList)
+	err := c.cc.Invoke(ctx, PositionService_ListPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) GetPosition(ctx context.Context, in *PositionID, opts ...grpc.CallOption) (*Position, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Position)
+	err := c.cc.Invoke(ctx, PositionService_GetPosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) CreatePosition(ctx context.Context, in *Position, opts ...grpc.CallOption) (*Position, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Position)
+	err := c.cc.Invoke(ctx, PositionService_CreatePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) UpdatePosition(ctx context.Context, in *Position, opts ...grpc.CallOption) (*Position, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Position)
+	err := c.cc.Invoke(ctx, PositionService_UpdatePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) DeletePosition(ctx context.Context, in *PositionID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PositionService_DeletePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *positionServiceClient) GetPositionHeadcount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PositionHeadcountReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PositionHeadcountReport)
+	err := c.cc.Invoke(ctx, PositionService_GetPositionHeadcount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PositionServiceServer is the server API for PositionService service.
+// All implementations must embed UnimplementedPositionServiceServer
+// for forward compatibility.
+type PositionServiceServer interface {
+	ListPositions(context.Context, *Empty) (*PositionList, error)
+	GetPosition(context.Context, *PositionID) (*Position, error)
+	CreatePosition(context.Context, *Position) (*Position, error)
+	UpdatePosition(context.Context, *Position) (*Position, error)
+	DeletePosition(context.Context, *PositionID) (*Empty, error)
+	// Headcount per job family and level
+	GetPositionHeadcount(context.Context, *Empty) (*PositionHeadcountReport, error)
+	mustEmbedUnimplementedPositionServiceServer()
+}
+
+// UnimplementedPositionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPositionServiceServer struct{}
+
+func (UnimplementedPositionServiceServer) ListPositions(context.Context, *Empty) (*PositionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPositions not implemented")
+}
+func (UnimplementedPositionServiceServer) GetPosition(context.Context, *PositionID) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosition not implemented")
+}
+func (UnimplementedPositionServiceServer) CreatePosition(context.Context, *Position) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) UpdatePosition(context.Context, *Position) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) DeletePosition(context.Context, *PositionID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePosition not implemented")
+}
+func (UnimplementedPositionServiceServer) GetPositionHeadcount(context.Context, *Empty) (*PositionHeadcountReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPositionHeadcount not implemented")
+}
+func (UnimplementedPositionServiceServer) mustEmbedUnimplementedPositionServiceServer() {}
+func (UnimplementedPositionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafePositionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PositionServiceServer will
+// result in compilation errors.
+type UnsafePositionServiceServer interface {
+	mustEmbedUnimplementedPositionServiceServer()
+}
+
+func RegisterPositionServiceServer(s grpc.ServiceRegistrar, srv PositionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPositionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PositionService_ServiceDesc, srv)
+}
+
+func _PositionService_ListPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).ListPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_ListPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).ListPositions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_GetPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).GetPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_GetPosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).GetPosition(ctx, req.(*PositionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_CreatePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Position)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).CreatePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_CreatePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).CreatePosition(ctx, req.(*Position))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_UpdatePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Position)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).UpdatePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_UpdatePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).UpdatePosition(ctx, req.(*Position))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_DeletePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).DeletePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_DeletePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).DeletePosition(ctx, req.(*PositionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PositionService_GetPositionHeadcount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PositionServiceServer).GetPositionHeadcount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PositionService_GetPositionHeadcount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PositionServiceServer).GetPositionHeadcount(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PositionService_ServiceDesc is the grpc.ServiceDesc for PositionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PositionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.PositionService",
+	HandlerType: (*PositionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPositions",
+			Handler:    _PositionService_ListPositions_Handler,
+		},
+		{
+			MethodName: "GetPosition",
+			Handler:    _PositionService_GetPosition_Handler,
+		},
+		{
+			MethodName: "CreatePosition",
+			Handler:    _PositionService_CreatePosition_Handler,
+		},
+		{
+			MethodName: "UpdatePosition",
+			Handler:    _PositionService_UpdatePosition_Handler,
+		},
+		{
+			MethodName: "DeletePosition",
+			Handler:    _PositionService_DeletePosition_Handler,
+		},
+		{
+			MethodName: "GetPositionHeadcount",
+			Handler:    _PositionService_GetPositionHeadcount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "position.proto",
+}
@@ -0,0 +1,389 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: scim.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ScimListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SCIM filter expression, e.g. userName eq "ada@example.com"
+	Filter string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 1-based index of the first result
+	StartIndex int32 `protobuf:"varint,2,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	// Results per page, 100 by default and at most 200
+	Count *int32 `protobuf:"varint,3,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// Comma-separated attributes to return, or to leave out
+	Attributes         string `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	ExcludedAttributes string `protobuf:"bytes,5,opt,name=excluded_attributes,json=excludedAttributes,proto3" json:"excluded_attributes,omitempty"`
+	SortBy             string `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// ascending (default) or descending
+	SortOrder     string `protobuf:"bytes,7,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScimListRequest) Reset() {
+	*x = ScimListRequest{}
+	mi := &file_scim_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScimListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScimListRequest) ProtoMessage() {}
+
+func (x *ScimListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scim_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScimListRequest.ProtoReflect.Descriptor instead.
+func (*ScimListRequest) Descriptor() ([]byte, []int) {
+	return file_scim_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScimListRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ScimListRequest) GetStartIndex() int32 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *ScimListRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ScimListRequest) GetAttributes() string {
+	if x != nil {
+		return x.Attributes
+	}
+	return ""
+}
+
+func (x *ScimListRequest) GetExcludedAttributes() string {
+	if x != nil {
+		return x.ExcludedAttributes
+	}
+	return ""
+}
+
+func (x *ScimListRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ScimListRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+type ScimGetRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attributes         string                 `protobuf:"bytes,2,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	ExcludedAttributes string                 `protobuf:"bytes,3,opt,name=excluded_attributes,json=excludedAttributes,proto3" json:"excluded_attributes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ScimGetRequest) Reset() {
+	*x = ScimGetRequest{}
+	mi := &file_scim_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScimGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScimGetRequest) ProtoMessage() {}
+
+func (x *ScimGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scim_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScimGetRequest.ProtoReflect.Descriptor instead.
+func (*ScimGetRequest) Descriptor() ([]byte, []int) {
+	return file_scim_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScimGetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScimGetRequest) GetAttributes() string {
+	if x != nil {
+		return x.Attributes
+	}
+	return ""
+}
+
+func (x *ScimGetRequest) GetExcludedAttributes() string {
+	if x != nil {
+		return x.ExcludedAttributes
+	}
+	return ""
+}
+
+type ScimResourceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty on create
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The SCIM resource, or a PatchOp message on patch
+	Resource           *structpb.Struct `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Attributes         string           `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	ExcludedAttributes string           `protobuf:"bytes,4,opt,name=excluded_attributes,json=excludedAttributes,proto3" json:"excluded_attributes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ScimResourceRequest) Reset() {
+	*x = ScimResourceRequest{}
+	mi := &file_scim_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScimResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScimResourceRequest) ProtoMessage() {}
+
+func (x *ScimResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scim_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScimResourceRequest.ProtoReflect.Descriptor instead.
+func (*ScimResourceRequest) Descriptor() ([]byte, []int) {
+	return file_scim_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScimResourceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScimResourceRequest) GetResource() *structpb.Struct {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *ScimResourceRequest) GetAttributes() string {
+	if x != nil {
+		return x.Attributes
+	}
+	return ""
+}
+
+func (x *ScimResourceRequest) GetExcludedAttributes() string {
+	if x != nil {
+		return x.ExcludedAttributes
+	}
+	return ""
+}
+
+var File_scim_proto protoreflect.FileDescriptor
+
+const file_scim_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"scim.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x0eemployee.proto\"\xf8\x01\n" +
+	"\x0fScimListRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12\x1f\n" +
+	"\vstart_index\x18\x02 \x01(\x05R\n" +
+	"startIndex\x12\x19\n" +
+	"\x05count\x18\x03 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\tR\n" +
+	"attributes\x12/\n" +
+	"\x13excluded_attributes\x18\x05 \x01(\tR\x12excludedAttributes\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\a \x01(\tR\tsortOrderB\b\n" +
+	"\x06_count\"q\n" +
+	"\x0eScimGetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x02 \x01(\tR\n" +
+	"attributes\x12/\n" +
+	"\x13excluded_attributes\x18\x03 \x01(\tR\x12excludedAttributes\"\xab\x01\n" +
+	"\x13ScimResourceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\bresource\x18\x02 \x01(\v2\x17.google.protobuf.StructR\bresource\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\tR\n" +
+	"attributes\x12/\n" +
+	"\x13excluded_attributes\x18\x04 \x01(\tR\x12excludedAttributes2\xd1\v\n" +
+	"\vScimService\x12l\n" +
+	"\x18GetServiceProviderConfig\x12\x0f.employee.Empty\x1a\x17.google.protobuf.Struct\"&\x82\xd3\xe4\x93\x02 \x12\x1e/scim/v2/ServiceProviderConfig\x12]\n" +
+	"\x11ListResourceTypes\x12\x0f.employee.Empty\x1a\x17.google.protobuf.Struct\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/scim/v2/ResourceTypes\x12Q\n" +
+	"\vListSchemas\x12\x0f.employee.Empty\x1a\x17.google.protobuf.Struct\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/scim/v2/Schemas\x12W\n" +
+	"\tListUsers\x12\x19.employee.ScimListRequest\x1a\x17.google.protobuf.Struct\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/scim/v2/Users\x12Y\n" +
+	"\aGetUser\x12\x18.employee.ScimGetRequest\x1a\x17.google.protobuf.Struct\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/scim/v2/Users/{id}\x12f\n" +
+	"\n" +
+	"CreateUser\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\" \x82\xd3\xe4\x93\x02\x1a:\bresource\"\x0e/scim/v2/Users\x12l\n" +
+	"\vReplaceUser\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\"%\x82\xd3\xe4\x93\x02\x1f:\bresource\x1a\x13/scim/v2/Users/{id}\x12j\n" +
+	"\tPatchUser\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\"%\x82\xd3\xe4\x93\x02\x1f:\bresource2\x13/scim/v2/Users/{id}\x12T\n" +
+	"\n" +
+	"DeleteUser\x12\x18.employee.ScimGetRequest\x1a\x0f.employee.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/scim/v2/Users/{id}\x12Y\n" +
+	"\n" +
+	"ListGroups\x12\x19.employee.ScimListRequest\x1a\x17.google.protobuf.Struct\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/scim/v2/Groups\x12[\n" +
+	"\bGetGroup\x12\x18.employee.ScimGetRequest\x1a\x17.google.protobuf.Struct\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/scim/v2/Groups/{id}\x12h\n" +
+	"\vCreateGroup\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\"!\x82\xd3\xe4\x93\x02\x1b:\bresource\"\x0f/scim/v2/Groups\x12n\n" +
+	"\fReplaceGroup\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\"&\x82\xd3\xe4\x93\x02 :\bresource\x1a\x14/scim/v2/Groups/{id}\x12l\n" +
+	"\n" +
+	"PatchGroup\x12\x1d.employee.ScimResourceRequest\x1a\x17.google.protobuf.Struct\"&\x82\xd3\xe4\x93\x02 :\bresource2\x14/scim/v2/Groups/{id}\x12V\n" +
+	"\vDeleteGroup\x12\x18.employee.ScimGetRequest\x1a\x0f.employee.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/scim/v2/Groups/{id}B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_scim_proto_rawDescOnce sync.Once
+	file_scim_proto_rawDescData []byte
+)
+
+func file_scim_proto_rawDescGZIP() []byte {
+	file_scim_proto_rawDescOnce.Do(func() {
+		file_scim_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scim_proto_rawDesc), len(file_scim_proto_rawDesc)))
+	})
+	return file_scim_proto_rawDescData
+}
+
+var file_scim_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_scim_proto_goTypes = []any{
+	(*ScimListRequest)(nil),     // 0: employee.ScimListRequest
+	(*ScimGetRequest)(nil),      // 1: employee.ScimGetRequest
+	(*ScimResourceRequest)(nil), // 2: employee.ScimResourceRequest
+	(*structpb.Struct)(nil),     // 3: google.protobuf.Struct
+	(*Empty)(nil),               // 4: employee.Empty
+}
+var file_scim_proto_depIdxs = []int32{
+	3,  // 0: employee.ScimResourceRequest.resource:type_name -> google.protobuf.Struct
+	4,  // 1: employee.ScimService.GetServiceProviderConfig:input_type -> employee.Empty
+	4,  // 2: employee.ScimService.ListResourceTypes:input_type -> employee.Empty
+	4,  // 3: employee.ScimService.ListSchemas:input_type -> employee.Empty
+	0,  // 4: employee.ScimService.ListUsers:input_type -> employee.ScimListRequest
+	1,  // 5: employee.ScimService.GetUser:input_type -> employee.ScimGetRequest
+	2,  // 6: employee.ScimService.CreateUser:input_type -> employee.ScimResourceRequest
+	2,  // 7: employee.ScimService.ReplaceUser:input_type -> employee.ScimResourceRequest
+	2,  // 8: employee.ScimService.PatchUser:input_type -> employee.ScimResourceRequest
+	1,  // 9: employee.ScimService.DeleteUser:input_type -> employee.ScimGetRequest
+	0,  // 10: employee.ScimService.ListGroups:input_type -> employee.ScimListRequest
+	1,  // 11: employee.ScimService.GetGroup:input_type -> employee.ScimGetRequest
+	2,  // 12: employee.ScimService.CreateGroup:input_type -> employee.ScimResourceRequest
+	2,  // 13: employee.ScimService.ReplaceGroup:input_type -> employee.ScimResourceRequest
+	2,  // 14: employee.ScimService.PatchGroup:input_type -> employee.ScimResourceRequest
+	1,  // 15: employee.ScimService.DeleteGroup:input_type -> employee.ScimGetRequest
+	3,  // 16: employee.ScimService.GetServiceProviderConfig:output_type -> google.protobuf.Struct
+	3,  // 17: employee.ScimService.ListResourceTypes:output_type -> google.protobuf.Struct
+	3,  // 18: employee.ScimService.ListSchemas:output_type -> google.protobuf.Struct
+	3,  // 19: employee.ScimService.ListUsers:output_type -> google.protobuf.Struct
+	3,  // 20: employee.ScimService.GetUser:output_type -> google.protobuf.Struct
+	3,  // 21: employee.ScimService.CreateUser:output_type -> google.protobuf.Struct
+	3,  // 22: employee.ScimService.ReplaceUser:output_type -> google.protobuf.Struct
+	3,  // 23: employee.ScimService.PatchUser:output_type -> google.protobuf.Struct
+	4,  // 24: employee.ScimService.DeleteUser:output_type -> employee.Empty
+	3,  // 25: employee.ScimService.ListGroups:output_type -> google.protobuf.Struct
+	3,  // 26: employee.ScimService.GetGroup:output_type -> google.protobuf.Struct
+	3,  // 27: employee.ScimService.CreateGroup:output_type -> google.protobuf.Struct
+	3,  // 28: employee.ScimService.ReplaceGroup:output_type -> google.protobuf.Struct
+	3,  // 29: employee.ScimService.PatchGroup:output_type -> google.protobuf.Struct
+	4,  // 30: employee.ScimService.DeleteGroup:output_type -> employee.Empty
+	16, // [16:31] is the sub-list for method output_type
+	1,  // [1:16] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_scim_proto_init() }
+func file_scim_proto_init() {
+	if File_scim_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	file_scim_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scim_proto_rawDesc), len(file_scim_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scim_proto_goTypes,
+		DependencyIndexes: file_scim_proto_depIdxs,
+		MessageInfos:      file_scim_proto_msgTypes,
+	}.Build()
+	File_scim_proto = out.File
+	file_scim_proto_goTypes = nil
+	file_scim_proto_depIdxs = nil
+}
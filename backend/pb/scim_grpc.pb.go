@@ -0,0 +1,688 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: scim.proto
+
+package employee
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScimService_GetServiceProviderConfig_FullMethodName = "/employee.ScimService/GetServiceProviderConfig"
+	ScimService_ListResourceTypes_FullMethodName        = "/employee.ScimService/ListResourceTypes"
+	ScimService_ListSchemas_FullMethodName              = "/employee.ScimService/ListSchemas"
+	ScimService_ListUsers_FullMethodName                = "/employee.ScimService/ListUsers"
+	ScimService_GetUser_FullMethodName                  = "/employee.ScimService/GetUser"
+	ScimService_CreateUser_FullMethodName               = "/employee.ScimService/CreateUser"
+	ScimService_ReplaceUser_FullMethodName              = "/employee.ScimService/ReplaceUser"
+	ScimService_PatchUser_FullMethodName                = "/employee.ScimService/PatchUser"
+	ScimService_DeleteUser_FullMethodName               = "/employee.ScimService/DeleteUser"
+	ScimService_ListGroups_FullMethodName               = "/employee.ScimService/ListGroups"
+	ScimService_GetGroup_FullMethodName                 = "/employee.ScimService/GetGroup"
+	ScimService_CreateGroup_FullMethodName              = "/employee.ScimService/CreateGroup"
+	ScimService_ReplaceGroup_FullMethodName             = "/employee.ScimService/ReplaceGroup"
+	ScimService_PatchGroup_FullMethodName               = "/employee.ScimService/PatchGroup"
+	ScimService_DeleteGroup_FullMethodName              = "/employee.ScimService/DeleteGroup"
+)
+
+// ScimServiceClient is the client API for ScimService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScimService is a SCIM 2.0 (RFC 7643, RFC 7644) provisioning endpoint for
+// identity providers. Users are employees and Groups are departments; the
+// enterprise user extension carries the department and the manager.
+// Resources are plain SCIM JSON, hence the Struct messages. Requests are
+// served on the tenant of the bearer token, like every other service.
+type ScimServiceClient interface {
+	GetServiceProviderConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ListResourceTypes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ListSchemas(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ListUsers(ctx context.Context, in *ScimListRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	GetUser(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// userName is the employee's email. Users created inactive become
+	// candidates.
+	CreateUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Attributes left out of the resource keep their values; null clears
+	// them. Setting active to false suspends the employee and setting it back
+	// reinstates, rehires or hires them.
+	ReplaceUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Applies a PatchOp message
+	PatchUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	DeleteUser(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListGroups(ctx context.Context, in *ScimListRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	GetGroup(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// The department code is made from the displayName. Members are moved
+	// into the department, since an employee is in one department only.
+	CreateGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Employees no longer listed as members leave the department
+	ReplaceGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	PatchGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error)
+	// Fails like DeleteDepartment while the department has employees or
+	// sub-departments
+	DeleteGroup(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type scimServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScimServiceClient(cc grpc.ClientConnInterface) ScimServiceClient {
+	return &scimServiceClient{cc}
+}
+
+func (c *scimServiceClient) GetServiceProviderConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_GetServiceProviderConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ListResourceTypes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ListResourceTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ListSchemas(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ListSchemas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ListUsers(ctx context.Context, in *ScimListRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) GetUser(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) CreateUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ReplaceUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ReplaceUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) PatchUser(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_PatchUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) DeleteUser(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ScimService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ListGroups(ctx context.Context, in *ScimListRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ListGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) GetGroup(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_GetGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) CreateGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) ReplaceGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_ReplaceGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) PatchGroup(ctx context.Context, in *ScimResourceRequest, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, ScimService_PatchGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scimServiceClient) DeleteGroup(ctx context.Context, in *ScimGetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ScimService_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScimServiceServer is the server API for ScimService service.
+// All implementations must embed UnimplementedScimServiceServer
+// for forward compatibility.
+//
+// ScimService is a SCIM 2.0 (RFC 7643, RFC 7644) provisioning endpoint for
+// identity providers. Users are employees and Groups are departments; the
+// enterprise user extension carries the department and the manager.
+// Resources are plain SCIM JSON, hence the Struct messages. Requests are
+// served on the tenant of the bearer token, like every other service.
+type ScimServiceServer interface {
+	GetServiceProviderConfig(context.Context, *Empty) (*structpb.Struct, error)
+	ListResourceTypes(context.Context, *Empty) (*structpb.Struct, error)
+	ListSchemas(context.Context, *Empty) (*structpb.Struct, error)
+	ListUsers(context.Context, *ScimListRequest) (*structpb.Struct, error)
+	GetUser(context.Context, *ScimGetRequest) (*structpb.Struct, error)
+	// userName is the employee's email. Users created inactive become
+	// candidates.
+	CreateUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	// Attributes left out of the resource keep their values; null clears
+	// them. Setting active to false suspends the employee and setting it back
+	// reinstates, rehires or hires them.
+	ReplaceUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	// Applies a PatchOp message
+	PatchUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	DeleteUser(context.Context, *ScimGetRequest) (*Empty, error)
+	ListGroups(context.Context, *ScimListRequest) (*structpb.Struct, error)
+	GetGroup(context.Context, *ScimGetRequest) (*structpb.Struct, error)
+	// The department code is made from the displayName. Members are moved
+	// into the department, since an employee is in one department only.
+	CreateGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	// Employees no longer listed as members leave the department
+	ReplaceGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	PatchGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error)
+	// Fails like DeleteDepartment while the department has employees or
+	// sub-departments
+	DeleteGroup(context.Context, *ScimGetRequest) (*Empty, error)
+	mustEmbedUnimplementedScimServiceServer()
+}
+
+// UnimplementedScimServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScimServiceServer struct{}
+
+func (UnimplementedScimServiceServer) GetServiceProviderConfig(context.Context, *Empty) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceProviderConfig not implemented")
+}
+func (UnimplementedScimServiceServer) ListResourceTypes(context.Context, *Empty) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResourceTypes not implemented")
+}
+func (UnimplementedScimServiceServer) ListSchemas(context.Context, *Empty) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchemas not implemented")
+}
+func (UnimplementedScimServiceServer) ListUsers(context.Context, *ScimListRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedScimServiceServer) GetUser(context.Context, *ScimGetRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedScimServiceServer) CreateUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedScimServiceServer) ReplaceUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceUser not implemented")
+}
+func (UnimplementedScimServiceServer) PatchUser(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchUser not implemented")
+}
+func (UnimplementedScimServiceServer) DeleteUser(context.Context, *ScimGetRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedScimServiceServer) ListGroups(context.Context, *ScimListRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedScimServiceServer) GetGroup(context.Context, *ScimGetRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedScimServiceServer) CreateGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedScimServiceServer) ReplaceGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceGroup not implemented")
+}
+func (UnimplementedScimServiceServer) PatchGroup(context.Context, *ScimResourceRequest) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchGroup not implemented")
+}
+func (UnimplementedScimServiceServer) DeleteGroup(context.Context, *ScimGetRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedScimServiceServer) mustEmbedUnimplementedScimServiceServer() {}
+func (UnimplementedScimServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeScimServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScimServiceServer will
+// result in compilation errors.
+type UnsafeScimServiceServer interface {
+	mustEmbedUnimplementedScimServiceServer()
+}
+
+func RegisterScimServiceServer(s grpc.ServiceRegistrar, srv ScimServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScimServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScimService_ServiceDesc, srv)
+}
+
+func _ScimService_GetServiceProviderConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).GetServiceProviderConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_GetServiceProviderConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).GetServiceProviderConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ListResourceTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ListResourceTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ListResourceTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ListResourceTypes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ListSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ListSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ListSchemas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ListSchemas(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ListUsers(ctx, req.(*ScimListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).GetUser(ctx, req.(*ScimGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).CreateUser(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ReplaceUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ReplaceUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ReplaceUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ReplaceUser(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_PatchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).PatchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_PatchUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).PatchUser(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).DeleteUser(ctx, req.(*ScimGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ListGroups(ctx, req.(*ScimListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_GetGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).GetGroup(ctx, req.(*ScimGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).CreateGroup(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_ReplaceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).ReplaceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_ReplaceGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).ReplaceGroup(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_PatchGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).PatchGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_PatchGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).PatchGroup(ctx, req.(*ScimResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScimService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScimGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScimServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScimService_DeleteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScimServiceServer).DeleteGroup(ctx, req.(*ScimGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScimService_ServiceDesc is the grpc.ServiceDesc for ScimService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScimService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "employee.ScimService",
+	HandlerType: (*ScimServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServiceProviderConfig",
+			Handler:    _ScimService_GetServiceProviderConfig_Handler,
+		},
+		{
+			MethodName: "ListResourceTypes",
+			Handler:    _ScimService_ListResourceTypes_Handler,
+		},
+		{
+			MethodName: "ListSchemas",
+			Handler:    _ScimService_ListSchemas_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _ScimService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _ScimService_GetUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _ScimService_CreateUser_Handler,
+		},
+		{
+			MethodName: "ReplaceUser",
+			Handler:    _ScimService_ReplaceUser_Handler,
+		},
+		{
+			MethodName: "PatchUser",
+			Handler:    _ScimService_PatchUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _ScimService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _ScimService_ListGroups_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _ScimService_GetGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _ScimService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "ReplaceGroup",
+			Handler:    _ScimService_ReplaceGroup_Handler,
+		},
+		{
+			MethodName: "PatchGroup",
+			Handler:    _ScimService_PatchGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _ScimService_DeleteGroup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scim.proto",
+}
@@ -0,0 +1,420 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: tenant.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TenantID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantID) Reset() {
+	*x = TenantID{}
+	mi := &file_tenant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantID) ProtoMessage() {}
+
+func (x *TenantID) ProtoReflect() protoreflect.Message {
+	mi := &file_tenant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantID.ProtoReflect.Descriptor instead.
+func (*TenantID) Descriptor() ([]byte, []int) {
+	return file_tenant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TenantID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Tenant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lower case letters, digits and dashes, e.g. acme-uk. Fixed once created.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Read-only, the MongoDB database holding the tenant's data
+	Database string        `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Config   *TenantConfig `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	// Read-only
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_tenant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_tenant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_tenant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Tenant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *Tenant) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Tenant) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// Settings a tenant can change from the server defaults. Unset fields use
+// the server's configuration.
+type TenantConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Where the tenant's domain events are published, like EVENT_SINKS
+	EventSinks string `protobuf:"bytes,1,opt,name=event_sinks,json=eventSinks,proto3" json:"event_sinks,omitempty"`
+	// CloudEvents source attribute of the tenant's events
+	EventSource string `protobuf:"bytes,2,opt,name=event_source,json=eventSource,proto3" json:"event_source,omitempty"`
+	// Failed attempts before a webhook delivery is dead-lettered
+	WebhookMaxAttempts int32 `protobuf:"varint,3,opt,name=webhook_max_attempts,json=webhookMaxAttempts,proto3" json:"webhook_max_attempts,omitempty"`
+	// Jobs of the tenant run at the same time on each server
+	JobWorkers    int32 `protobuf:"varint,4,opt,name=job_workers,json=jobWorkers,proto3" json:"job_workers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantConfig) Reset() {
+	*x = TenantConfig{}
+	mi := &file_tenant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantConfig) ProtoMessage() {}
+
+func (x *TenantConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_tenant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantConfig.ProtoReflect.Descriptor instead.
+func (*TenantConfig) Descriptor() ([]byte, []int) {
+	return file_tenant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TenantConfig) GetEventSinks() string {
+	if x != nil {
+		return x.EventSinks
+	}
+	return ""
+}
+
+func (x *TenantConfig) GetEventSource() string {
+	if x != nil {
+		return x.EventSource
+	}
+	return ""
+}
+
+func (x *TenantConfig) GetWebhookMaxAttempts() int32 {
+	if x != nil {
+		return x.WebhookMaxAttempts
+	}
+	return 0
+}
+
+func (x *TenantConfig) GetJobWorkers() int32 {
+	if x != nil {
+		return x.JobWorkers
+	}
+	return 0
+}
+
+type TenantList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantList) Reset() {
+	*x = TenantList{}
+	mi := &file_tenant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantList) ProtoMessage() {}
+
+func (x *TenantList) ProtoReflect() protoreflect.Message {
+	mi := &file_tenant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantList.ProtoReflect.Descriptor instead.
+func (*TenantList) Descriptor() ([]byte, []int) {
+	return file_tenant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TenantList) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+type DeleteTenantRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Only stop serving the tenant and leave its database in place
+	KeepData      bool `protobuf:"varint,2,opt,name=keep_data,json=keepData,proto3" json:"keep_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	mi := &file_tenant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tenant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_tenant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTenantRequest) GetKeepData() bool {
+	if x != nil {
+		return x.KeepData
+	}
+	return false
+}
+
+var File_tenant_proto protoreflect.FileDescriptor
+
+const file_tenant_proto_rawDesc = "" +
+	"\n" +
+	"\ftenant.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eemployee.proto\"\x1a\n" +
+	"\bTenantID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb5\x01\n" +
+	"\x06Tenant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bdatabase\x18\x03 \x01(\tR\bdatabase\x12.\n" +
+	"\x06config\x18\x04 \x01(\v2\x16.employee.TenantConfigR\x06config\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\xa5\x01\n" +
+	"\fTenantConfig\x12\x1f\n" +
+	"\vevent_sinks\x18\x01 \x01(\tR\n" +
+	"eventSinks\x12!\n" +
+	"\fevent_source\x18\x02 \x01(\tR\veventSource\x120\n" +
+	"\x14webhook_max_attempts\x18\x03 \x01(\x05R\x12webhookMaxAttempts\x12\x1f\n" +
+	"\vjob_workers\x18\x04 \x01(\x05R\n" +
+	"jobWorkers\"8\n" +
+	"\n" +
+	"TenantList\x12*\n" +
+	"\atenants\x18\x01 \x03(\v2\x10.employee.TenantR\atenants\"B\n" +
+	"\x13DeleteTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tkeep_data\x18\x02 \x01(\bR\bkeepData2\x9e\x03\n" +
+	"\rTenantService\x12I\n" +
+	"\vListTenants\x12\x0f.employee.Empty\x1a\x14.employee.TenantList\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/tenants\x12K\n" +
+	"\tGetTenant\x12\x12.employee.TenantID\x1a\x10.employee.Tenant\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tenants/{id}\x12J\n" +
+	"\fCreateTenant\x12\x10.employee.Tenant\x1a\x10.employee.Tenant\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/tenants\x12O\n" +
+	"\fUpdateTenant\x12\x10.employee.Tenant\x1a\x10.employee.Tenant\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\x1a\x10/v1/tenants/{id}\x12X\n" +
+	"\fDeleteTenant\x12\x1d.employee.DeleteTenantRequest\x1a\x0f.employee.Empty\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/tenants/{id}B\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_tenant_proto_rawDescOnce sync.Once
+	file_tenant_proto_rawDescData []byte
+)
+
+func file_tenant_proto_rawDescGZIP() []byte {
+	file_tenant_proto_rawDescOnce.Do(func() {
+		file_tenant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tenant_proto_rawDesc), len(file_tenant_proto_rawDesc)))
+	})
+	return file_tenant_proto_rawDescData
+}
+
+var file_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_tenant_proto_goTypes = []any{
+	(*TenantID)(nil),              // 0: employee.TenantID
+	(*Tenant)(nil),                // 1: employee.Tenant
+	(*TenantConfig)(nil),          // 2: employee.TenantConfig
+	(*TenantList)(nil),            // 3: employee.TenantList
+	(*DeleteTenantRequest)(nil),   // 4: employee.DeleteTenantRequest
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*Empty)(nil),                 // 6: employee.Empty
+}
+var file_tenant_proto_depIdxs = []int32{
+	2, // 0: employee.Tenant.config:type_name -> employee.TenantConfig
+	5, // 1: employee.Tenant.create_time:type_name -> google.protobuf.Timestamp
+	1, // 2: employee.TenantList.tenants:type_name -> employee.Tenant
+	6, // 3: employee.TenantService.ListTenants:input_type -> employee.Empty
+	0, // 4: employee.TenantService.GetTenant:input_type -> employee.TenantID
+	1, // 5: employee.TenantService.CreateTenant:input_type -> employee.Tenant
+	1, // 6: employee.TenantService.UpdateTenant:input_type -> employee.Tenant
+	4, // 7: employee.TenantService.DeleteTenant:input_type -> employee.DeleteTenantRequest
+	3, // 8: employee.TenantService.ListTenants:output_type -> employee.TenantList
+	1, // 9: employee.TenantService.GetTenant:output_type -> employee.Tenant
+	1, // 10: employee.TenantService.CreateTenant:output_type -> employee.Tenant
+	1, // 11: employee.TenantService.UpdateTenant:output_type -> employee.Tenant
+	6, // 12: employee.TenantService.DeleteTenant:output_type -> employee.Empty
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_tenant_proto_init() }
+func file_tenant_proto_init() {
+	if File_tenant_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tenant_proto_rawDesc), len(file_tenant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tenant_proto_goTypes,
+		DependencyIndexes: file_tenant_proto_depIdxs,
+		MessageInfos:      file_tenant_proto_msgTypes,
+	}.Build()
+	File_tenant_proto = out.File
+	file_tenant_proto_goTypes = nil
+	file_tenant_proto_depIdxs = nil
+}
@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: webhook.proto
+
+package employee
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeliveryStatus int32
+
+const (
+	DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED DeliveryStatus = 0
+	// Waiting for its next attempt
+	DeliveryStatus_PENDING   DeliveryStatus = 1
+	DeliveryStatus_DELIVERED DeliveryStatus = 2
+	// Gave up after the maximum number of attempts
+	DeliveryStatus_DEAD_LETTER DeliveryStatus = 3
+)
+
+// Enum value maps for DeliveryStatus.
+var (
+	DeliveryStatus_name = map[int32]string{
+		0: "DELIVERY_STATUS_UNSPECIFIED",
+		1: "PENDING",
+		2: "DELIVERED",
+		3: "DEAD_LETTER",
+	}
+	DeliveryStatus_value = map[string]int32{
+		"DELIVERY_STATUS_UNSPECIFIED": 0,
+		"PENDING":                     1,
+		"DELIVERED":                   2,
+		"DEAD_LETTER":                 3,
+	}
+)
+
+func (x DeliveryStatus) Enum() *DeliveryStatus {
+	p := new(DeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x DeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_webhook_proto_enumTypes[0].Descriptor()
+}
+
+func (DeliveryStatus) Type() protoreflect.EnumType {
+	return &file_webhook_proto_enumTypes[0]
+}
+
+func (x DeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryStatus.Descriptor instead.
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+type WebhookID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookID) Reset() {
+	*x = WebhookID{}
+	mi := &file_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookID) ProtoMessage() {}
+
+func (x *WebhookID) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookID.ProtoReflect.Descriptor instead.
+func (*WebhookID) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebhookID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Event types to deliver, e.g. employee.created or employee.terminated.
+	// Empty means all of them.
+	EventTypes []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Secret     string   `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Disabled webhooks get no new deliveries, and queued ones are
+	// dead-lettered
+	Disabled      bool                   `protobuf:"varint,5,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Webhook) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WebhookList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookList) Reset() {
+	*x = WebhookList{}
+	mi := &file_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookList) ProtoMessage() {}
+
+func (x *WebhookList) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookList.ProtoReflect.Descriptor instead.
+func (*WebhookList) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WebhookList) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeliveryAttempt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// HTTP status returned by the endpoint, 0 if there was no response
+	StatusCode    int32  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	mi := &file_webhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeliveryAttempt) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status        DeliveryStatus         `protobuf:"varint,5,opt,name=status,proto3,enum=employee.DeliveryStatus" json:"status,omitempty"`
+	Attempts      []*DeliveryAttempt     `protobuf:"bytes,6,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	// The JSON body that is posted
+	Payload       string `protobuf:"bytes,10,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_webhook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() DeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() []*DeliveryAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Only deliveries with this status
+	Status DeliveryStatus `protobuf:"varint,2,opt,name=status,proto3,enum=employee.DeliveryStatus" json:"status,omitempty"`
+	// Defaults to 100, at most 1000
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_webhook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() DeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type WebhookDeliveryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryList) Reset() {
+	*x = WebhookDeliveryList{}
+	mi := &file_webhook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryList) ProtoMessage() {}
+
+func (x *WebhookDeliveryList) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryList.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryList) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WebhookDeliveryList) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *WebhookDeliveryList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	DeliveryId    string                 `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryRequest) Reset() {
+	*x = WebhookDeliveryRequest{}
+	mi := &file_webhook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *WebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webhook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_webhook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WebhookDeliveryRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+var File_webhook_proto protoreflect.FileDescriptor
+
+const file_webhook_proto_rawDesc = "" +
+	"\n" +
+	"\rwebhook.proto\x12\bemployee\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eemployee.proto\"\x1b\n" +
+	"\tWebhookID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdd\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12\x1a\n" +
+	"\bdisabled\x18\x05 \x01(\bR\bdisabled\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"<\n" +
+	"\vWebhookList\x12-\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x11.employee.WebhookR\bwebhooks\"\x99\x01\n" +
+	"\x0fDeliveryAttempt\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\"\xbb\x03\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x120\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x18.employee.DeliveryStatusR\x06status\x125\n" +
+	"\battempts\x18\x06 \x03(\v2\x19.employee.DeliveryAttemptR\battempts\x12B\n" +
+	"\x0fnext_attempt_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x12\x18\n" +
+	"\apayload\x18\n" +
+	" \x01(\tR\apayload\"\xab\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.employee.DeliveryStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"x\n" +
+	"\x13WebhookDeliveryList\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.employee.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"X\n" +
+	"\x16WebhookDeliveryRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId*^\n" +
+	"\x0eDeliveryStatus\x12\x1f\n" +
+	"\x1bDELIVERY_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\r\n" +
+	"\tDELIVERED\x10\x02\x12\x0f\n" +
+	"\vDEAD_LETTER\x10\x032\xe0\x06\n" +
+	"\x0eWebhookService\x12L\n" +
+	"\fListWebhooks\x12\x0f.employee.Empty\x1a\x15.employee.WebhookList\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12O\n" +
+	"\n" +
+	"GetWebhook\x12\x13.employee.WebhookID\x1a\x11.employee.Webhook\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/webhooks/{id}\x12N\n" +
+	"\rCreateWebhook\x12\x11.employee.Webhook\x1a\x11.employee.Webhook\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12S\n" +
+	"\rUpdateWebhook\x12\x11.employee.Webhook\x1a\x11.employee.Webhook\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\x1a\x11/v1/webhooks/{id}\x12P\n" +
+	"\rDeleteWebhook\x12\x13.employee.WebhookID\x1a\x0f.employee.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/webhooks/{id}\x12\x8c\x01\n" +
+	"\x15ListWebhookDeliveries\x12&.employee.ListWebhookDeliveriesRequest\x1a\x1d.employee.WebhookDeliveryList\",\x82\xd3\xe4\x93\x02&\x12$/v1/webhooks/{webhook_id}/deliveries\x12\x8d\x01\n" +
+	"\x12GetWebhookDelivery\x12 .employee.WebhookDeliveryRequest\x1a\x19.employee.WebhookDelivery\":\x82\xd3\xe4\x93\x024\x122/v1/webhooks/{webhook_id}/deliveries/{delivery_id}\x12\x98\x01\n" +
+	"\x14RetryWebhookDelivery\x12 .employee.WebhookDeliveryRequest\x1a\x19.employee.WebhookDelivery\"C\x82\xd3\xe4\x93\x02=:\x01*\"8/v1/webhooks/{webhook_id}/deliveries/{delivery_id}:retryB\"Z EMPLOYEE_APP/backend/pb;employeeb\x06proto3"
+
+var (
+	file_webhook_proto_rawDescOnce sync.Once
+	file_webhook_proto_rawDescData []byte
+)
+
+func file_webhook_proto_rawDescGZIP() []byte {
+	file_webhook_proto_rawDescOnce.Do(func() {
+		file_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_webhook_proto_rawDesc), len(file_webhook_proto_rawDesc)))
+	})
+	return file_webhook_proto_rawDescData
+}
+
+var file_webhook_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_webhook_proto_goTypes = []any{
+	(DeliveryStatus)(0),                  // 0: employee.DeliveryStatus
+	(*WebhookID)(nil),                    // 1: employee.WebhookID
+	(*Webhook)(nil),                      // 2: employee.Webhook
+	(*WebhookList)(nil),                  // 3: employee.WebhookList
+	(*DeliveryAttempt)(nil),              // 4: employee.DeliveryAttempt
+	(*WebhookDelivery)(nil),              // 5: employee.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil), // 6: employee.ListWebhookDeliveriesRequest
+	(*WebhookDeliveryList)(nil),          // 7: employee.WebhookDeliveryList
+	(*WebhookDeliveryRequest)(nil),       // 8: employee.WebhookDeliveryRequest
+	(*timestamppb.Timestamp)(nil),        // 9: google.protobuf.Timestamp
+	(*Empty)(nil),                        // 10: employee.Empty
+}
+var file_webhook_proto_depIdxs = []int32{
+	9,  // 0: employee.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: employee.WebhookList.webhooks:type_name -> employee.Webhook
+	9,  // 2: employee.DeliveryAttempt.time:type_name -> google.protobuf.Timestamp
+	0,  // 3: employee.WebhookDelivery.status:type_name -> employee.DeliveryStatus
+	4,  // 4: employee.WebhookDelivery.attempts:type_name -> employee.DeliveryAttempt
+	9,  // 5: employee.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: employee.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: employee.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: employee.ListWebhookDeliveriesRequest.status:type_name -> employee.DeliveryStatus
+	5,  // 9: employee.WebhookDeliveryList.deliveries:type_name -> employee.WebhookDelivery
+	10, // 10: employee.WebhookService.ListWebhooks:input_type -> employee.Empty
+	1,  // 11: employee.WebhookService.GetWebhook:input_type -> employee.WebhookID
+	2,  // 12: employee.WebhookService.CreateWebhook:input_type -> employee.Webhook
+	2,  // 13: employee.WebhookService.UpdateWebhook:input_type -> employee.Webhook
+	1,  // 14: employee.WebhookService.DeleteWebhook:input_type -> employee.WebhookID
+	6,  // 15: employee.WebhookService.ListWebhookDeliveries:input_type -> employee.ListWebhookDeliveriesRequest
+	8,  // 16: employee.WebhookService.GetWebhookDelivery:input_type -> employee.WebhookDeliveryRequest
+	8,  // 17: employee.WebhookService.RetryWebhookDelivery:input_type -> employee.WebhookDeliveryRequest
+	3,  // 18: employee.WebhookService.ListWebhooks:output_type -> employee.WebhookList
+	2,  // 19: employee.WebhookService.GetWebhook:output_type -> employee.Webhook
+	2,  // 20: employee.WebhookService.CreateWebhook:output_type -> employee.Webhook
+	2,  // 21: employee.WebhookService.UpdateWebhook:output_type -> employee.Webhook
+	10, // 22: employee.WebhookService.DeleteWebhook:output_type -> employee.Empty
+	7,  // 23: employee.WebhookService.ListWebhookDeliveries:output_type -> employee.WebhookDeliveryList
+	5,  // 24: employee.WebhookService.GetWebhookDelivery:output_type -> employee.WebhookDelivery
+	5,  // 25: employee.WebhookService.RetryWebhookDelivery:output_type -> employee.WebhookDelivery
+	18, // [18:26] is the sub-list for method output_type
+	10, // [10:18] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_webhook_proto_init() }
+func file_webhook_proto_init() {
+	if File_webhook_proto != nil {
+		return
+	}
+	file_employee_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_webhook_proto_rawDesc), len(file_webhook_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_webhook_proto_goTypes,
+		DependencyIndexes: file_webhook_proto_depIdxs,
+		EnumInfos:         file_webhook_proto_enumTypes,
+		MessageInfos:      file_webhook_proto_msgTypes,
+	}.Build()
+	File_webhook_proto = out.File
+	file_webhook_proto_goTypes = nil
+	file_webhook_proto_depIdxs = nil
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// MongoDB Position model
+type Position struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Title      string             `bson:"title"`
+	JobFamily  string             `bson:"job_family"`
+	Level      int32              `bson:"level"`
+	Grade      string             `bson:"grade"`
+	SalaryBand *SalaryBand        `bson:"salary_band,omitempty"`
+}
+
+type SalaryBand struct {
+	Min      int64  `bson:"min"`
+	Max      int64  `bson:"max"`
+	Currency string `bson:"currency"`
+}
+
+func (p Position) toProto() *pb.Position {
+	pos := &pb.Position{
+		Id:        p.ID.Hex(),
+		Title:     p.Title,
+		JobFamily: p.JobFamily,
+		Level:     p.Level,
+		Grade:     p.Grade,
+	}
+	if p.SalaryBand != nil {
+		pos.SalaryBand = &pb.SalaryBand{
+			Min:      p.SalaryBand.Min,
+			Max:      p.SalaryBand.Max,
+			Currency: p.SalaryBand.Currency,
+		}
+	}
+	return pos
+}
+
+// positionFromProto validates req and converts it to the stored model
+func positionFromProto(req *pb.Position) (Position, error) {
+	pos := Position{
+		Title:     strings.TrimSpace(req.GetTitle()),
+		JobFamily: strings.TrimSpace(req.GetJobFamily()),
+		Level:     req.GetLevel(),
+		Grade:     strings.TrimSpace(req.GetGrade()),
+	}
+	if pos.Title == "" {
+		return pos, status.Errorf(codes.InvalidArgument, "Position title is required")
+	}
+	if pos.JobFamily == "" {
+		return pos, status.Errorf(codes.InvalidArgument, "Job family is required")
+	}
+	if pos.Level < 0 {
+		return pos, status.Errorf(codes.InvalidArgument, "Level must not be negative")
+	}
+
+	if band := req.GetSalaryBand(); band != nil {
+		currency := strings.ToUpper(strings.TrimSpace(band.GetCurrency()))
+		if band.GetMin() < 0 || band.GetMax() < band.GetMin() {
+			return pos, status.Errorf(codes.InvalidArgument, "Salary band must satisfy 0 <= min <= max")
+		}
+		if !currencyCode.MatchString(currency) {
+			return pos, status.Errorf(codes.InvalidArgument, "Salary band currency must be an ISO 4217 code")
+		}
+		pos.SalaryBand = &SalaryBand{Min: band.GetMin(), Max: band.GetMax(), Currency: currency}
+	}
+
+	return pos, nil
+}
+
+type positionServer struct {
+	pb.UnimplementedPositionServiceServer
+	positionsCollection *mongo.Collection
+	// Renames reach employees through the employee server, so they are
+	// versioned and published like any other change
+	employees *server
+}
+
+func NewPositionServer(positions *mongo.Collection, employees *server) pb.PositionServiceServer {
+	return &positionServer{positionsCollection: positions, employees: employees}
+}
+
+// ListPositions
+func (s *positionServer) ListPositions(ctx context.Context, req *pb.Empty) (*pb.PositionList, error) {
+	log.Println("ListPositions RPC called")
+
+	cursor, err := s.positionsCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve positions: %v", err)
+	}
+
+	var positions []Position
+	if err := cursor.All(ctx, &positions); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode position: %v", err)
+	}
+
+	list := &pb.PositionList{}
+	for _, p := range positions {
+		list.Positions = append(list.Positions, p.toProto())
+	}
+	return list, nil
+}
+
+// GetPosition
+func (s *positionServer) GetPosition(ctx context.Context, req *pb.PositionID) (*pb.Position, error) {
+	log.Println("GetPosition RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	var pos Position
+	err = s.positionsCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&pos)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Position not found with ID: %s", req.GetId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve position: %v", err)
+	}
+
+	return pos.toProto(), nil
+}
+
+// CreatePosition
+func (s *positionServer) CreatePosition(ctx context.Context, req *pb.Position) (*pb.Position, error) {
+	log.Println("CreatePosition RPC called")
+
+	pos, err := positionFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.positionsCollection.InsertOne(ctx, pos)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Position %s already exists in %s at level %d", pos.Title, pos.JobFamily, pos.Level)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create position: %v", err)
+	}
+
+	pos.ID = res.InsertedID.(primitive.ObjectID)
+	return pos.toProto(), nil
+}
+
+// UpdatePosition
+func (s *positionServer) UpdatePosition(ctx context.Context, req *pb.Position) (*pb.Position, error) {
+	log.Println("UpdatePosition RPC called")
+	return inTransaction(ctx, s.employees.mongoClient(), func(ctx context.Context) (*pb.Position, error) {
+		return s.updatePosition(ctx, req)
+	})
+}
+
+func (s *positionServer) updatePosition(ctx context.Context, req *pb.Position) (*pb.Position, error) {
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	pos, err := positionFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": pos}
+	if pos.SalaryBand == nil {
+		update["$unset"] = bson.M{"salary_band": ""}
+	}
+
+	res, err := s.positionsCollection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Position %s already exists in %s at level %d", pos.Title, pos.JobFamily, pos.Level)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update position: %v", err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Position not found with ID: %s", req.GetId())
+	}
+
+	// Keep the denormalized job title on employees in sync
+	if _, err := s.employees.setEmployeeFields(ctx, bson.M{"position_id": oid}, bson.M{"position": pos.Title}); err != nil {
+		return nil, err
+	}
+
+	pos.ID = oid
+	return pos.toProto(), nil
+}
+
+// DeletePosition
+func (s *positionServer) DeletePosition(ctx context.Context, req *pb.PositionID) (*pb.Empty, error) {
+	log.Println("DeletePosition RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	holders, err := s.employees.employeesCollection.CountDocuments(ctx, bson.M{"position_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check position holders: %v", err)
+	}
+	if holders > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Position %s is still held by %d employees", req.GetId(), holders)
+	}
+
+	res, err := s.positionsCollection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete position: %v", err)
+	}
+	if res.DeletedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Position not found with ID: %s", req.GetId())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// GetPositionHeadcount
+func (s *positionServer) GetPositionHeadcount(ctx context.Context, req *pb.Empty) (*pb.PositionHeadcountReport, error) {
+	log.Println("GetPositionHeadcount RPC called")
+
+	cursor, err := s.employees.employeesCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: notTerminated}},
+		{{Key: "$group", Value: bson.M{"_id": "$position_id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         s.positionsCollection.Name(),
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "position",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$position", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"job_family": bson.M{"$ifNull": bson.A{"$position.job_family", ""}},
+				"level":      bson.M{"$ifNull": bson.A{"$position.level", 0}},
+			},
+			"headcount": bson.M{"$sum": "$count"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.job_family", Value: 1}, {Key: "_id.level", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to count headcount: %v", err)
+	}
+
+	var rows []struct {
+		Key struct {
+			JobFamily string `bson:"job_family"`
+			Level     int32  `bson:"level"`
+		} `bson:"_id"`
+		Headcount int64 `bson:"headcount"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode headcount: %v", err)
+	}
+
+	report := &pb.PositionHeadcountReport{}
+	for _, r := range rows {
+		report.Rows = append(report.Rows, &pb.PositionHeadcount{
+			JobFamily: r.Key.JobFamily,
+			Level:     r.Key.Level,
+			Headcount: r.Headcount,
+		})
+	}
+	return report, nil
+}
+
+// resolvePosition validates the position of an employee write. The position
+// may be given by ID or, for older clients, by a job title that matches
+// exactly one position. Titles stored before the catalog existed are
+// backfilled with the migrate-positions command.
+func (s *server) resolvePosition(ctx context.Context, req *pb.Employee) (primitive.ObjectID, string, error) {
+	if req.GetPositionId() != "" {
+		oid, err := primitive.ObjectIDFromHex(req.GetPositionId())
+		if err != nil {
+			return primitive.NilObjectID, "", status.Errorf(codes.InvalidArgument, "Invalid position ID format: %v", err)
+		}
+		var pos Position
+		err = s.positionsCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&pos)
+		if err == mongo.ErrNoDocuments {
+			return primitive.NilObjectID, "", status.Errorf(codes.FailedPrecondition, "Position not found with ID: %s", req.GetPositionId())
+		}
+		if err != nil {
+			return primitive.NilObjectID, "", status.Errorf(codes.Internal, "Failed to retrieve position: %v", err)
+		}
+		return pos.ID, pos.Title, nil
+	}
+
+	title := strings.TrimSpace(req.GetPosition())
+	if title == "" {
+		return primitive.NilObjectID, "", nil
+	}
+
+	cursor, err := s.positionsCollection.Find(ctx, bson.M{
+		"title": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(title) + "$", Options: "i"},
+	})
+	if err != nil {
+		return primitive.NilObjectID, "", status.Errorf(codes.Internal, "Failed to retrieve position: %v", err)
+	}
+	var matches []Position
+	if err := cursor.All(ctx, &matches); err != nil {
+		return primitive.NilObjectID, "", status.Errorf(codes.Internal, "Failed to decode position: %v", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return primitive.NilObjectID, "", status.Errorf(codes.InvalidArgument, "Unknown position: %s", title)
+	case 1:
+		return matches[0].ID, matches[0].Title, nil
+	default:
+		return primitive.NilObjectID, "", status.Errorf(codes.InvalidArgument, "Position %s matches %d positions, pass position_id instead", title, len(matches))
+	}
+}
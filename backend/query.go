@@ -0,0 +1,654 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Kinds of query fields
+const (
+	fieldString  = "string"
+	fieldID      = "id"
+	fieldStatus  = "status"
+	fieldDate    = "date"
+	fieldNumber  = "number"
+	fieldBoolean = "boolean"
+)
+
+// queryField is an employee field queries can group by, filter on and
+// aggregate
+type queryField struct {
+	name string
+	kind string
+	// Path of the field in an employee document
+	path string
+}
+
+// queryFieldSet is the registry of the fields queries can use
+type queryFieldSet []queryField
+
+// queryFields are the fields every employee has. Custom fields come on top,
+// see loadQueryFields.
+var queryFields = queryFieldSet{
+	{name: "first_name", kind: fieldString, path: "first_name"},
+	{name: "last_name", kind: fieldString, path: "last_name"},
+	{name: "email", kind: fieldString, path: "email"},
+	{name: "department", kind: fieldString, path: "department"},
+	{name: "department_id", kind: fieldID, path: "department_id"},
+	{name: "position", kind: fieldString, path: "position"},
+	{name: "position_id", kind: fieldID, path: "position_id"},
+	{name: "manager_id", kind: fieldID, path: "manager_id"},
+	{name: "status", kind: fieldStatus, path: "status"},
+	{name: "hire_date", kind: fieldDate, path: "hire_date"},
+	{name: "termination_date", kind: fieldDate, path: "termination_date"},
+	{name: "external_id", kind: fieldString, path: "external_id"},
+}
+
+// entryError says which entry of a request an error is about, such as
+// filter[1]
+func entryError(entry string, err error) error {
+	st := status.Convert(err)
+	return status.Errorf(st.Code(), "%s: %s", entry, st.Message())
+}
+
+func (fs queryFieldSet) lookup(name string) (queryField, error) {
+	for _, f := range fs {
+		if f.name == name {
+			return f, nil
+		}
+	}
+	return queryField{}, status.Errorf(codes.InvalidArgument, "Unknown field: %s", name)
+}
+
+// aggregates lists the aggregate functions that take the field
+func (f queryField) aggregates() []string {
+	if f.kind == fieldDate || f.kind == fieldNumber {
+		return []string{"count", "count_distinct", "min", "max"}
+	}
+	return []string{"count", "count_distinct"}
+}
+
+// groupField resolves a group_by entry such as department or
+// hire_date:year into its column name, type and $group expression.
+// Missing values group as "", or null for dates, numbers and booleans.
+func (fs queryFieldSet) groupField(entry string) (string, string, interface{}, error) {
+	name, unit, _ := strings.Cut(strings.TrimSpace(entry), ":")
+	f, err := fs.lookup(name)
+	if err != nil {
+		return "", "", nil, err
+	}
+	path := "$" + f.path
+	switch f.kind {
+	case fieldDate:
+		if _, ok := analyticsIntervals[unit]; !ok {
+			return "", "", nil, status.Errorf(codes.InvalidArgument, "Group %s by a period, e.g. %s:year", name, name)
+		}
+		expr := bson.M{"$dateTrunc": bson.M{"date": path, "unit": unit, "startOfWeek": "monday", "timezone": "UTC"}}
+		return name + ":" + unit, fieldDate, expr, nil
+	case fieldID:
+		if unit != "" {
+			return "", "", nil, status.Errorf(codes.InvalidArgument, "Only dates are grouped by a period, not %s", name)
+		}
+		return name, fieldString, bson.M{"$ifNull": bson.A{bson.M{"$toString": path}, ""}}, nil
+	case fieldStatus:
+		if unit != "" {
+			return "", "", nil, status.Errorf(codes.InvalidArgument, "Only dates are grouped by a period, not %s", name)
+		}
+		// Documents without a status predate the lifecycle and count as active
+		return name, fieldString, bson.M{"$ifNull": bson.A{path, statusActive}}, nil
+	case fieldNumber, fieldBoolean:
+		if unit != "" {
+			return "", "", nil, status.Errorf(codes.InvalidArgument, "Only dates are grouped by a period, not %s", name)
+		}
+		return name, f.kind, path, nil
+	}
+	if unit != "" {
+		return "", "", nil, status.Errorf(codes.InvalidArgument, "Only dates are grouped by a period, not %s", name)
+	}
+	return name, fieldString, bson.M{"$ifNull": bson.A{path, ""}}, nil
+}
+
+// queryValue parses a filter value for a field
+func queryValue(f queryField, v string) (interface{}, error) {
+	switch f.kind {
+	case fieldID:
+		oid, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid ID for %s: %s", f.name, v)
+		}
+		return oid, nil
+	case fieldDate:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid date for %s: %s", f.name, v)
+	case fieldStatus:
+		st := strings.ToLower(v)
+		if _, ok := statusToProto[st]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid status: %s", v)
+		}
+		return st, nil
+	case fieldNumber:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid number for %s: %s", f.name, v)
+		}
+		return n, nil
+	case fieldBoolean:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid boolean for %s: %s", f.name, v)
+		}
+		return b, nil
+	}
+	return v, nil
+}
+
+// condition compiles a filter condition into a $match expression
+func (fs queryFieldSet) condition(c *pb.QueryCondition) (bson.M, error) {
+	f, err := fs.lookup(c.GetField())
+	if err != nil {
+		return nil, err
+	}
+	op := c.GetOp()
+	if op == "" {
+		op = "eq"
+	}
+
+	var values bson.A
+	for _, v := range c.GetValues() {
+		value, err := queryValue(f, v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		// Documents without a status count as active
+		if value == statusActive && f.kind == fieldStatus {
+			values = append(values, nil)
+		}
+	}
+	want := func(n int) error {
+		if len(c.GetValues()) != n {
+			return status.Errorf(codes.InvalidArgument, "%s on %s takes %d value(s)", op, f.name, n)
+		}
+		return nil
+	}
+
+	switch op {
+	case "eq", "ne", "in", "not_in":
+		if op == "eq" || op == "ne" {
+			if err := want(1); err != nil {
+				return nil, err
+			}
+		} else if len(values) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "%s on %s takes values", op, f.name)
+		}
+		if op == "eq" || op == "in" {
+			return bson.M{f.path: bson.M{"$in": values}}, nil
+		}
+		return bson.M{f.path: bson.M{"$nin": values}}, nil
+	case "lt", "lte", "gt", "gte":
+		if err := want(1); err != nil {
+			return nil, err
+		}
+		if f.kind != fieldDate && f.kind != fieldString && f.kind != fieldNumber {
+			return nil, status.Errorf(codes.InvalidArgument, "%s cannot be compared with %s", f.name, op)
+		}
+		return bson.M{f.path: bson.M{"$" + op: values[0]}}, nil
+	case "exists", "missing":
+		if err := want(0); err != nil {
+			return nil, err
+		}
+		if op == "exists" {
+			return bson.M{f.path: bson.M{"$exists": true, "$nin": bson.A{nil, ""}}}, nil
+		}
+		return bson.M{f.path: bson.M{"$in": bson.A{nil, ""}}}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "Unknown operator: %s", op)
+}
+
+// parseListFilter reads a list filter written as field:op:value. in and
+// not_in take comma-separated values, exists and missing none.
+func parseListFilter(entry string) *pb.QueryCondition {
+	field, rest, _ := strings.Cut(strings.TrimSpace(entry), ":")
+	op, value, hasValue := strings.Cut(rest, ":")
+	c := &pb.QueryCondition{Field: field, Op: op}
+	switch {
+	case !hasValue:
+	case op == "in" || op == "not_in":
+		c.Values = strings.Split(value, ",")
+	default:
+		c.Values = []string{value}
+	}
+	return c
+}
+
+// sortOrder compiles an order_by such as "custom.badge_number desc,
+// last_name" into a sort on the fields, with _id last so pages are stable.
+// It returns nil for an empty order_by.
+func (fs queryFieldSet) sortOrder(orderBy string) (bson.D, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+	var sort bson.D
+	for i, entry := range strings.Split(orderBy, ",") {
+		where := fmt.Sprintf("order_by[%d]", i)
+		parts := strings.Fields(entry)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: Invalid order_by: %s", where, orderBy)
+		}
+		f, err := fs.lookup(parts[0])
+		if err != nil {
+			return nil, entryError(where, err)
+		}
+		direction := 1
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				direction = -1
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "%s: Sort %s asc or desc, not %s", where, f.name, parts[1])
+			}
+		}
+		sort = append(sort, bson.E{Key: f.path, Value: direction})
+	}
+	return append(sort, bson.E{Key: "_id", Value: 1}), nil
+}
+
+// aggregate compiles an aggregate into its column name, type and $group
+// accumulator. count_distinct collects a set that is counted afterwards.
+func (fs queryFieldSet) aggregate(a *pb.QueryAggregate) (string, string, interface{}, error) {
+	fn := strings.ToLower(a.GetFunction())
+	if fn == "" {
+		fn = "count"
+	}
+	alias := a.GetAlias()
+
+	if fn == "count" && a.GetField() == "" {
+		if alias == "" {
+			alias = "count"
+		}
+		return alias, fieldNumber, bson.M{"$sum": 1}, nil
+	}
+	if a.GetField() == "" {
+		return "", "", nil, status.Errorf(codes.InvalidArgument, "%s needs a field", fn)
+	}
+	f, err := fs.lookup(a.GetField())
+	if err != nil {
+		return "", "", nil, err
+	}
+	supported := false
+	for _, name := range f.aggregates() {
+		supported = supported || name == fn
+	}
+	if !supported {
+		return "", "", nil, status.Errorf(codes.InvalidArgument, "%s does not take %s", fn, f.name)
+	}
+	if alias == "" {
+		alias = fn + "_" + f.name
+	}
+
+	path := "$" + f.path
+	switch fn {
+	case "count":
+		return alias, fieldNumber, bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{path, nil}}, 1, 0}}}, nil
+	case "count_distinct":
+		return alias, fieldNumber, bson.M{"$addToSet": path}, nil
+	case "min":
+		return alias, f.kind, bson.M{"$min": path}, nil
+	}
+	return alias, f.kind, bson.M{"$max": path}, nil
+}
+
+// compiledQuery is a validated query: its pipeline and how to read the rows
+type compiledQuery struct {
+	pipeline mongo.Pipeline
+	// Group columns, the pivot column last if there is one
+	groups  []*pb.QueryColumn
+	pivoted bool
+	values  []*pb.QueryColumn
+	// Whether each value is a count, which is 0 rather than null where no
+	// employee matched
+	counts []bool
+}
+
+// Caps on the rows of a query
+const (
+	defaultQueryLimit = 1000
+	maxQueryLimit     = 10000
+	// Groups a pivot may read before it is turned into rows
+	maxPivotGroups = 100000
+)
+
+// compileQuery validates a query against the field registry and builds its
+// aggregation pipeline. Errors name the entry they are about, such as
+// aggregates[1].
+func compileQuery(fields queryFieldSet, q *pb.EmployeeQuery, limit int) (*compiledQuery, error) {
+	c := &compiledQuery{}
+	seen := map[string]bool{}
+	id := bson.D{}
+	addGroup := func(where, entry string) error {
+		name, kind, expr, err := fields.groupField(entry)
+		if err != nil {
+			return entryError(where, err)
+		}
+		if seen[name] {
+			return status.Errorf(codes.InvalidArgument, "%s: %s is grouped by twice", where, name)
+		}
+		seen[name] = true
+		id = append(id, bson.E{Key: "g" + strconv.Itoa(len(c.groups)), Value: expr})
+		c.groups = append(c.groups, &pb.QueryColumn{Name: name, Type: kind})
+		return nil
+	}
+	for i, entry := range q.GetGroupBy() {
+		if err := addGroup(fmt.Sprintf("group_by[%d]", i), entry); err != nil {
+			return nil, err
+		}
+	}
+	if q.GetPivotBy() != "" {
+		if err := addGroup("pivot_by", q.GetPivotBy()); err != nil {
+			return nil, err
+		}
+		c.pivoted = true
+	}
+
+	group := bson.D{{Key: "_id", Value: id}}
+	sizes := bson.M{}
+	aggregates := q.GetAggregates()
+	if len(aggregates) == 0 {
+		aggregates = []*pb.QueryAggregate{{Function: "count"}}
+	}
+	for i, a := range aggregates {
+		where := fmt.Sprintf("aggregates[%d]", i)
+		alias, kind, acc, err := fields.aggregate(a)
+		if err != nil {
+			return nil, entryError(where, err)
+		}
+		if seen[alias] {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: Duplicate column: %s", where, alias)
+		}
+		seen[alias] = true
+		key := "a" + strconv.Itoa(i)
+		group = append(group, bson.E{Key: key, Value: acc})
+		fn := strings.ToLower(a.GetFunction())
+		if fn == "count_distinct" {
+			sizes[key] = bson.M{"$size": "$" + key}
+		}
+		c.values = append(c.values, &pb.QueryColumn{Name: alias, Type: kind})
+		c.counts = append(c.counts, fn == "" || fn == "count" || fn == "count_distinct")
+	}
+
+	var match bson.A
+	if !q.GetIncludeTerminated() {
+		match = append(match, notTerminated)
+	}
+	for i, cond := range q.GetFilter() {
+		m, err := fields.condition(cond)
+		if err != nil {
+			return nil, entryError(fmt.Sprintf("filter[%d]", i), err)
+		}
+		match = append(match, m)
+	}
+
+	if q.GetAsOf() != nil {
+		if err := q.GetAsOf().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+		}
+		c.pipeline = asOfStages(q.GetAsOf().AsTime())
+	}
+	if len(match) > 0 {
+		c.pipeline = append(c.pipeline, bson.D{{Key: "$match", Value: bson.M{"$and": match}}})
+	}
+	c.pipeline = append(c.pipeline, bson.D{{Key: "$group", Value: group}})
+	if len(sizes) > 0 {
+		c.pipeline = append(c.pipeline, bson.D{{Key: "$set", Value: sizes}})
+	}
+	sortBy := bson.D{}
+	for i := range c.groups {
+		sortBy = append(sortBy, bson.E{Key: "_id.g" + strconv.Itoa(i), Value: 1})
+	}
+	if len(sortBy) > 0 {
+		c.pipeline = append(c.pipeline, bson.D{{Key: "$sort", Value: sortBy}})
+	}
+	if c.pivoted {
+		limit = maxPivotGroups
+	}
+	c.pipeline = append(c.pipeline, bson.D{{Key: "$limit", Value: limit + 1}})
+	return c, nil
+}
+
+// queryCell converts an aggregated value for the result table
+func queryCell(v interface{}) *structpb.Value {
+	switch v := v.(type) {
+	case nil:
+		return structpb.NewNullValue()
+	case string:
+		return structpb.NewStringValue(v)
+	case bool:
+		return structpb.NewBoolValue(v)
+	case primitive.DateTime:
+		return structpb.NewStringValue(v.Time().UTC().Format(time.RFC3339))
+	case int32:
+		return structpb.NewNumberValue(float64(v))
+	case int64:
+		return structpb.NewNumberValue(float64(v))
+	case float64:
+		return structpb.NewNumberValue(v)
+	}
+	return structpb.NewStringValue(fmt.Sprint(v))
+}
+
+// cellText is how a cell reads in a pivot column name or CSV
+func cellText(v *structpb.Value) string {
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return k.StringValue
+	case *structpb.Value_NumberValue:
+		return strconv.FormatFloat(k.NumberValue, 'f', -1, 64)
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(k.BoolValue)
+	}
+	return ""
+}
+
+// pivot turns rows grouped by the pivot column last into one row per other
+// group, with a column per pivot value and aggregate. Counts are 0 where a
+// combination has no employees, other aggregates null.
+func (c *compiledQuery) pivot(rows []*pb.QueryRow) ([]*pb.QueryColumn, []*pb.QueryRow) {
+	keys := len(c.groups) - 1
+	var pivotValues []string
+	seenValue := map[string]bool{}
+	for _, row := range rows {
+		if v := cellText(row.Values[keys]); !seenValue[v] {
+			seenValue[v] = true
+			pivotValues = append(pivotValues, v)
+		}
+	}
+	sort.Strings(pivotValues)
+	index := make(map[string]int, len(pivotValues))
+	for i, v := range pivotValues {
+		index[v] = i
+	}
+
+	columns := append([]*pb.QueryColumn{}, c.groups[:keys]...)
+	for _, v := range pivotValues {
+		name := v
+		if name == "" {
+			name = "(none)"
+		}
+		for _, col := range c.values {
+			if len(c.values) > 1 {
+				columns = append(columns, &pb.QueryColumn{Name: name + " " + col.Name, Type: col.Type})
+			} else {
+				columns = append(columns, &pb.QueryColumn{Name: name, Type: col.Type})
+			}
+		}
+	}
+
+	var out []*pb.QueryRow
+	byKey := map[string]*pb.QueryRow{}
+	for _, row := range rows {
+		var key strings.Builder
+		for _, v := range row.Values[:keys] {
+			key.WriteString(cellText(v))
+			key.WriteByte(0)
+		}
+		pivoted, ok := byKey[key.String()]
+		if !ok {
+			pivoted = &pb.QueryRow{Values: append([]*structpb.Value{}, row.Values[:keys]...)}
+			for range pivotValues {
+				for _, count := range c.counts {
+					if count {
+						pivoted.Values = append(pivoted.Values, structpb.NewNumberValue(0))
+					} else {
+						pivoted.Values = append(pivoted.Values, structpb.NewNullValue())
+					}
+				}
+			}
+			byKey[key.String()] = pivoted
+			out = append(out, pivoted)
+		}
+		at := keys + index[cellText(row.Values[keys])]*len(c.values)
+		copy(pivoted.Values[at:], row.Values[keys+1:])
+	}
+	return columns, out
+}
+
+// QueryEmployees
+func (s *analyticsServer) QueryEmployees(ctx context.Context, req *pb.EmployeeQuery) (*pb.QueryResult, error) {
+	log.Println("QueryEmployees RPC called")
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+	fields, err := loadQueryFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	q, err := compileQuery(fields, req, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := s.employeesCollection
+	if req.GetAsOf() != nil {
+		collection = s.versionsCollection
+	}
+	cursor, err := collection.Aggregate(ctx, q.pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to run query: %v", err)
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode query result: %v", err)
+	}
+
+	result := &pb.QueryResult{}
+	if q.pivoted && len(docs) > maxPivotGroups {
+		return nil, status.Errorf(codes.InvalidArgument, "More than %d groups to pivot, filter or group by less", maxPivotGroups)
+	}
+	var rows []*pb.QueryRow
+	for _, doc := range docs {
+		row := &pb.QueryRow{}
+		id, _ := doc["_id"].(bson.M)
+		for i := range q.groups {
+			row.Values = append(row.Values, queryCell(id["g"+strconv.Itoa(i)]))
+		}
+		for i := range q.values {
+			row.Values = append(row.Values, queryCell(doc["a"+strconv.Itoa(i)]))
+		}
+		rows = append(rows, row)
+	}
+
+	if q.pivoted {
+		result.Columns, result.Rows = q.pivot(rows)
+	} else {
+		result.Columns, result.Rows = append(q.groups, q.values...), rows
+	}
+	if len(result.Rows) > limit {
+		result.Rows, result.Truncated = result.Rows[:limit], true
+	}
+	return result, nil
+}
+
+// ListQueryFields
+func (s *analyticsServer) ListQueryFields(ctx context.Context, req *pb.Empty) (*pb.QueryFieldList, error) {
+	log.Println("ListQueryFields RPC called")
+
+	fields, err := loadQueryFields(ctx, s.customFieldsCollection)
+	if err != nil {
+		return nil, err
+	}
+	list := &pb.QueryFieldList{}
+	for _, f := range fields {
+		list.Fields = append(list.Fields, &pb.QueryField{
+			Name:       f.name,
+			Type:       f.kind,
+			Groupable:  true,
+			Filterable: true,
+			Aggregates: f.aggregates(),
+		})
+	}
+	return list, nil
+}
+
+// csvMarshaler renders query results as CSV for REST callers that accept
+// text/csv. Anything else, errors included, is written as JSON.
+type csvMarshaler struct {
+	runtime.Marshaler
+}
+
+func (m csvMarshaler) ContentType(v interface{}) string {
+	if _, ok := v.(*pb.QueryResult); ok {
+		return "text/csv"
+	}
+	return m.Marshaler.ContentType(v)
+}
+
+func (m csvMarshaler) Marshal(v interface{}) ([]byte, error) {
+	result, ok := v.(*pb.QueryResult)
+	if !ok {
+		return m.Marshaler.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(result.GetColumns()))
+	for i, col := range result.GetColumns() {
+		header[i] = col.GetName()
+	}
+	w.Write(header)
+	for _, row := range result.GetRows() {
+		record := make([]string, len(row.GetValues()))
+		for i, v := range row.GetValues() {
+			record[i] = cellText(v)
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// queryTestFields are the standard fields with a custom field of each kind
+var queryTestFields = append(append(queryFieldSet{}, queryFields...),
+	queryField{name: "custom.badge", kind: fieldNumber, path: "custom_fields.badge"},
+	queryField{name: "custom.remote", kind: fieldBoolean, path: "custom_fields.remote"},
+	queryField{name: "custom.started", kind: fieldDate, path: "custom_fields.started"},
+)
+
+// wantQueryError checks that err is InvalidArgument with a message that
+// starts with want
+func wantQueryError(t *testing.T, err error, want string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("no error, want %q", want)
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || !strings.HasPrefix(st.Message(), want) {
+		t.Errorf("error = %v %q, want InvalidArgument %q", st.Code(), st.Message(), want)
+	}
+}
+
+func TestParseListFilter(t *testing.T) {
+	tests := []struct {
+		entry string
+		want  *pb.QueryCondition
+	}{
+		{"department:eq:Sales", &pb.QueryCondition{Field: "department", Op: "eq", Values: []string{"Sales"}}},
+		{" department:eq:Sales ", &pb.QueryCondition{Field: "department", Op: "eq", Values: []string{"Sales"}}},
+		{"email:eq:a:b@example.com", &pb.QueryCondition{Field: "email", Op: "eq", Values: []string{"a:b@example.com"}}},
+		{"department:eq:", &pb.QueryCondition{Field: "department", Op: "eq", Values: []string{""}}},
+		{"status:in:active,on_leave", &pb.QueryCondition{Field: "status", Op: "in", Values: []string{"active", "on_leave"}}},
+		{"status:not_in:terminated", &pb.QueryCondition{Field: "status", Op: "not_in", Values: []string{"terminated"}}},
+		{"manager_id:missing", &pb.QueryCondition{Field: "manager_id", Op: "missing"}},
+		{"department", &pb.QueryCondition{Field: "department"}},
+		{"", &pb.QueryCondition{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			got := parseListFilter(tt.entry)
+			if got.GetField() != tt.want.GetField() || got.GetOp() != tt.want.GetOp() || !reflect.DeepEqual(got.GetValues(), tt.want.GetValues()) {
+				t.Errorf("parseListFilter(%q) = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryCondition(t *testing.T) {
+	id := primitive.NewObjectID()
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		filter string
+		want   bson.M
+	}{
+		{"department:eq:Sales", bson.M{"department": bson.M{"$in": bson.A{"Sales"}}}},
+		{"department::Sales", bson.M{"department": bson.M{"$in": bson.A{"Sales"}}}},
+		{"department:ne:Sales", bson.M{"department": bson.M{"$nin": bson.A{"Sales"}}}},
+		{"department:in:Sales,Finance", bson.M{"department": bson.M{"$in": bson.A{"Sales", "Finance"}}}},
+		{"manager_id:eq:" + id.Hex(), bson.M{"manager_id": bson.M{"$in": bson.A{id}}}},
+		// Employees without a status are active
+		{"status:eq:ACTIVE", bson.M{"status": bson.M{"$in": bson.A{"active", nil}}}},
+		{"status:not_in:active,terminated", bson.M{"status": bson.M{"$nin": bson.A{"active", nil, "terminated"}}}},
+		{"hire_date:gte:2024-03-01", bson.M{"hire_date": bson.M{"$gte": date}}},
+		{"hire_date:lt:2024-03-01T01:00:00+01:00", bson.M{"hire_date": bson.M{"$lt": date}}},
+		{"last_name:gt:M", bson.M{"last_name": bson.M{"$gt": "M"}}},
+		{"custom.badge:lte:1e3", bson.M{"custom_fields.badge": bson.M{"$lte": 1000.0}}},
+		{"custom.remote:eq:true", bson.M{"custom_fields.remote": bson.M{"$in": bson.A{true}}}},
+		{"manager_id:exists", bson.M{"manager_id": bson.M{"$exists": true, "$nin": bson.A{nil, ""}}}},
+		{"manager_id:missing", bson.M{"manager_id": bson.M{"$in": bson.A{nil, ""}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := queryTestFields.condition(parseListFilter(tt.filter))
+			if err != nil {
+				t.Fatalf("condition(%q): %v", tt.filter, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("condition(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryConditionErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		err    string
+	}{
+		{"", "Unknown field: "},
+		{"salary:eq:1", "Unknown field: salary"},
+		{"Department:eq:Sales", "Unknown field: Department"},
+		{"department:like:Sa", "Unknown operator: like"},
+		{"department:eq", "eq on department takes 1 value(s)"},
+		{"department:exists:yes", "exists on department takes 0 value(s)"},
+		{"department:missing:", "missing on department takes 0 value(s)"},
+		{"manager_id:eq:nobody", "Invalid ID for manager_id: nobody"},
+		{"status:eq:retired", "Invalid status: retired"},
+		{"hire_date:gt:01/03/2024", "Invalid date for hire_date: 01/03/2024"},
+		{"custom.badge:eq:twelve", "Invalid number for custom.badge: twelve"},
+		{"custom.remote:eq:maybe", "Invalid boolean for custom.remote: maybe"},
+		{"status:gt:active", "status cannot be compared with gt"},
+		{"manager_id:lt:" + primitive.NewObjectID().Hex(), "manager_id cannot be compared with lt"},
+		{"custom.remote:gte:true", "custom.remote cannot be compared with gte"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := queryTestFields.condition(parseListFilter(tt.filter))
+			wantQueryError(t, err, tt.err)
+		})
+	}
+
+	// in takes at least one value, given as a list rather than a filter string
+	_, err := queryTestFields.condition(&pb.QueryCondition{Field: "department", Op: "in"})
+	wantQueryError(t, err, "in on department takes values")
+}
+
+func TestSortOrder(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    bson.D
+		err     string
+	}{
+		{orderBy: "", want: nil},
+		{orderBy: "  ", want: nil},
+		{orderBy: "last_name", want: bson.D{{Key: "last_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{orderBy: "custom.badge DESC, last_name asc", want: bson.D{{Key: "custom_fields.badge", Value: -1}, {Key: "last_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{orderBy: "salary", err: "order_by[0]: Unknown field: salary"},
+		{orderBy: "last_name, salary desc", err: "order_by[1]: Unknown field: salary"},
+		{orderBy: "last_name,", err: "order_by[1]: Invalid order_by"},
+		{orderBy: "last_name down", err: "order_by[0]: Sort last_name asc or desc, not down"},
+		{orderBy: "first_name, last_name asc desc", err: "order_by[1]: Invalid order_by"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			got, err := queryTestFields.sortOrder(tt.orderBy)
+			if tt.err != "" {
+				wantQueryError(t, err, tt.err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("sortOrder(%q): %v", tt.orderBy, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortOrder(%q) = %v, want %v", tt.orderBy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupField(t *testing.T) {
+	tests := []struct {
+		entry string
+		name  string
+		kind  string
+		err   string
+	}{
+		{entry: "department", name: "department", kind: fieldString},
+		{entry: " position ", name: "position", kind: fieldString},
+		{entry: "manager_id", name: "manager_id", kind: fieldString},
+		{entry: "status", name: "status", kind: fieldString},
+		{entry: "hire_date:year", name: "hire_date:year", kind: fieldDate},
+		{entry: "custom.started:week", name: "custom.started:week", kind: fieldDate},
+		{entry: "custom.badge", name: "custom.badge", kind: fieldNumber},
+		{entry: "custom.remote", name: "custom.remote", kind: fieldBoolean},
+		{entry: "salary", err: "Unknown field: salary"},
+		{entry: "hire_date", err: "Group hire_date by a period, e.g. hire_date:year"},
+		{entry: "hire_date:decade", err: "Group hire_date by a period"},
+		{entry: "department:year", err: "Only dates are grouped by a period, not department"},
+		{entry: "manager_id:month", err: "Only dates are grouped by a period, not manager_id"},
+		{entry: "status:day", err: "Only dates are grouped by a period, not status"},
+		{entry: "custom.badge:year", err: "Only dates are grouped by a period, not custom.badge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			name, kind, _, err := queryTestFields.groupField(tt.entry)
+			if tt.err != "" {
+				wantQueryError(t, err, tt.err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("groupField(%q): %v", tt.entry, err)
+			}
+			if name != tt.name || kind != tt.kind {
+				t.Errorf("groupField(%q) = %s %s, want %s %s", tt.entry, name, kind, tt.name, tt.kind)
+			}
+		})
+	}
+}
+
+func TestCompileQuery(t *testing.T) {
+	q := &pb.EmployeeQuery{
+		GroupBy: []string{"department"},
+		PivotBy: "status",
+		Aggregates: []*pb.QueryAggregate{
+			{},
+			{Function: "COUNT_DISTINCT", Field: "position"},
+			{Function: "max", Field: "hire_date", Alias: "latest_hire"},
+		},
+		Filter: []*pb.QueryCondition{{Field: "custom.remote", Values: []string{"true"}}},
+	}
+	c, err := compileQuery(queryTestFields, q, 10)
+	if err != nil {
+		t.Fatalf("compileQuery: %v", err)
+	}
+
+	var columns []string
+	for _, col := range append(append([]*pb.QueryColumn{}, c.groups...), c.values...) {
+		columns = append(columns, col.GetName()+" "+col.GetType())
+	}
+	want := []string{"department string", "status string", "count number", "count_distinct_position number", "latest_hire date"}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %q, want %q", columns, want)
+	}
+	if !c.pivoted || !reflect.DeepEqual(c.counts, []bool{true, true, false}) {
+		t.Errorf("pivoted = %v, counts = %v, want true [true true false]", c.pivoted, c.counts)
+	}
+
+	var stages []string
+	for _, stage := range c.pipeline {
+		stages = append(stages, stage[0].Key)
+	}
+	if want := []string{"$match", "$group", "$set", "$sort", "$limit"}; !reflect.DeepEqual(stages, want) {
+		t.Errorf("stages = %q, want %q", stages, want)
+	}
+	// A pivot reads every group before it is turned into rows
+	if limit := c.pipeline[len(c.pipeline)-1][0].Value; limit != maxPivotGroups+1 {
+		t.Errorf("$limit = %v, want %d", limit, maxPivotGroups+1)
+	}
+
+	// as_of reads the versions first; terminated employees are only
+	// filtered out when asked
+	c, err = compileQuery(queryTestFields, &pb.EmployeeQuery{IncludeTerminated: true, AsOf: timestamppb.Now()}, 10)
+	if err != nil {
+		t.Fatalf("compileQuery: %v", err)
+	}
+	last := c.pipeline[len(c.pipeline)-2:]
+	if last[0][0].Key != "$group" || last[1][0].Value != 11 {
+		t.Errorf("pipeline ends with %v, want $group and $limit 11", last)
+	}
+	for _, stage := range c.pipeline {
+		if stage[0].Key == "$match" && reflect.DeepEqual(stage[0].Value, bson.M{"$and": bson.A{notTerminated}}) {
+			t.Errorf("terminated employees are filtered out")
+		}
+	}
+}
+
+func TestCompileQueryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *pb.EmployeeQuery
+		err   string
+	}{
+		{"unknown group", &pb.EmployeeQuery{GroupBy: []string{"department", "salary"}}, "group_by[1]: Unknown field: salary"},
+		{"date without a period", &pb.EmployeeQuery{GroupBy: []string{"hire_date"}}, "group_by[0]: Group hire_date by a period"},
+		{"grouped twice", &pb.EmployeeQuery{GroupBy: []string{"department", "position", "department"}}, "group_by[2]: department is grouped by twice"},
+		{"pivot on a group", &pb.EmployeeQuery{GroupBy: []string{"status"}, PivotBy: "status"}, "pivot_by: status is grouped by twice"},
+		{"unknown pivot", &pb.EmployeeQuery{PivotBy: "salary"}, "pivot_by: Unknown field: salary"},
+		{"aggregate without a field", &pb.EmployeeQuery{Aggregates: []*pb.QueryAggregate{{}, {Function: "max"}}}, "aggregates[1]: max needs a field"},
+		{"aggregate of an unknown field", &pb.EmployeeQuery{Aggregates: []*pb.QueryAggregate{{Function: "count", Field: "salary"}}}, "aggregates[0]: Unknown field: salary"},
+		{"aggregate a field does not take", &pb.EmployeeQuery{Aggregates: []*pb.QueryAggregate{{Function: "min", Field: "last_name"}}}, "aggregates[0]: min does not take last_name"},
+		{"unknown aggregate", &pb.EmployeeQuery{Aggregates: []*pb.QueryAggregate{{Function: "avg", Field: "custom.badge"}}}, "aggregates[0]: avg does not take custom.badge"},
+		{"duplicate aggregate", &pb.EmployeeQuery{Aggregates: []*pb.QueryAggregate{{}, {Function: "count"}}}, "aggregates[1]: Duplicate column: count"},
+		{"aggregate named like a group", &pb.EmployeeQuery{GroupBy: []string{"department"}, Aggregates: []*pb.QueryAggregate{{Alias: "department"}}}, "aggregates[0]: Duplicate column: department"},
+		{"invalid filter", &pb.EmployeeQuery{Filter: []*pb.QueryCondition{{Field: "department", Values: []string{"Sales"}}, {Field: "status", Values: []string{"retired"}}}}, "filter[1]: Invalid status: retired"},
+		{"invalid as_of", &pb.EmployeeQuery{AsOf: &timestamppb.Timestamp{Nanos: -1}}, "Invalid as_of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileQuery(queryTestFields, tt.query, defaultQueryLimit)
+			wantQueryError(t, err, tt.err)
+		})
+	}
+}
+
+func TestPivot(t *testing.T) {
+	c, err := compileQuery(queryTestFields, &pb.EmployeeQuery{
+		GroupBy:    []string{"department"},
+		PivotBy:    "status",
+		Aggregates: []*pb.QueryAggregate{{}, {Function: "max", Field: "hire_date"}},
+	}, defaultQueryLimit)
+	if err != nil {
+		t.Fatalf("compileQuery: %v", err)
+	}
+	row := func(values ...interface{}) *pb.QueryRow {
+		r := &pb.QueryRow{}
+		for _, v := range values {
+			r.Values = append(r.Values, queryCell(v))
+		}
+		return r
+	}
+	hired := primitive.NewDateTimeFromTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	columns, rows := c.pivot([]*pb.QueryRow{
+		row("Engineering", "active", int32(3), hired),
+		row("Engineering", "on_leave", int32(1), nil),
+		row("Sales", "", int32(2), nil),
+	})
+
+	var names []string
+	for _, col := range columns {
+		names = append(names, col.GetName())
+	}
+	wantNames := []string{"department", "(none) count", "(none) max_hire_date", "active count", "active max_hire_date", "on_leave count", "on_leave max_hire_date"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("columns = %q, want %q", names, wantNames)
+	}
+
+	var got [][]string
+	for _, r := range rows {
+		var cells []string
+		for _, v := range r.GetValues() {
+			if _, null := v.GetKind().(*structpb.Value_NullValue); null {
+				cells = append(cells, "null")
+			} else {
+				cells = append(cells, cellText(v))
+			}
+		}
+		got = append(got, cells)
+	}
+	// Counts are 0 where there is no group, other aggregates null
+	want := [][]string{
+		{"Engineering", "0", "null", "3", "2024-03-01T00:00:00Z", "1", "null"},
+		{"Sales", "2", "null", "0", "null", "0", "null"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Revision actions
+const (
+	actionCreate       = "create"
+	actionUpdate       = "update"
+	actionStatusChange = "status_change"
+	actionDelete       = "delete"
+	actionRestore      = "restore"
+	actionMerge        = "merge"
+)
+
+// Fields left out of revision diffs. The status history is itself a log of
+// status changes, which show up through the status field.
+var revisionIgnoredFields = map[string]bool{
+	"_id":            true,
+	"status_history": true,
+}
+
+// EmployeeRevision is an immutable record of one change to an employee
+type EmployeeRevision struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	EmployeeID    primitive.ObjectID `bson:"employee_id"`
+	Revision      int64              `bson:"revision"`
+	Action        string             `bson:"action"`
+	Actor         string             `bson:"actor"`
+	Timestamp     time.Time          `bson:"timestamp"`
+	EffectiveDate *time.Time         `bson:"effective_date,omitempty"`
+	Changes       []FieldChange      `bson:"changes"`
+	Before        bson.M             `bson:"before,omitempty"`
+	After         bson.M             `bson:"after,omitempty"`
+	Merge         *RevisionMerge     `bson:"merge,omitempty"`
+}
+
+type FieldChange struct {
+	Field  string      `bson:"field"`
+	Before interface{} `bson:"before"`
+	After  interface{} `bson:"after"`
+}
+
+func (r EmployeeRevision) toProto() *pb.EmployeeRevision {
+	rev := &pb.EmployeeRevision{
+		Id:         r.ID.Hex(),
+		EmployeeId: r.EmployeeID.Hex(),
+		Revision:   r.Revision,
+		Action:     r.Action,
+		Actor:      r.Actor,
+		Timestamp:  timestamppb.New(r.Timestamp),
+	}
+	if r.EffectiveDate != nil {
+		rev.EffectiveDate = timestamppb.New(*r.EffectiveDate)
+	}
+	if r.Merge != nil {
+		rev.Merge = &pb.EmployeeMerge{
+			SurvivorId:  r.Merge.SurvivorID.Hex(),
+			DuplicateId: r.Merge.DuplicateID.Hex(),
+		}
+	}
+	for _, c := range r.Changes {
+		rev.Changes = append(rev.Changes, &pb.FieldChange{
+			Field:  c.Field,
+			Before: bsonToValue(c.Before),
+			After:  bsonToValue(c.After),
+		})
+	}
+
+	state := r.After
+	if state == nil {
+		state = r.Before
+	}
+	if emp, err := snapshotToEmployee(state); err == nil {
+		emp.ID = r.EmployeeID
+		rev.Snapshot = emp.toProto()
+	}
+	return rev
+}
+
+// diffFields lists the fields that differ between two employee documents
+func diffFields(before, after bson.M) []FieldChange {
+	fields := map[string]bool{}
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		if !revisionIgnoredFields[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []FieldChange{}
+	for _, k := range names {
+		b, inBefore := before[k]
+		a, inAfter := after[k]
+		if inBefore == inAfter && reflect.DeepEqual(normalizeBSON(b), normalizeBSON(a)) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: k, Before: b, After: a})
+	}
+	return changes
+}
+
+// normalizeBSON maps values that decode differently depending on where they
+// came from onto one representation, so they compare equal.
+func normalizeBSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return primitive.NewDateTimeFromTime(t)
+	case *time.Time:
+		if t == nil {
+			return nil
+		}
+		return primitive.NewDateTimeFromTime(*t)
+	case int:
+		return int64(t)
+	case int32:
+		return int64(t)
+	}
+	return v
+}
+
+// bsonToValue converts a stored field value for the API
+func bsonToValue(v interface{}) *structpb.Value {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case primitive.ObjectID:
+		return structpb.NewStringValue(t.Hex())
+	case primitive.DateTime:
+		return structpb.NewStringValue(t.Time().UTC().Format(time.RFC3339Nano))
+	case time.Time:
+		return structpb.NewStringValue(t.UTC().Format(time.RFC3339Nano))
+	case string:
+		return structpb.NewStringValue(t)
+	case bool:
+		return structpb.NewBoolValue(t)
+	case int32:
+		return structpb.NewNumberValue(float64(t))
+	case int64:
+		return structpb.NewNumberValue(float64(t))
+	case float64:
+		return structpb.NewNumberValue(t)
+	case primitive.A:
+		list := &structpb.ListValue{}
+		for _, item := range t {
+			list.Values = append(list.Values, bsonToValue(item))
+		}
+		return structpb.NewListValue(list)
+	case primitive.M:
+		fields := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for k, item := range t {
+			fields.Fields[k] = bsonToValue(item)
+		}
+		return structpb.NewStructValue(fields)
+	case primitive.D:
+		return bsonToValue(t.Map())
+	default:
+		// Structs such as EmploymentEvent go through a BSON round trip
+		if doc, err := toDocument(t); err == nil {
+			return bsonToValue(doc)
+		}
+		return structpb.NewNullValue()
+	}
+}
+
+// recordRevision appends a revision to the history of an employee and queues
+// the matching event in the outbox. It runs in the caller's transaction.
+func (s *server) recordRevision(ctx context.Context, id primitive.ObjectID, action string, effective *time.Time, before, after bson.M) error {
+	rev := EmployeeRevision{
+		ID:            primitive.NewObjectID(),
+		EmployeeID:    id,
+		Action:        action,
+		Actor:         actorFromContext(ctx),
+		Timestamp:     time.Now().UTC(),
+		EffectiveDate: effective,
+		Changes:       diffFields(before, after),
+		Before:        before,
+		After:         after,
+		Merge:         mergeFromContext(ctx),
+	}
+
+	var err error
+	if rev.Revision, err = s.nextRevision(ctx, id); err != nil {
+		return err
+	}
+	if _, err := s.revisionsCollection.InsertOne(ctx, rev); err != nil {
+		return status.Errorf(codes.Internal, "Failed to record revision: %v", err)
+	}
+	return s.enqueueEvent(ctx, rev)
+}
+
+// nextRevision allocates the next revision number of an employee with $inc
+// on a counter document per employee. Two transactions numbering the same
+// employee write the same counter, so one of them hits a write conflict and
+// is retried by inTransaction. The counter of an employee whose history
+// predates it starts after the highest stored revision.
+func (s *server) nextRevision(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	counters := s.revisionsCollection.Database().Collection("employee_revision_counters")
+
+	var counter struct {
+		Revision int64 `bson:"revision"`
+	}
+	err := counters.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"revision": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to allocate revision: %v", err)
+	}
+	if counter.Revision > 1 {
+		return counter.Revision, nil
+	}
+
+	var last EmployeeRevision
+	err = s.revisionsCollection.FindOne(ctx, bson.M{"employee_id": id},
+		options.FindOne().SetSort(bson.M{"revision": -1}).SetProjection(bson.M{"revision": 1}),
+	).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to allocate revision: %v", err)
+	}
+	if _, err := counters.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"revision": last.Revision + 1}}); err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to allocate revision: %v", err)
+	}
+	return last.Revision + 1, nil
+}
+
+// ListEmployeeRevisions
+func (s *server) ListEmployeeRevisions(ctx context.Context, req *pb.ListEmployeeRevisionsRequest) (*pb.EmployeeRevisionList, error) {
+	log.Println("ListEmployeeRevisions RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetEmployeeId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	// Deleted employees keep their history, so there is no existence check
+	cursor, err := s.revisionsCollection.Find(ctx, bson.M{"employee_id": oid},
+		options.Find().SetSort(bson.M{"revision": 1}),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve revisions: %v", err)
+	}
+
+	var revisions []EmployeeRevision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode revision: %v", err)
+	}
+
+	list := &pb.EmployeeRevisionList{}
+	for _, r := range revisions {
+		list.Revisions = append(list.Revisions, r.toProto())
+	}
+	return list, nil
+}
+
+// GetEmployeeRevision
+func (s *server) GetEmployeeRevision(ctx context.Context, req *pb.EmployeeRevisionRequest) (*pb.EmployeeRevision, error) {
+	log.Println("GetEmployeeRevision RPC called")
+
+	rev, err := s.findRevision(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rev.toProto(), nil
+}
+
+// RestoreEmployeeRevision
+func (s *server) RestoreEmployeeRevision(ctx context.Context, req *pb.EmployeeRevisionRequest) (*pb.Employee, error) {
+	log.Println("RestoreEmployeeRevision RPC called")
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Employee, error) {
+		return s.restoreRevision(ctx, req)
+	})
+}
+
+func (s *server) restoreRevision(ctx context.Context, req *pb.EmployeeRevisionRequest) (*pb.Employee, error) {
+	rev, err := s.findRevision(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	state := rev.After
+	if state == nil {
+		state = rev.Before
+	}
+	emp, err := snapshotToEmployee(state)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode revision: %v", err)
+	}
+	emp.ID = rev.EmployeeID
+
+	exists, err := s.employeesCollection.CountDocuments(ctx, bson.M{"_id": rev.EmployeeID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+	}
+
+	// An existing employee gets the recorded fields back as a normal update.
+	// Status is left alone since it only moves through the lifecycle RPCs.
+	if exists > 0 {
+		update := emp.toProto()
+		update.EffectiveDate = nil
+		return s.updateEmployee(ctx, update, actionRestore)
+	}
+
+	// A deleted employee is recreated under its old ID, provided the people
+	// and records it referenced are still there
+	if !emp.ManagerID.IsZero() {
+		if _, err := s.validateManager(ctx, rev.EmployeeID, emp.ManagerID.Hex()); err != nil {
+			return nil, err
+		}
+	}
+	if !emp.DepartmentID.IsZero() {
+		if emp.DepartmentID, emp.Department, err = s.resolveDepartment(ctx, &pb.Employee{DepartmentId: emp.DepartmentID.Hex()}); err != nil {
+			return nil, err
+		}
+	}
+	if !emp.PositionID.IsZero() {
+		if emp.PositionID, emp.Position, err = s.resolvePosition(ctx, &pb.Employee{PositionId: emp.PositionID.Hex()}); err != nil {
+			return nil, err
+		}
+	}
+
+	doc, err := toDocument(emp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode employee: %v", err)
+	}
+	if _, err := s.employeesCollection.InsertOne(ctx, doc); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to restore employee: %v", err)
+	}
+
+	now := time.Now().UTC()
+	delete(doc, "_id")
+	if err := s.insertVersion(ctx, rev.EmployeeID, now, nil, doc, true); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, rev.EmployeeID, actionRestore, nil, nil, doc); err != nil {
+		return nil, err
+	}
+
+	return emp.toProto(), nil
+}
+
+func (s *server) findRevision(ctx context.Context, req *pb.EmployeeRevisionRequest) (*EmployeeRevision, error) {
+	oid, err := primitive.ObjectIDFromHex(req.GetEmployeeId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	var rev EmployeeRevision
+	err = s.revisionsCollection.FindOne(ctx, bson.M{"employee_id": oid, "revision": req.GetRevision()}).Decode(&rev)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Revision %d not found for employee %s", req.GetRevision(), req.GetEmployeeId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve revision: %v", err)
+	}
+	return &rev, nil
+}
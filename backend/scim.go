@@ -0,0 +1,1777 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	scimUserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimEnterpriseSchema   = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	scimListSchema         = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimPatchSchema        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimProviderSchema     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimResourceTypeSchema = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	scimSchemaSchema       = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+
+	scimContentType = "application/scim+json"
+	scimBasePath    = "/scim/v2"
+
+	// Page size of list responses
+	scimDefaultCount = 100
+	scimMaxCount     = 200
+)
+
+// scimError is a gRPC error that carries the SCIM error type, such as
+// invalidFilter or uniqueness, for the error response
+func scimError(code codes.Code, scimType string, format string, args ...interface{}) error {
+	st := status.New(code, fmt.Sprintf(format, args...))
+	if withType, err := st.WithDetails(&errdetails.ErrorInfo{Domain: "scim", Reason: scimType}); err == nil {
+		st = withType
+	}
+	return st.Err()
+}
+
+type scimServer struct {
+	pb.UnimplementedScimServiceServer
+	// Writes go through the employee and department servers so they are
+	// validated, versioned and published like any other change
+	employees   *server
+	departments pb.DepartmentServiceServer
+}
+
+func NewScimServer(employees *server, departments pb.DepartmentServiceServer) pb.ScimServiceServer {
+	return &scimServer{employees: employees, departments: departments}
+}
+
+// GetServiceProviderConfig
+func (s *scimServer) GetServiceProviderConfig(ctx context.Context, req *pb.Empty) (*structpb.Struct, error) {
+	log.Println("GetServiceProviderConfig RPC called")
+
+	return scimStruct(map[string]interface{}{
+		"schemas":          []interface{}{scimProviderSchema},
+		"documentationUri": "/docs",
+		"patch":            map[string]interface{}{"supported": true},
+		"bulk":             map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]interface{}{"supported": true, "maxResults": scimMaxCount},
+		"changePassword":   map[string]interface{}{"supported": false},
+		"sort":             map[string]interface{}{"supported": true},
+		"etag":             map[string]interface{}{"supported": false},
+		"authenticationSchemes": []interface{}{map[string]interface{}{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "A bearer token whose tenant claim picks the tenant",
+			"primary":     true,
+		}},
+		"meta": map[string]interface{}{
+			"resourceType": "ServiceProviderConfig",
+			"location":     scimBasePath + "/ServiceProviderConfig",
+		},
+	})
+}
+
+// ListResourceTypes
+func (s *scimServer) ListResourceTypes(ctx context.Context, req *pb.Empty) (*structpb.Struct, error) {
+	log.Println("ListResourceTypes RPC called")
+
+	resourceType := func(name, endpoint, schema string, extensions ...string) interface{} {
+		t := map[string]interface{}{
+			"schemas":     []interface{}{scimResourceTypeSchema},
+			"id":          name,
+			"name":        name,
+			"endpoint":    "/" + endpoint,
+			"description": name,
+			"schema":      schema,
+			"meta": map[string]interface{}{
+				"resourceType": "ResourceType",
+				"location":     scimBasePath + "/ResourceTypes/" + name,
+			},
+		}
+		var ext []interface{}
+		for _, e := range extensions {
+			ext = append(ext, map[string]interface{}{"schema": e, "required": false})
+		}
+		if ext != nil {
+			t["schemaExtensions"] = ext
+		}
+		return t
+	}
+	return scimStruct(scimListResponse([]interface{}{
+		resourceType("User", "Users", scimUserSchema, scimEnterpriseSchema),
+		resourceType("Group", "Groups", scimGroupSchema),
+	}, 2, 1))
+}
+
+// scimAttributeDef describes an attribute in the Schemas endpoint
+type scimAttributeDef struct {
+	name       string
+	typ        string
+	multi      bool
+	required   bool
+	caseExact  bool
+	mutability string
+	uniqueness string
+	sub        []scimAttributeDef
+}
+
+func (a scimAttributeDef) toMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"name":        a.name,
+		"type":        a.typ,
+		"multiValued": a.multi,
+		"required":    a.required,
+		"caseExact":   a.caseExact,
+		"mutability":  "readWrite",
+		"returned":    "default",
+		"uniqueness":  "none",
+	}
+	if a.mutability != "" {
+		m["mutability"] = a.mutability
+	}
+	if a.uniqueness != "" {
+		m["uniqueness"] = a.uniqueness
+	}
+	if a.sub != nil {
+		var sub []interface{}
+		for _, s := range a.sub {
+			sub = append(sub, s.toMap())
+		}
+		m["subAttributes"] = sub
+	}
+	return m
+}
+
+var scimSchemas = []struct {
+	id, name   string
+	attributes []scimAttributeDef
+}{
+	{scimUserSchema, "User", []scimAttributeDef{
+		{name: "userName", typ: "string", required: true, uniqueness: "server"},
+		{name: "name", typ: "complex", sub: []scimAttributeDef{
+			{name: "givenName", typ: "string"},
+			{name: "familyName", typ: "string"},
+			{name: "formatted", typ: "string", mutability: "readOnly"},
+		}},
+		{name: "displayName", typ: "string", mutability: "readOnly"},
+		{name: "title", typ: "string"},
+		{name: "active", typ: "boolean"},
+		{name: "emails", typ: "complex", multi: true, sub: []scimAttributeDef{
+			{name: "value", typ: "string"},
+			{name: "type", typ: "string"},
+			{name: "primary", typ: "boolean"},
+		}},
+		{name: "groups", typ: "complex", multi: true, mutability: "readOnly", sub: []scimAttributeDef{
+			{name: "value", typ: "string", mutability: "readOnly"},
+			{name: "$ref", typ: "reference", mutability: "readOnly"},
+			{name: "display", typ: "string", mutability: "readOnly"},
+		}},
+	}},
+	{scimEnterpriseSchema, "EnterpriseUser", []scimAttributeDef{
+		{name: "department", typ: "string"},
+		{name: "costCenter", typ: "string", mutability: "readOnly"},
+		{name: "manager", typ: "complex", sub: []scimAttributeDef{
+			{name: "value", typ: "string"},
+			{name: "$ref", typ: "reference", mutability: "readOnly"},
+			{name: "displayName", typ: "string", mutability: "readOnly"},
+		}},
+	}},
+	{scimGroupSchema, "Group", []scimAttributeDef{
+		{name: "displayName", typ: "string", required: true},
+		{name: "members", typ: "complex", multi: true, sub: []scimAttributeDef{
+			{name: "value", typ: "string", mutability: "immutable"},
+			{name: "$ref", typ: "reference", mutability: "immutable"},
+			{name: "display", typ: "string", mutability: "readOnly"},
+			{name: "type", typ: "string", mutability: "immutable"},
+		}},
+	}},
+}
+
+// ListSchemas
+func (s *scimServer) ListSchemas(ctx context.Context, req *pb.Empty) (*structpb.Struct, error) {
+	log.Println("ListSchemas RPC called")
+
+	var schemas []interface{}
+	for _, sc := range scimSchemas {
+		var attrs []interface{}
+		for _, a := range sc.attributes {
+			attrs = append(attrs, a.toMap())
+		}
+		schemas = append(schemas, map[string]interface{}{
+			"schemas":    []interface{}{scimSchemaSchema},
+			"id":         sc.id,
+			"name":       sc.name,
+			"attributes": attrs,
+			"meta": map[string]interface{}{
+				"resourceType": "Schema",
+				"location":     scimBasePath + "/Schemas/" + sc.id,
+			},
+		})
+	}
+	return scimStruct(scimListResponse(schemas, len(schemas), 1))
+}
+
+// Kinds of attributes filters and sorting work on
+const (
+	// Case-insensitive strings
+	scimString = iota
+	// Case-sensitive strings
+	scimExact
+	scimObjectID
+	// The active flag, worked out from the employment status
+	scimActive
+	// The same value for every resource, like emails.type
+	scimConstant
+	// Group members, found through the employees' department
+	scimMember
+)
+
+type scimAttribute struct {
+	path     string
+	kind     int
+	constant interface{}
+}
+
+// scimAttrKey normalizes an attribute path for the attribute tables
+func scimAttrKey(attr string) string {
+	return strings.ToLower(strings.Join(scimPath(attr), "."))
+}
+
+var scimEnterpriseKey = strings.ToLower(scimEnterpriseSchema)
+
+var scimUserAttributes = map[string]scimAttribute{
+	"id":                                 {path: "_id", kind: scimObjectID},
+	"externalid":                         {path: "external_id", kind: scimExact},
+	"username":                           {path: "email"},
+	"emails":                             {path: "email"},
+	"emails.value":                       {path: "email"},
+	"emails.type":                        {kind: scimConstant, constant: "work"},
+	"emails.primary":                     {kind: scimConstant, constant: true},
+	"name.givenname":                     {path: "first_name"},
+	"name.familyname":                    {path: "last_name"},
+	"title":                              {path: "position"},
+	"active":                             {path: "status", kind: scimActive},
+	"groups":                             {path: "department_id", kind: scimObjectID},
+	"groups.value":                       {path: "department_id", kind: scimObjectID},
+	"groups.display":                     {path: "department"},
+	scimEnterpriseKey + ".department":    {path: "department"},
+	scimEnterpriseKey + ".manager":       {path: "manager_id", kind: scimObjectID},
+	scimEnterpriseKey + ".manager.value": {path: "manager_id", kind: scimObjectID},
+	"meta.resourcetype":                  {kind: scimConstant, constant: "User"},
+}
+
+var scimGroupAttributes = map[string]scimAttribute{
+	"id":                {path: "_id", kind: scimObjectID},
+	"externalid":        {path: "external_id", kind: scimExact},
+	"displayname":       {path: "name"},
+	"members":           {kind: scimMember},
+	"members.value":     {kind: scimMember},
+	"meta.resourcetype": {kind: scimConstant, constant: "Group"},
+}
+
+// scimNone matches no document
+var scimNone = bson.M{"_id": bson.M{"$exists": false}}
+
+// scimQuery compiles SCIM filters into MongoDB filters
+type scimQuery struct {
+	ctx        context.Context
+	attributes map[string]scimAttribute
+	employees  *mongo.Collection
+}
+
+func (q scimQuery) compile(f *scimFilter, prefix string) (bson.M, error) {
+	switch f.op {
+	case "and", "or":
+		left, err := q.compile(f.left, prefix)
+		if err != nil {
+			return nil, err
+		}
+		right, err := q.compile(f.right, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$" + f.op: bson.A{left, right}}, nil
+	case "not":
+		inner, err := q.compile(f.left, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$nor": bson.A{inner}}, nil
+	case "[]":
+		return q.compile(f.left, prefix+f.attr+".")
+	}
+
+	attr, ok := q.attributes[scimAttrKey(prefix+f.attr)]
+	if !ok {
+		return nil, scimError(codes.InvalidArgument, "invalidFilter", "Filtering on %s is not supported", prefix+f.attr)
+	}
+
+	switch attr.kind {
+	case scimConstant:
+		if (f.op == "pr" && attr.constant != nil) || scimCompare(f.op, attr.constant, f.value) {
+			return bson.M{}, nil
+		}
+		return scimNone, nil
+
+	case scimActive:
+		active := bson.A{statusActive, statusOnLeave, nil}
+		switch want, _ := f.value.(bool); {
+		case f.op == "pr":
+			return bson.M{}, nil
+		case f.op == "eq" && want, f.op == "ne" && !want:
+			return bson.M{attr.path: bson.M{"$in": active}}, nil
+		case f.op == "eq" || f.op == "ne":
+			return bson.M{attr.path: bson.M{"$nin": active}}, nil
+		}
+
+	case scimObjectID:
+		if f.op == "pr" {
+			return bson.M{attr.path: bson.M{"$exists": true}}, nil
+		}
+		s, _ := f.value.(string)
+		oid, err := primitive.ObjectIDFromHex(s)
+		switch {
+		case f.op == "eq" && err != nil:
+			return scimNone, nil
+		case f.op == "eq":
+			return bson.M{attr.path: oid}, nil
+		case f.op == "ne" && err != nil:
+			return bson.M{}, nil
+		case f.op == "ne":
+			return bson.M{attr.path: bson.M{"$ne": oid}}, nil
+		}
+
+	case scimMember:
+		return q.members(f)
+
+	default:
+		if f.op == "pr" {
+			return bson.M{attr.path: bson.M{"$exists": true, "$nin": bson.A{nil, ""}}}, nil
+		}
+		s, ok := f.value.(string)
+		if !ok {
+			return nil, scimError(codes.InvalidArgument, "invalidFilter", "%s compares with strings", f.attr)
+		}
+		options := "i"
+		if attr.kind == scimExact {
+			options = ""
+		}
+		quoted := regexp.QuoteMeta(s)
+		switch f.op {
+		case "eq":
+			if attr.kind == scimExact {
+				return bson.M{attr.path: s}, nil
+			}
+			return bson.M{attr.path: primitive.Regex{Pattern: "^" + quoted + "$", Options: options}}, nil
+		case "ne":
+			if attr.kind == scimExact {
+				return bson.M{attr.path: bson.M{"$ne": s}}, nil
+			}
+			return bson.M{attr.path: bson.M{"$not": primitive.Regex{Pattern: "^" + quoted + "$", Options: options}}}, nil
+		case "co":
+			return bson.M{attr.path: primitive.Regex{Pattern: quoted, Options: options}}, nil
+		case "sw":
+			return bson.M{attr.path: primitive.Regex{Pattern: "^" + quoted, Options: options}}, nil
+		case "ew":
+			return bson.M{attr.path: primitive.Regex{Pattern: quoted + "$", Options: options}}, nil
+		case "gt", "ge", "lt", "le":
+			op := map[string]string{"gt": "$gt", "ge": "$gte", "lt": "$lt", "le": "$lte"}[f.op]
+			return bson.M{attr.path: bson.M{op: s}}, nil
+		}
+	}
+	return nil, scimError(codes.InvalidArgument, "invalidFilter", "%s does not support the %s operator", f.attr, f.op)
+}
+
+// members finds the groups with a member, by the member's department
+func (q scimQuery) members(f *scimFilter) (bson.M, error) {
+	switch f.op {
+	case "pr":
+		ids, err := q.employees.Distinct(q.ctx, "department_id", bson.M{"department_id": bson.M{"$exists": true}})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve departments: %v", err)
+		}
+		return bson.M{"_id": bson.M{"$in": ids}}, nil
+	case "eq":
+		s, _ := f.value.(string)
+		oid, err := primitive.ObjectIDFromHex(s)
+		if err != nil {
+			return scimNone, nil
+		}
+		var emp Employee
+		err = q.employees.FindOne(q.ctx, bson.M{"_id": oid}).Decode(&emp)
+		if err == mongo.ErrNoDocuments || (err == nil && emp.DepartmentID.IsZero()) {
+			return scimNone, nil
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+		}
+		return bson.M{"_id": emp.DepartmentID}, nil
+	}
+	return nil, scimError(codes.InvalidArgument, "invalidFilter", "members only support the eq and pr operators")
+}
+
+// find runs a list request against coll: the filter, sort order and page
+func (q scimQuery) find(coll *mongo.Collection, req *pb.ScimListRequest, results interface{}) (int64, int64, error) {
+	filter := bson.M{}
+	if strings.TrimSpace(req.GetFilter()) != "" {
+		f, err := parseScimFilter(req.GetFilter())
+		if err != nil {
+			return 0, 0, scimError(codes.InvalidArgument, "invalidFilter", "Invalid filter: %v", err)
+		}
+		if filter, err = q.compile(f, ""); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	start := int64(req.GetStartIndex())
+	if start < 1 {
+		start = 1
+	}
+	count := int64(scimDefaultCount)
+	if req.Count != nil {
+		count = int64(req.GetCount())
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+
+	total, err := coll.CountDocuments(q.ctx, filter)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "Failed to count resources: %v", err)
+	}
+	if count == 0 {
+		return total, start, nil
+	}
+
+	order := 1
+	if strings.EqualFold(req.GetSortOrder(), "descending") {
+		order = -1
+	}
+	sort := bson.D{}
+	if req.GetSortBy() != "" {
+		attr, ok := q.attributes[scimAttrKey(req.GetSortBy())]
+		if !ok || attr.path == "" {
+			return 0, 0, scimError(codes.InvalidArgument, "invalidValue", "Sorting by %s is not supported", req.GetSortBy())
+		}
+		if attr.path != "_id" {
+			sort = append(sort, bson.E{Key: attr.path, Value: order})
+		}
+	}
+	sort = append(sort, bson.E{Key: "_id", Value: order})
+
+	cursor, err := coll.Find(q.ctx, filter, options.Find().SetSort(sort).SetSkip(start-1).SetLimit(count))
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "Failed to retrieve resources: %v", err)
+	}
+	if err := cursor.All(q.ctx, results); err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "Failed to decode resource: %v", err)
+	}
+	return total, start, nil
+}
+
+// scimListResponse wraps a page of resources
+func scimListResponse(resources []interface{}, total int, start int64) map[string]interface{} {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return map[string]interface{}{
+		"schemas":      []interface{}{scimListSchema},
+		"totalResults": total,
+		"startIndex":   start,
+		"itemsPerPage": len(resources),
+		"Resources":    resources,
+	}
+}
+
+// scimStruct converts a resource to its API message
+func scimStruct(res map[string]interface{}) (*structpb.Struct, error) {
+	st, err := structpb.NewStruct(res)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode resource: %v", err)
+	}
+	return st, nil
+}
+
+// scimUser is the SCIM representation of an employee
+func scimUser(e *pb.Employee, costCenter, managerName string) map[string]interface{} {
+	formatted := strings.TrimSpace(e.GetFirstName() + " " + e.GetLastName())
+	user := map[string]interface{}{
+		"schemas":  []interface{}{scimUserSchema, scimEnterpriseSchema},
+		"id":       e.GetId(),
+		"userName": e.GetEmail(),
+		"name": map[string]interface{}{
+			"givenName":  e.GetFirstName(),
+			"familyName": e.GetLastName(),
+			"formatted":  formatted,
+		},
+		"displayName": formatted,
+		"active":      scimIsActive(e.GetStatus()),
+		"meta": map[string]interface{}{
+			"resourceType": "User",
+			"location":     scimBasePath + "/Users/" + e.GetId(),
+		},
+	}
+	if oid, err := primitive.ObjectIDFromHex(e.GetId()); err == nil {
+		user["meta"].(map[string]interface{})["created"] = oid.Timestamp().UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if e.GetExternalId() != "" {
+		user["externalId"] = e.GetExternalId()
+	}
+	if e.GetEmail() != "" {
+		user["emails"] = []interface{}{map[string]interface{}{"value": e.GetEmail(), "type": "work", "primary": true}}
+	}
+	if e.GetPosition() != "" {
+		user["title"] = e.GetPosition()
+	}
+
+	enterprise := map[string]interface{}{}
+	if e.GetDepartmentId() != "" {
+		user["groups"] = []interface{}{map[string]interface{}{
+			"value":   e.GetDepartmentId(),
+			"display": e.GetDepartment(),
+			"$ref":    scimBasePath + "/Groups/" + e.GetDepartmentId(),
+		}}
+	}
+	if e.GetDepartment() != "" {
+		enterprise["department"] = e.GetDepartment()
+	}
+	if costCenter != "" {
+		enterprise["costCenter"] = costCenter
+	}
+	if e.GetManagerId() != "" {
+		enterprise["manager"] = map[string]interface{}{
+			"value":       e.GetManagerId(),
+			"displayName": managerName,
+			"$ref":        scimBasePath + "/Users/" + e.GetManagerId(),
+		}
+	}
+	user[scimEnterpriseSchema] = enterprise
+	return user
+}
+
+// scimIsActive tells whether an employee may sign in: those who work here
+// now, including on leave. Employees without a status predate the
+// lifecycle and are active.
+func scimIsActive(st pb.EmploymentStatus) bool {
+	switch st {
+	case pb.EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED, pb.EmploymentStatus_ACTIVE, pb.EmploymentStatus_ON_LEAVE:
+		return true
+	}
+	return false
+}
+
+// userResources converts employees to SCIM users, looking up the cost
+// centers of their departments and the names of their managers
+func (s *scimServer) userResources(ctx context.Context, emps []*pb.Employee) ([]map[string]interface{}, error) {
+	var deptIDs, managerIDs bson.A
+	for _, e := range emps {
+		if oid, err := primitive.ObjectIDFromHex(e.GetDepartmentId()); err == nil {
+			deptIDs = append(deptIDs, oid)
+		}
+		if oid, err := primitive.ObjectIDFromHex(e.GetManagerId()); err == nil {
+			managerIDs = append(managerIDs, oid)
+		}
+	}
+
+	costCenters := map[string]string{}
+	if len(deptIDs) > 0 {
+		cursor, err := s.employees.departmentsCollection.Find(ctx, bson.M{"_id": bson.M{"$in": deptIDs}})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve departments: %v", err)
+		}
+		var depts []Department
+		if err := cursor.All(ctx, &depts); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode department: %v", err)
+		}
+		for _, d := range depts {
+			costCenters[d.ID.Hex()] = d.CostCenter
+		}
+	}
+
+	managers := map[string]string{}
+	if len(managerIDs) > 0 {
+		cursor, err := s.employees.employeesCollection.Find(ctx, bson.M{"_id": bson.M{"$in": managerIDs}},
+			options.Find().SetProjection(bson.M{"first_name": 1, "last_name": 1}))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+		}
+		var found []Employee
+		if err := cursor.All(ctx, &found); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+		}
+		for _, m := range found {
+			managers[m.ID.Hex()] = strings.TrimSpace(m.FirstName + " " + m.LastName)
+		}
+	}
+
+	var users []map[string]interface{}
+	for _, e := range emps {
+		users = append(users, scimUser(e, costCenters[e.GetDepartmentId()], managers[e.GetManagerId()]))
+	}
+	return users, nil
+}
+
+// userResource is one employee as a SCIM user
+func (s *scimServer) userResource(ctx context.Context, e *pb.Employee) (map[string]interface{}, error) {
+	users, err := s.userResources(ctx, []*pb.Employee{e})
+	if err != nil {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+// ListUsers
+func (s *scimServer) ListUsers(ctx context.Context, req *pb.ScimListRequest) (*structpb.Struct, error) {
+	log.Println("ListUsers RPC called")
+
+	q := scimQuery{ctx: ctx, attributes: scimUserAttributes, employees: s.employees.employeesCollection}
+	var found []Employee
+	total, start, err := q.find(s.employees.employeesCollection, req, &found)
+	if err != nil {
+		return nil, err
+	}
+	var emps []*pb.Employee
+	for _, e := range found {
+		emps = append(emps, e.toProto())
+	}
+	users, err := s.userResources(ctx, emps)
+	if err != nil {
+		return nil, err
+	}
+	var resources []interface{}
+	for _, u := range users {
+		resources = append(resources, scimSelect(u, req.GetAttributes(), req.GetExcludedAttributes()))
+	}
+	return scimStruct(scimListResponse(resources, int(total), start))
+}
+
+// employee loads the current version of an employee for a SCIM request
+func (s *scimServer) employee(ctx context.Context, id string) (*pb.Employee, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, scimError(codes.NotFound, "", "User not found: %s", id)
+	}
+	emp, err := s.employees.GetEmployee(ctx, &pb.GetEmployeeRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, scimError(codes.NotFound, "", "User not found: %s", id)
+	}
+	return emp, err
+}
+
+// GetUser
+func (s *scimServer) GetUser(ctx context.Context, req *pb.ScimGetRequest) (*structpb.Struct, error) {
+	log.Println("GetUser RPC called")
+
+	emp, err := s.employee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return s.userResponse(ctx, emp, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+func (s *scimServer) userResponse(ctx context.Context, emp *pb.Employee, attributes, excluded string) (*structpb.Struct, error) {
+	user, err := s.userResource(ctx, emp)
+	if err != nil {
+		return nil, err
+	}
+	return scimStruct(scimSelect(user, attributes, excluded))
+}
+
+// CreateUser
+func (s *scimServer) CreateUser(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("CreateUser RPC called")
+
+	res := req.GetResource().AsMap()
+	emp := &pb.Employee{}
+	active, err := applyScimUser(res, emp, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUserName(ctx, emp.GetEmail(), primitive.NilObjectID); err != nil {
+		return nil, err
+	}
+	if active != nil && !*active {
+		emp.Status = pb.EmploymentStatus_CANDIDATE
+	}
+
+	created, err := s.employees.CreateEmployee(ctx, emp)
+	if err != nil {
+		return nil, err
+	}
+	scimCreated(ctx, scimBasePath+"/Users/"+created.GetId())
+	return s.userResponse(ctx, created, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// ReplaceUser
+func (s *scimServer) ReplaceUser(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("ReplaceUser RPC called")
+
+	emp, err := s.employee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.updateUser(ctx, emp, req.GetResource().AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return s.userResponse(ctx, updated, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// PatchUser
+func (s *scimServer) PatchUser(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("PatchUser RPC called")
+
+	ops, err := scimPatchOps(req.GetResource().AsMap())
+	if err != nil {
+		return nil, err
+	}
+	emp, err := s.employee(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userResource(ctx, emp)
+	if err != nil {
+		return nil, err
+	}
+	// Round trip through JSON so the resource holds plain JSON values, like
+	// the ones in the patch
+	if user, err = scimJSON(user); err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if err := scimApplyPatch(user, op); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.updateUser(ctx, emp, user)
+	if err != nil {
+		return nil, err
+	}
+	return s.userResponse(ctx, updated, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// updateUser writes the attributes of res to the employee, then changes
+// their employment if active changed
+func (s *scimServer) updateUser(ctx context.Context, emp *pb.Employee, res map[string]interface{}) (*pb.Employee, error) {
+	before := proto.Clone(emp).(*pb.Employee)
+	active, err := applyScimUser(res, emp, false)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(emp.GetEmail(), before.GetEmail()) {
+		oid, _ := primitive.ObjectIDFromHex(emp.GetId())
+		if err := s.checkUserName(ctx, emp.GetEmail(), oid); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unchanged resources are not written, so they do not add revisions
+	if !proto.Equal(emp, before) {
+		emp.EffectiveDate = nil
+		if emp, err = s.employees.UpdateEmployee(ctx, emp); err != nil {
+			return nil, err
+		}
+	}
+	if active != nil && *active != scimIsActive(emp.GetStatus()) {
+		return s.setActive(ctx, emp, *active)
+	}
+	return emp, nil
+}
+
+// setActive moves the employee through the lifecycle: deactivating
+// suspends, activating reinstates, rehires, hires or starts them
+func (s *scimServer) setActive(ctx context.Context, emp *pb.Employee, active bool) (*pb.Employee, error) {
+	var t *transition
+	reason := "Deactivated through SCIM"
+	if active {
+		reason = "Activated through SCIM"
+		switch emp.GetStatus() {
+		case pb.EmploymentStatus_SUSPENDED:
+			t = &reinstateTransition
+		case pb.EmploymentStatus_TERMINATED:
+			t = &rehireTransition
+		case pb.EmploymentStatus_CANDIDATE:
+			t = &hireTransition
+		case pb.EmploymentStatus_PRE_BOARDING:
+			t = &startTransition
+		}
+	} else {
+		switch emp.GetStatus() {
+		case pb.EmploymentStatus_ACTIVE, pb.EmploymentStatus_ON_LEAVE, pb.EmploymentStatus_EMPLOYMENT_STATUS_UNSPECIFIED:
+			t = &suspendTransition
+		}
+	}
+	if t == nil {
+		return emp, nil
+	}
+	return s.employees.changeEmployment(ctx, &pb.EmploymentChangeRequest{Id: emp.GetId(), Reason: reason}, *t)
+}
+
+// checkUserName makes sure no other employee has the email
+func (s *scimServer) checkUserName(ctx context.Context, email string, except primitive.ObjectID) error {
+	filter := bson.M{"email": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(email) + "$", Options: "i"}}
+	if !except.IsZero() {
+		filter["_id"] = bson.M{"$ne": except}
+	}
+	n, err := s.employees.employeesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to check userName: %v", err)
+	}
+	if n > 0 {
+		return scimError(codes.AlreadyExists, "uniqueness", "userName %s is already in use", email)
+	}
+	return nil
+}
+
+// applyScimUser copies the attributes of a SCIM user onto an employee.
+// Attributes left out keep their values and null clears them. It returns
+// the requested active flag, if any.
+func applyScimUser(res map[string]interface{}, emp *pb.Employee, create bool) (*bool, error) {
+	str := func(v interface{}, attr string) (string, error) {
+		switch v := v.(type) {
+		case nil:
+			return "", nil
+		case string:
+			return strings.TrimSpace(v), nil
+		}
+		return "", scimError(codes.InvalidArgument, "invalidValue", "%s must be a string", attr)
+	}
+
+	userName, hasUserName := scimLookup(res, "userName")
+	email, err := str(userName, "userName")
+	if err != nil {
+		return nil, err
+	}
+	// A changed primary email counts when userName stays the same
+	if v, ok := scimLookup(res, "emails"); ok && (!hasUserName || strings.EqualFold(email, emp.GetEmail())) {
+		if primary := scimPrimaryEmail(v); primary != "" {
+			email, hasUserName = primary, true
+		}
+	}
+	if hasUserName {
+		if email == "" {
+			return nil, scimError(codes.InvalidArgument, "invalidValue", "userName is required")
+		}
+		emp.Email = email
+	} else if create {
+		return nil, scimError(codes.InvalidArgument, "invalidValue", "userName is required")
+	}
+
+	if v, ok := scimLookup(res, "name.givenName"); ok {
+		if emp.FirstName, err = str(v, "name.givenName"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := scimLookup(res, "name.familyName"); ok {
+		if emp.LastName, err = str(v, "name.familyName"); err != nil {
+			return nil, err
+		}
+	}
+	// Identity providers without name sub-attributes send a display name
+	if _, ok := scimLookup(res, "name"); !ok && create {
+		if v, ok := scimLookup(res, "displayName"); ok {
+			display, err := str(v, "displayName")
+			if err != nil {
+				return nil, err
+			}
+			emp.FirstName, emp.LastName, _ = strings.Cut(display, " ")
+		}
+	}
+
+	if v, ok := scimLookup(res, "externalId"); ok {
+		if emp.ExternalId, err = str(v, "externalId"); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new title is looked up among the positions
+	if v, ok := scimLookup(res, "title"); ok {
+		title, err := str(v, "title")
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(title, emp.GetPosition()) {
+			emp.Position, emp.PositionId = title, ""
+		}
+	}
+
+	if v, ok := scimLookup(res, scimEnterpriseSchema+":department"); ok {
+		dept, err := str(v, "department")
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(dept, emp.GetDepartment()) {
+			emp.Department, emp.DepartmentId = dept, ""
+		}
+	}
+	if v, ok := scimLookup(res, scimEnterpriseSchema+":manager"); ok {
+		if m, isMap := v.(map[string]interface{}); isMap {
+			v, _ = scimGet(m, "value")
+		}
+		if emp.ManagerId, err = str(v, "manager"); err != nil {
+			return nil, err
+		}
+	}
+
+	v, ok := scimLookup(res, "active")
+	if !ok || v == nil {
+		return nil, nil
+	}
+	switch a := v.(type) {
+	case bool:
+		return &a, nil
+	case string:
+		// Some identity providers send booleans as strings in PATCH
+		if b, err := strconv.ParseBool(a); err == nil {
+			return &b, nil
+		}
+	}
+	return nil, scimError(codes.InvalidArgument, "invalidValue", "active must be a boolean")
+}
+
+// scimPrimaryEmail picks the email to keep: the last primary one, else the
+// last work one, else the first
+func scimPrimaryEmail(v interface{}) string {
+	var first, work, primary string
+	for _, el := range scimValues(v) {
+		m, ok := el.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := scimGet(m, "value")
+		s, _ := value.(string)
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if first == "" {
+			first = s
+		}
+		if t, _ := scimGet(m, "type"); t == "work" {
+			work = s
+		}
+		if p, _ := scimGet(m, "primary"); p == true {
+			primary = s
+		}
+	}
+	switch {
+	case primary != "":
+		return primary
+	case work != "":
+		return work
+	}
+	return first
+}
+
+// DeleteUser
+func (s *scimServer) DeleteUser(ctx context.Context, req *pb.ScimGetRequest) (*pb.Empty, error) {
+	log.Println("DeleteUser RPC called")
+
+	if _, err := s.employee(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	if _, err := s.employees.DeleteEmployee(ctx, &pb.EmployeeID{Id: req.GetId()}); err != nil {
+		return nil, err
+	}
+	scimNoContent(ctx)
+	return &pb.Empty{}, nil
+}
+
+// groupResources converts departments to SCIM groups, with their members
+// unless they are left out
+func (s *scimServer) groupResources(ctx context.Context, depts []*pb.Department, withMembers bool) ([]map[string]interface{}, error) {
+	members := map[string][]interface{}{}
+	if withMembers && len(depts) > 0 {
+		var ids bson.A
+		for _, d := range depts {
+			if oid, err := primitive.ObjectIDFromHex(d.GetId()); err == nil {
+				ids = append(ids, oid)
+			}
+		}
+		cursor, err := s.employees.employeesCollection.Find(ctx, bson.M{"department_id": bson.M{"$in": ids}},
+			options.Find().SetProjection(bson.M{"first_name": 1, "last_name": 1, "department_id": 1}).SetSort(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+		}
+		var found []Employee
+		if err := cursor.All(ctx, &found); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+		}
+		for _, e := range found {
+			dept := e.DepartmentID.Hex()
+			members[dept] = append(members[dept], map[string]interface{}{
+				"value":   e.ID.Hex(),
+				"display": strings.TrimSpace(e.FirstName + " " + e.LastName),
+				"type":    "User",
+				"$ref":    scimBasePath + "/Users/" + e.ID.Hex(),
+			})
+		}
+	}
+
+	var groups []map[string]interface{}
+	for _, d := range depts {
+		group := map[string]interface{}{
+			"schemas":     []interface{}{scimGroupSchema},
+			"id":          d.GetId(),
+			"displayName": d.GetName(),
+			"meta": map[string]interface{}{
+				"resourceType": "Group",
+				"location":     scimBasePath + "/Groups/" + d.GetId(),
+			},
+		}
+		if d.GetExternalId() != "" {
+			group["externalId"] = d.GetExternalId()
+		}
+		if withMembers {
+			group["members"] = members[d.GetId()]
+			if group["members"] == nil {
+				group["members"] = []interface{}{}
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// scimWantsMembers tells whether a response includes the members, which
+// identity providers often leave out of lists of large groups
+func scimWantsMembers(attributes, excluded string) bool {
+	if attributes != "" {
+		return scimListed(attributes, "members")
+	}
+	return !scimListed(excluded, "members")
+}
+
+func scimListed(list, attr string) bool {
+	for _, a := range strings.Split(list, ",") {
+		key := scimAttrKey(strings.TrimSpace(a))
+		if key == attr || strings.HasPrefix(key, attr+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ListGroups
+func (s *scimServer) ListGroups(ctx context.Context, req *pb.ScimListRequest) (*structpb.Struct, error) {
+	log.Println("ListGroups RPC called")
+
+	q := scimQuery{ctx: ctx, attributes: scimGroupAttributes, employees: s.employees.employeesCollection}
+	var found []Department
+	total, start, err := q.find(s.employees.departmentsCollection, req, &found)
+	if err != nil {
+		return nil, err
+	}
+	var depts []*pb.Department
+	for _, d := range found {
+		depts = append(depts, d.toProto())
+	}
+	groups, err := s.groupResources(ctx, depts, scimWantsMembers(req.GetAttributes(), req.GetExcludedAttributes()))
+	if err != nil {
+		return nil, err
+	}
+	var resources []interface{}
+	for _, g := range groups {
+		resources = append(resources, scimSelect(g, req.GetAttributes(), req.GetExcludedAttributes()))
+	}
+	return scimStruct(scimListResponse(resources, int(total), start))
+}
+
+// department loads a department for a SCIM request
+func (s *scimServer) department(ctx context.Context, id string) (*pb.Department, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, scimError(codes.NotFound, "", "Group not found: %s", id)
+	}
+	dept, err := s.departments.GetDepartment(ctx, &pb.DepartmentID{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, scimError(codes.NotFound, "", "Group not found: %s", id)
+	}
+	return dept, err
+}
+
+func (s *scimServer) groupResponse(ctx context.Context, dept *pb.Department, attributes, excluded string) (*structpb.Struct, error) {
+	groups, err := s.groupResources(ctx, []*pb.Department{dept}, scimWantsMembers(attributes, excluded))
+	if err != nil {
+		return nil, err
+	}
+	return scimStruct(scimSelect(groups[0], attributes, excluded))
+}
+
+// GetGroup
+func (s *scimServer) GetGroup(ctx context.Context, req *pb.ScimGetRequest) (*structpb.Struct, error) {
+	log.Println("GetGroup RPC called")
+
+	dept, err := s.department(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return s.groupResponse(ctx, dept, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+var scimCodeSeparators = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// scimGroupCode makes a department code from a group's display name
+func scimGroupCode(name string) string {
+	code := strings.Trim(scimCodeSeparators.ReplaceAllString(strings.ToUpper(name), "-"), "-")
+	if len(code) > 32 {
+		code = strings.TrimRight(code[:32], "-")
+	}
+	if code == "" {
+		code = "GROUP"
+	}
+	return code
+}
+
+// CreateGroup
+func (s *scimServer) CreateGroup(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("CreateGroup RPC called")
+
+	res := req.GetResource().AsMap()
+	dept := &pb.Department{}
+	members, err := applyScimGroup(res, dept)
+	if err != nil {
+		return nil, err
+	}
+	if dept.GetName() == "" {
+		return nil, scimError(codes.InvalidArgument, "invalidValue", "displayName is required")
+	}
+	existing, err := findDepartment(ctx, s.employees.departmentsCollection, dept.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && strings.EqualFold(existing.Name, dept.GetName()) {
+		return nil, scimError(codes.AlreadyExists, "uniqueness", "A group named %s already exists", dept.GetName())
+	}
+	dept.Code = scimGroupCode(dept.GetName())
+
+	created, err := s.departments.CreateDepartment(ctx, dept)
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, scimError(codes.AlreadyExists, "uniqueness", "Department code %s made from the displayName is already in use", dept.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if members != nil {
+		if err := s.setMembers(ctx, created.GetId(), members, false); err != nil {
+			return nil, err
+		}
+	}
+	scimCreated(ctx, scimBasePath+"/Groups/"+created.GetId())
+	return s.groupResponse(ctx, created, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// ReplaceGroup
+func (s *scimServer) ReplaceGroup(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("ReplaceGroup RPC called")
+
+	dept, err := s.department(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.updateGroup(ctx, dept, req.GetResource().AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return s.groupResponse(ctx, updated, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// PatchGroup
+func (s *scimServer) PatchGroup(ctx context.Context, req *pb.ScimResourceRequest) (*structpb.Struct, error) {
+	log.Println("PatchGroup RPC called")
+
+	ops, err := scimPatchOps(req.GetResource().AsMap())
+	if err != nil {
+		return nil, err
+	}
+	dept, err := s.department(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.groupResources(ctx, []*pb.Department{dept}, true)
+	if err != nil {
+		return nil, err
+	}
+	group, err := scimJSON(groups[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if err := scimApplyPatch(group, op); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.updateGroup(ctx, dept, group)
+	if err != nil {
+		return nil, err
+	}
+	return s.groupResponse(ctx, updated, req.GetAttributes(), req.GetExcludedAttributes())
+}
+
+// updateGroup writes the attributes of res to the department and moves
+// employees in and out of it to match the members
+func (s *scimServer) updateGroup(ctx context.Context, dept *pb.Department, res map[string]interface{}) (*pb.Department, error) {
+	before := proto.Clone(dept).(*pb.Department)
+	members, err := applyScimGroup(res, dept)
+	if err != nil {
+		return nil, err
+	}
+	if dept.GetName() == "" {
+		return nil, scimError(codes.InvalidArgument, "invalidValue", "displayName is required")
+	}
+	if !proto.Equal(dept, before) {
+		if dept, err = s.departments.UpdateDepartment(ctx, dept); err != nil {
+			return nil, err
+		}
+	}
+	if members != nil {
+		if err := s.setMembers(ctx, dept.GetId(), members, true); err != nil {
+			return nil, err
+		}
+	}
+	return dept, nil
+}
+
+// applyScimGroup copies the attributes of a SCIM group onto a department.
+// It returns the member IDs if the members were given.
+func applyScimGroup(res map[string]interface{}, dept *pb.Department) ([]string, error) {
+	if v, ok := scimLookup(res, "displayName"); ok {
+		name, isString := v.(string)
+		if !isString && v != nil {
+			return nil, scimError(codes.InvalidArgument, "invalidValue", "displayName must be a string")
+		}
+		dept.Name = strings.TrimSpace(name)
+	}
+	if v, ok := scimLookup(res, "externalId"); ok {
+		id, isString := v.(string)
+		if !isString && v != nil {
+			return nil, scimError(codes.InvalidArgument, "invalidValue", "externalId must be a string")
+		}
+		dept.ExternalId = strings.TrimSpace(id)
+	}
+
+	v, ok := scimLookup(res, "members")
+	if !ok {
+		return nil, nil
+	}
+	members := []string{}
+	for _, el := range scimValues(v) {
+		m, isMap := el.(map[string]interface{})
+		if !isMap {
+			return nil, scimError(codes.InvalidArgument, "invalidValue", "members must be objects with a value")
+		}
+		id, _ := scimGet(m, "value")
+		s, _ := id.(string)
+		if s == "" {
+			return nil, scimError(codes.InvalidArgument, "invalidValue", "members must be objects with a value")
+		}
+		members = append(members, s)
+	}
+	return members, nil
+}
+
+// setMembers moves the employees into the department. With replace, the
+// employees of the department that are not listed leave it.
+func (s *scimServer) setMembers(ctx context.Context, deptID string, members []string, replace bool) error {
+	oid, _ := primitive.ObjectIDFromHex(deptID)
+	current, err := s.employees.employeesCollection.Distinct(ctx, "_id", bson.M{"department_id": oid})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	isMember := map[string]bool{}
+	for _, id := range current {
+		if id, ok := id.(primitive.ObjectID); ok {
+			isMember[id.Hex()] = true
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, id := range members {
+		wanted[id] = true
+		if !isMember[id] {
+			if err := s.moveEmployee(ctx, id, deptID); err != nil {
+				return err
+			}
+		}
+	}
+	if replace {
+		for id := range isMember {
+			if !wanted[id] {
+				if err := s.moveEmployee(ctx, id, ""); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// moveEmployee changes the department of an employee, none if deptID is
+// empty
+func (s *scimServer) moveEmployee(ctx context.Context, id, deptID string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return scimError(codes.InvalidArgument, "invalidValue", "Unknown member: %s", id)
+	}
+	emp, err := s.employees.GetEmployee(ctx, &pb.GetEmployeeRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return scimError(codes.InvalidArgument, "invalidValue", "Unknown member: %s", id)
+	}
+	if err != nil {
+		return err
+	}
+	emp.DepartmentId, emp.Department, emp.EffectiveDate = deptID, "", nil
+	_, err = s.employees.UpdateEmployee(ctx, emp)
+	return err
+}
+
+// DeleteGroup
+func (s *scimServer) DeleteGroup(ctx context.Context, req *pb.ScimGetRequest) (*pb.Empty, error) {
+	log.Println("DeleteGroup RPC called")
+
+	if _, err := s.department(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	if _, err := s.departments.DeleteDepartment(ctx, &pb.DepartmentID{Id: req.GetId()}); err != nil {
+		return nil, err
+	}
+	scimNoContent(ctx)
+	return &pb.Empty{}, nil
+}
+
+// scimJSON copies a resource through JSON
+func scimJSON(res map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode resource: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode resource: %v", err)
+	}
+	return out, nil
+}
+
+// scimSelect applies the attributes and excludedAttributes parameters to a
+// resource. id and schemas are always returned.
+func scimSelect(res map[string]interface{}, attributes, excluded string) map[string]interface{} {
+	if attributes != "" {
+		out := map[string]interface{}{"id": res["id"], "schemas": res["schemas"]}
+		for _, a := range strings.Split(attributes, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				scimCopyPath(out, res, scimPath(a))
+			}
+		}
+		return out
+	}
+	for _, a := range strings.Split(excluded, ",") {
+		if a = strings.TrimSpace(a); a != "" && !strings.EqualFold(a, "id") && !strings.EqualFold(a, "schemas") {
+			scimDeletePath(res, scimPath(a))
+		}
+	}
+	return res
+}
+
+// scimCopyPath copies one attribute path from src to dst. Sub-attributes of
+// multi-valued attributes are copied from every value.
+func scimCopyPath(dst, src map[string]interface{}, path []string) {
+	v, key := scimGet(src, path[0])
+	if key == "" {
+		return
+	}
+	if len(path) == 1 {
+		dst[key] = v
+		return
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		sub, ok := dst[key].(map[string]interface{})
+		if !ok {
+			sub = map[string]interface{}{}
+			dst[key] = sub
+		}
+		scimCopyPath(sub, v, path[1:])
+	case []interface{}:
+		out, _ := dst[key].([]interface{})
+		for i, el := range v {
+			m, ok := el.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if i >= len(out) {
+				out = append(out, map[string]interface{}{})
+			}
+			if sub, ok := out[i].(map[string]interface{}); ok {
+				scimCopyPath(sub, m, path[1:])
+			}
+		}
+		dst[key] = out
+	}
+}
+
+// scimDeletePath removes one attribute path from a resource
+func scimDeletePath(res map[string]interface{}, path []string) {
+	v, key := scimGet(res, path[0])
+	if key == "" {
+		return
+	}
+	if len(path) == 1 {
+		delete(res, key)
+		return
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		scimDeletePath(v, path[1:])
+	case []interface{}:
+		for _, el := range v {
+			if m, ok := el.(map[string]interface{}); ok {
+				scimDeletePath(m, path[1:])
+			}
+		}
+	}
+}
+
+// scimPatchOp is one operation of a PatchOp message
+type scimPatchOp struct {
+	op    string
+	path  string
+	value interface{}
+}
+
+func scimPatchOps(msg map[string]interface{}) ([]scimPatchOp, error) {
+	schemas, _ := scimGet(msg, "schemas")
+	isPatch := false
+	for _, s := range scimValues(schemas) {
+		isPatch = isPatch || s == scimPatchSchema
+	}
+	if !isPatch {
+		return nil, scimError(codes.InvalidArgument, "invalidSyntax", "Expected a %s message", scimPatchSchema)
+	}
+
+	list, _ := scimGet(msg, "Operations")
+	var ops []scimPatchOp
+	for _, el := range scimValues(list) {
+		m, ok := el.(map[string]interface{})
+		if !ok {
+			return nil, scimError(codes.InvalidArgument, "invalidSyntax", "Operations must be objects")
+		}
+		op, _ := scimGet(m, "op")
+		path, _ := scimGet(m, "path")
+		value, _ := scimGet(m, "value")
+		name, _ := op.(string)
+		p, _ := path.(string)
+		ops = append(ops, scimPatchOp{op: strings.ToLower(name), path: p, value: value})
+	}
+	if len(ops) == 0 {
+		return nil, scimError(codes.InvalidArgument, "invalidSyntax", "The patch has no Operations")
+	}
+	return ops, nil
+}
+
+// scimApplyPatch applies one operation to a resource. Removed attributes
+// are set to null rather than dropped, so that writing the resource back
+// clears them.
+func scimApplyPatch(res map[string]interface{}, op scimPatchOp) error {
+	switch op.op {
+	case "add", "replace", "remove":
+	default:
+		return scimError(codes.InvalidArgument, "invalidSyntax", "Unknown patch operation %q", op.op)
+	}
+
+	if op.path == "" {
+		values, ok := op.value.(map[string]interface{})
+		if op.op == "remove" || !ok {
+			return scimError(codes.InvalidArgument, "noTarget", "A %s operation without a path needs an object value", op.op)
+		}
+		for k, v := range values {
+			// Extension attributes are merged rather than replaced
+			if sub, ok := v.(map[string]interface{}); ok && strings.HasPrefix(strings.ToLower(k), "urn:") {
+				for k2, v2 := range sub {
+					if err := scimApplyPatch(res, scimPatchOp{op: op.op, path: k + ":" + k2, value: v2}); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := scimApplyPatch(res, scimPatchOp{op: op.op, path: k, value: v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parseScimPatchPath(op.path)
+	if err != nil {
+		return scimError(codes.InvalidArgument, "invalidPath", "Invalid path: %v", err)
+	}
+	keys := scimPath(path.attr)
+	parent := res
+	for _, k := range keys[:len(keys)-1] {
+		v, key := scimGet(parent, k)
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			if op.op == "remove" {
+				return nil
+			}
+			m = map[string]interface{}{}
+			if key == "" {
+				key = k
+			}
+			parent[key] = m
+		}
+		parent = m
+	}
+	last := keys[len(keys)-1]
+	cur, key := scimGet(parent, last)
+	if key == "" {
+		key = last
+	}
+
+	if path.filter != nil {
+		matched := false
+		out := []interface{}{}
+		for _, el := range scimValues(cur) {
+			m, ok := el.(map[string]interface{})
+			if !ok || !path.filter.matches(m) {
+				out = append(out, el)
+				continue
+			}
+			matched = true
+			switch {
+			case op.op == "remove" && path.sub == "":
+				continue
+			case op.op == "remove":
+				_, subKey := scimGet(m, path.sub)
+				delete(m, subKey)
+			case path.sub != "":
+				_, subKey := scimGet(m, path.sub)
+				if subKey == "" {
+					subKey = path.sub
+				}
+				m[subKey] = op.value
+			default:
+				values, ok := op.value.(map[string]interface{})
+				if !ok {
+					return scimError(codes.InvalidArgument, "invalidValue", "%s needs an object value", op.path)
+				}
+				if op.op == "replace" {
+					m = map[string]interface{}{}
+				}
+				for k, v := range values {
+					m[k] = v
+				}
+			}
+			out = append(out, m)
+		}
+		if !matched && op.op != "remove" {
+			return scimError(codes.InvalidArgument, "noTarget", "No values match %s", op.path)
+		}
+		parent[key] = out
+		return nil
+	}
+
+	_, isList := cur.([]interface{})
+	switch op.op {
+	case "remove":
+		// Removing listed values, as identity providers do for members
+		if isList && op.value != nil {
+			drop := map[string]bool{}
+			for _, el := range scimValues(op.value) {
+				if m, ok := el.(map[string]interface{}); ok {
+					v, _ := scimGet(m, "value")
+					if s, ok := v.(string); ok {
+						drop[s] = true
+					}
+				}
+			}
+			out := []interface{}{}
+			for _, el := range scimValues(cur) {
+				if m, ok := el.(map[string]interface{}); ok {
+					if v, _ := scimGet(m, "value"); v != nil && drop[fmt.Sprint(v)] {
+						continue
+					}
+				}
+				out = append(out, el)
+			}
+			parent[key] = out
+			return nil
+		}
+		parent[key] = nil
+	case "add":
+		if isList {
+			out := scimValues(cur)
+			seen := map[string]bool{}
+			for _, el := range out {
+				if m, ok := el.(map[string]interface{}); ok {
+					v, _ := scimGet(m, "value")
+					seen[fmt.Sprint(v)] = true
+				}
+			}
+			for _, el := range scimValues(op.value) {
+				if m, ok := el.(map[string]interface{}); ok {
+					v, _ := scimGet(m, "value")
+					if seen[fmt.Sprint(v)] {
+						continue
+					}
+				}
+				out = append(out, el)
+			}
+			parent[key] = out
+			return nil
+		}
+		if m, ok := cur.(map[string]interface{}); ok {
+			if values, ok := op.value.(map[string]interface{}); ok {
+				for k, v := range values {
+					m[k] = v
+				}
+				return nil
+			}
+		}
+		parent[key] = op.value
+	case "replace":
+		parent[key] = op.value
+	}
+	return nil
+}
+
+// Gateway plumbing. SCIM clients expect 201 with a Location on create, 204
+// on delete, the scim+json content type and errors in the SCIM format.
+
+const (
+	scimStatusHeader   = "x-scim-status"
+	scimLocationHeader = "x-scim-location"
+)
+
+// scimCreated makes the gateway answer 201 Created
+func scimCreated(ctx context.Context, location string) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(scimStatusHeader, "201", scimLocationHeader, location)); err != nil {
+		log.Printf("Failed to set SCIM response headers: %v", err)
+	}
+}
+
+// scimNoContent makes the gateway answer 204 No Content
+func scimNoContent(ctx context.Context) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(scimStatusHeader, "204")); err != nil {
+		log.Printf("Failed to set SCIM response headers: %v", err)
+	}
+}
+
+// scimForwardResponse turns the SCIM response headers into the HTTP status
+// and Location header
+func scimForwardResponse(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.HeaderMD.Get(scimStatusHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	w.Header().Del("Grpc-Metadata-" + scimStatusHeader)
+	w.Header().Del("Grpc-Metadata-" + scimLocationHeader)
+	if loc := md.HeaderMD.Get(scimLocationHeader); len(loc) > 0 {
+		w.Header().Set("Location", loc[0])
+	}
+	code, err := strconv.Atoi(values[0])
+	if err != nil {
+		return nil
+	}
+	w.WriteHeader(code)
+	return nil
+}
+
+// scimErrorHandler writes errors of SCIM requests as SCIM error responses
+// and leaves the others to the default handler
+func scimErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if !strings.HasPrefix(r.URL.Path, scimBasePath+"/") {
+		runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+		return
+	}
+
+	st := status.Convert(err)
+	code := runtime.HTTPStatusFromCode(st.Code())
+	body := map[string]interface{}{
+		"schemas": []string{scimErrorSchema},
+		"status":  strconv.Itoa(code),
+		"detail":  st.Message(),
+	}
+	switch st.Code() {
+	case codes.AlreadyExists:
+		body["scimType"] = "uniqueness"
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		body["scimType"] = "invalidValue"
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == "scim" && info.GetReason() != "" {
+			body["scimType"] = info.GetReason()
+		}
+	}
+
+	data, _ := json.Marshal(body)
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// scimHandler serves SCIM requests through the gateway with the SCIM
+// content type, and without a body on 204 responses
+func scimHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&scimResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+type scimResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	noContent   bool
+}
+
+func (w *scimResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.Header().Set("Content-Type", scimContentType)
+	}
+	w.noContent = code == http.StatusNoContent
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *scimResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.noContent {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *scimResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
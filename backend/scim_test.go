@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scimTestUser returns a user resource with the enterprise extension set
+func scimTestUser() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":  []interface{}{scimUserSchema, scimEnterpriseSchema},
+		"userName": "ada@example.com",
+		scimEnterpriseSchema: map[string]interface{}{
+			"department": "Engineering",
+			"manager":    map[string]interface{}{"value": "m1", "displayName": "Charles"},
+		},
+	}
+}
+
+// scimJSONValue decodes a JSON literal the way PATCH bodies are decoded
+func scimJSONValue(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	return v
+}
+
+// scimErrorType returns the SCIM error type scimError attached to st
+func scimErrorType(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == "scim" {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestScimPatchEnterprise(t *testing.T) {
+	tests := []struct {
+		name string
+		// Starts from scimTestUser, or from a user without the extension
+		bare bool
+		ops  string
+		// The extension after the patch, empty if it should be missing
+		want string
+	}{
+		{
+			name: "add department",
+			ops:  `[{"op": "add", "path": "` + scimEnterpriseSchema + `:department", "value": "Sales"}]`,
+			want: `{"department": "Sales", "manager": {"value": "m1", "displayName": "Charles"}}`,
+		},
+		{
+			name: "add department without the extension",
+			bare: true,
+			ops:  `[{"op": "add", "path": "` + scimEnterpriseSchema + `:department", "value": "Sales"}]`,
+			want: `{"department": "Sales"}`,
+		},
+		{
+			name: "add manager merges",
+			ops:  `[{"op": "add", "path": "` + scimEnterpriseSchema + `:manager", "value": {"value": "m2"}}]`,
+			want: `{"department": "Engineering", "manager": {"value": "m2", "displayName": "Charles"}}`,
+		},
+		{
+			name: "add manager.value without the extension",
+			bare: true,
+			ops:  `[{"op": "add", "path": "` + scimEnterpriseSchema + `:manager.value", "value": "m2"}]`,
+			want: `{"manager": {"value": "m2"}}`,
+		},
+		{
+			name: "replace manager",
+			ops:  `[{"op": "replace", "path": "` + scimEnterpriseSchema + `:manager", "value": {"value": "m2"}}]`,
+			want: `{"department": "Engineering", "manager": {"value": "m2"}}`,
+		},
+		{
+			name: "replace manager.value",
+			ops:  `[{"op": "Replace", "path": "` + scimEnterpriseSchema + `:manager.value", "value": "m2"}]`,
+			want: `{"department": "Engineering", "manager": {"value": "m2", "displayName": "Charles"}}`,
+		},
+		{
+			name: "replace with a lower-case path",
+			ops:  `[{"op": "replace", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:user:DEPARTMENT", "value": "Sales"}]`,
+			want: `{"department": "Sales", "manager": {"value": "m1", "displayName": "Charles"}}`,
+		},
+		{
+			name: "remove department",
+			ops:  `[{"op": "remove", "path": "` + scimEnterpriseSchema + `:department"}]`,
+			want: `{"department": null, "manager": {"value": "m1", "displayName": "Charles"}}`,
+		},
+		{
+			name: "remove manager",
+			ops:  `[{"op": "remove", "path": "` + scimEnterpriseSchema + `:manager"}]`,
+			want: `{"department": "Engineering", "manager": null}`,
+		},
+		{
+			name: "remove manager.value",
+			ops:  `[{"op": "remove", "path": "` + scimEnterpriseSchema + `:manager.value"}]`,
+			want: `{"department": "Engineering", "manager": {"value": null, "displayName": "Charles"}}`,
+		},
+		{
+			name: "remove without the extension",
+			bare: true,
+			ops:  `[{"op": "remove", "path": "` + scimEnterpriseSchema + `:manager.value"}]`,
+		},
+		{
+			name: "add without a path merges the extension",
+			ops:  `[{"op": "add", "value": {"` + scimEnterpriseSchema + `": {"department": "Sales"}}}]`,
+			want: `{"department": "Sales", "manager": {"value": "m1", "displayName": "Charles"}}`,
+		},
+		{
+			name: "replace without a path",
+			ops:  `[{"op": "replace", "value": {"` + scimEnterpriseSchema + `": {"manager": {"value": "m2"}}}}]`,
+			want: `{"department": "Engineering", "manager": {"value": "m2"}}`,
+		},
+		{
+			name: "replace without a path or the extension",
+			bare: true,
+			ops:  `[{"op": "replace", "value": {"` + scimEnterpriseSchema + `": {"department": "Sales"}}}]`,
+			want: `{"department": "Sales"}`,
+		},
+		{
+			name: "several operations in order",
+			ops: `[
+				{"op": "remove", "path": "` + scimEnterpriseSchema + `:manager"},
+				{"op": "add", "path": "` + scimEnterpriseSchema + `:manager.value", "value": "m3"},
+				{"op": "replace", "path": "` + scimEnterpriseSchema + `:department", "value": "Sales"}
+			]`,
+			want: `{"department": "Sales", "manager": {"value": "m3"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := scimTestUser()
+			if tt.bare {
+				delete(res, scimEnterpriseSchema)
+			}
+			msg := map[string]interface{}{
+				"schemas":    []interface{}{scimPatchSchema},
+				"Operations": scimJSONValue(t, tt.ops),
+			}
+			ops, err := scimPatchOps(msg)
+			if err != nil {
+				t.Fatalf("scimPatchOps: %v", err)
+			}
+			for _, op := range ops {
+				if err := scimApplyPatch(res, op); err != nil {
+					t.Fatalf("scimApplyPatch(%+v): %v", op, err)
+				}
+			}
+
+			ext, ok := res[scimEnterpriseSchema]
+			if tt.want == "" {
+				if ok {
+					t.Errorf("extension = %v, want none", ext)
+				}
+				return
+			}
+			if want := scimJSONValue(t, tt.want); !reflect.DeepEqual(ext, want) {
+				t.Errorf("extension = %v, want %v", ext, want)
+			}
+			if res["userName"] != "ada@example.com" {
+				t.Errorf("userName = %v, want it unchanged", res["userName"])
+			}
+		})
+	}
+}
+
+func TestScimPatchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		scimType string
+	}{
+		{
+			name:     "not a PatchOp",
+			msg:      `{"schemas": ["` + scimUserSchema + `"], "Operations": [{"op": "add", "path": "title", "value": "x"}]}`,
+			scimType: "invalidSyntax",
+		},
+		{
+			name:     "no operations",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": []}`,
+			scimType: "invalidSyntax",
+		},
+		{
+			name:     "operation not an object",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": ["add"]}`,
+			scimType: "invalidSyntax",
+		},
+		{
+			name:     "unknown operation",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": [{"op": "move", "path": "` + scimEnterpriseSchema + `:department"}]}`,
+			scimType: "invalidSyntax",
+		},
+		{
+			name:     "remove without a path",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": [{"op": "remove"}]}`,
+			scimType: "noTarget",
+		},
+		{
+			name:     "add without a path or an object",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": [{"op": "add", "value": "Sales"}]}`,
+			scimType: "noTarget",
+		},
+		{
+			name:     "invalid path",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": [{"op": "replace", "path": "` + scimEnterpriseSchema + `:manager[value eq", "value": "m2"}]}`,
+			scimType: "invalidPath",
+		},
+		{
+			name:     "no value matches",
+			msg:      `{"schemas": ["` + scimPatchSchema + `"], "Operations": [{"op": "replace", "path": "emails[type eq \"home\"].value", "value": "a@b.c"}]}`,
+			scimType: "noTarget",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := scimJSONValue(t, tt.msg).(map[string]interface{})
+			ops, err := scimPatchOps(msg)
+			if err == nil {
+				res := scimTestUser()
+				for _, op := range ops {
+					if err = scimApplyPatch(res, op); err != nil {
+						break
+					}
+				}
+			}
+			if err == nil {
+				t.Fatalf("the patch succeeded, want a %s error", tt.scimType)
+			}
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument {
+				t.Errorf("code = %v, want InvalidArgument", st.Code())
+			}
+			if got := scimErrorType(st); got != tt.scimType {
+				t.Errorf("scimType = %q, want %q (%v)", got, tt.scimType, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// scimFilter is a parsed SCIM filter expression (RFC 7644 section 3.4.2.2).
+// and, or and not combine left and right; "[]" is a value path, whose left
+// filter applies to the elements of attr; anything else compares attr to
+// value.
+type scimFilter struct {
+	op          string
+	attr        string
+	value       interface{}
+	left, right *scimFilter
+}
+
+var scimCompareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true, "pr": true,
+}
+
+// scimTokens splits a filter into attribute paths, operators, values and
+// brackets. Quoted strings keep their quotes so they can be told apart from
+// attribute names.
+func scimTokens(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n()[]\"", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type scimFilterParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseScimFilter parses a filter expression
+func parseScimFilter(s string) (*scimFilter, error) {
+	tokens, err := scimTokens(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &scimFilterParser{tokens: tokens}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return f, nil
+}
+
+func (p *scimFilterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *scimFilterParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *scimFilterParser) expect(t string) error {
+	if got := p.next(); got != t {
+		if got == "" {
+			return fmt.Errorf("expected %q at the end", t)
+		}
+		return fmt.Errorf("expected %q, got %q", t, got)
+	}
+	return nil
+}
+
+func (p *scimFilterParser) or() (*scimFilter, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimFilter{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) and() (*scimFilter, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimFilter{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) factor() (*scimFilter, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("the filter ends early")
+	case t == "(":
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+	case strings.EqualFold(t, "not"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return &scimFilter{op: "not", left: f}, p.expect(")")
+	case strings.HasPrefix(t, "\"") || strings.ContainsAny(t, "()[]"):
+		return nil, fmt.Errorf("expected an attribute, got %q", t)
+	}
+
+	attr := t
+	if p.peek() == "[" {
+		p.next()
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return &scimFilter{op: "[]", attr: attr, left: f}, p.expect("]")
+	}
+
+	op := strings.ToLower(p.next())
+	if !scimCompareOps[op] {
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+	if op == "pr" {
+		return &scimFilter{op: op, attr: attr}, nil
+	}
+	value, err := scimFilterValue(p.next())
+	if err != nil {
+		return nil, err
+	}
+	return &scimFilter{op: op, attr: attr, value: value}, nil
+}
+
+// scimFilterValue decodes a comparison value: a JSON string, number,
+// boolean or null
+func scimFilterValue(t string) (interface{}, error) {
+	if t == "" {
+		return nil, fmt.Errorf("a comparison value is missing")
+	}
+	switch strings.ToLower(t) {
+	case "true", "false", "null":
+		t = strings.ToLower(t)
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(t), &v); err != nil {
+		return nil, fmt.Errorf("invalid value %s", t)
+	}
+	return v, nil
+}
+
+// matches evaluates the filter against a resource, or an element of a
+// multi-valued attribute, as returned by the API
+func (f *scimFilter) matches(res map[string]interface{}) bool {
+	switch f.op {
+	case "and":
+		return f.left.matches(res) && f.right.matches(res)
+	case "or":
+		return f.left.matches(res) || f.right.matches(res)
+	case "not":
+		return !f.left.matches(res)
+	case "[]":
+		v, _ := scimLookup(res, f.attr)
+		for _, el := range scimValues(v) {
+			if m, ok := el.(map[string]interface{}); ok && f.left.matches(m) {
+				return true
+			}
+		}
+		return false
+	}
+
+	v, _ := scimLookup(res, f.attr)
+	values := scimValues(v)
+	if f.op == "pr" {
+		for _, el := range values {
+			if el != nil && el != "" {
+				return true
+			}
+		}
+		return false
+	}
+	// A multi-valued attribute matches if one of its values does; complex
+	// values are compared by their value sub-attribute
+	for _, el := range values {
+		if m, ok := el.(map[string]interface{}); ok {
+			el, _ = scimGet(m, "value")
+		}
+		if scimCompare(f.op, el, f.value) {
+			return true
+		}
+	}
+	return len(values) == 0 && f.op == "ne" && f.value != nil
+}
+
+// scimCompare compares an attribute value with a filter value. Strings
+// compare case-insensitively.
+func scimCompare(op string, v, want interface{}) bool {
+	switch w := want.(type) {
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return op == "ne"
+		}
+		s, w = strings.ToLower(s), strings.ToLower(w)
+		switch op {
+		case "eq":
+			return s == w
+		case "ne":
+			return s != w
+		case "co":
+			return strings.Contains(s, w)
+		case "sw":
+			return strings.HasPrefix(s, w)
+		case "ew":
+			return strings.HasSuffix(s, w)
+		case "gt":
+			return s > w
+		case "ge":
+			return s >= w
+		case "lt":
+			return s < w
+		case "le":
+			return s <= w
+		}
+	case float64:
+		n, ok := v.(float64)
+		if !ok {
+			return op == "ne"
+		}
+		switch op {
+		case "eq":
+			return n == w
+		case "ne":
+			return n != w
+		case "gt":
+			return n > w
+		case "ge":
+			return n >= w
+		case "lt":
+			return n < w
+		case "le":
+			return n <= w
+		}
+	default:
+		// Booleans and null only compare for equality
+		switch op {
+		case "eq":
+			return v == want
+		case "ne":
+			return v != want
+		}
+	}
+	return false
+}
+
+// scimValues returns the values of a single or multi-valued attribute
+func scimValues(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+// scimGet looks a key up case-insensitively, as SCIM attribute names are.
+// It returns the key as stored.
+func scimGet(m map[string]interface{}, name string) (interface{}, string) {
+	if v, ok := m[name]; ok {
+		return v, name
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v, k
+		}
+	}
+	return nil, ""
+}
+
+// scimLookup follows an attribute path such as name.givenName or
+// urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager.value
+// through a resource. A sub-attribute of a multi-valued attribute, such as
+// emails.value, returns the list of its values.
+func scimLookup(res map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = res
+	for _, seg := range scimPath(path) {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			var key string
+			cur, key = scimGet(v, seg)
+			if key == "" {
+				return nil, false
+			}
+		case []interface{}:
+			var values []interface{}
+			for _, el := range v {
+				if m, ok := el.(map[string]interface{}); ok {
+					if sub, key := scimGet(m, seg); key != "" {
+						values = append(values, scimValues(sub)...)
+					}
+				}
+			}
+			if values == nil {
+				return nil, false
+			}
+			cur = values
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// scimPath splits an attribute path into the keys it goes through. The core
+// schema URN is dropped and extension URNs are kept as the first key.
+func scimPath(path string) []string {
+	for _, urn := range []string{scimUserSchema, scimGroupSchema} {
+		if len(path) > len(urn) && strings.EqualFold(path[:len(urn)+1], urn+":") {
+			path = path[len(urn)+1:]
+		}
+	}
+	if len(path) > len(scimEnterpriseSchema) && strings.EqualFold(path[:len(scimEnterpriseSchema)+1], scimEnterpriseSchema+":") {
+		return append([]string{scimEnterpriseSchema}, strings.Split(path[len(scimEnterpriseSchema)+1:], ".")...)
+	}
+	if strings.EqualFold(path, scimEnterpriseSchema) {
+		return []string{scimEnterpriseSchema}
+	}
+	return strings.Split(path, ".")
+}
+
+// scimPatchPath is the target of a PATCH operation: an attribute, the
+// elements of a multi-valued attribute that match a filter and optionally a
+// sub-attribute of those elements, e.g. emails[type eq "work"].value
+type scimPatchPath struct {
+	attr   string
+	filter *scimFilter
+	sub    string
+}
+
+func parseScimPatchPath(s string) (scimPatchPath, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '[')
+	if open < 0 {
+		if s == "" || strings.ContainsAny(s, " ]\"") {
+			return scimPatchPath{}, fmt.Errorf("invalid path %q", s)
+		}
+		return scimPatchPath{attr: s}, nil
+	}
+	end := strings.LastIndexByte(s, ']')
+	if end < open {
+		return scimPatchPath{}, fmt.Errorf("invalid path %q", s)
+	}
+	filter, err := parseScimFilter(s[open+1 : end])
+	if err != nil {
+		return scimPatchPath{}, err
+	}
+	p := scimPatchPath{attr: s[:open], filter: filter}
+	if rest := s[end+1:]; rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+			return scimPatchPath{}, fmt.Errorf("invalid path %q", s)
+		}
+		p.sub = rest[1:]
+	}
+	return p, nil
+}
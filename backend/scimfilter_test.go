@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// scimFilterString renders a parsed filter with explicit parentheses, so
+// that tests can check how it was grouped
+func scimFilterString(f *scimFilter) string {
+	switch f.op {
+	case "and", "or":
+		return fmt.Sprintf("(%s %s %s)", scimFilterString(f.left), f.op, scimFilterString(f.right))
+	case "not":
+		return fmt.Sprintf("not(%s)", scimFilterString(f.left))
+	case "[]":
+		return fmt.Sprintf("%s[%s]", f.attr, scimFilterString(f.left))
+	case "pr":
+		return f.attr + " pr"
+	}
+	return fmt.Sprintf("%s %s %#v", f.attr, f.op, f.value)
+}
+
+func TestParseScimFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{`userName eq "ada"`, `userName eq "ada"`},
+		{`a pr and b pr or c pr`, `((a pr and b pr) or c pr)`},
+		{`a pr or b pr and c pr`, `(a pr or (b pr and c pr))`},
+		{`a pr or b pr or c pr`, `((a pr or b pr) or c pr)`},
+		{`(a pr or b pr) and c pr`, `((a pr or b pr) and c pr)`},
+		{`not (a pr) and b pr`, `(not(a pr) and b pr)`},
+		{`not(a pr or b pr)`, `not((a pr or b pr))`},
+		{`a PR AND b Eq "x" Or NOT (c pr)`, `((a pr and b eq "x") or not(c pr))`},
+		{`emails[type eq "work" and primary eq true]`, `emails[(type eq "work" and primary eq true)]`},
+		{`emails[type eq "work"] or userName sw "a"`, `(emails[type eq "work"] or userName sw "a")`},
+		{`a eq "say \"hi\" (or not)"`, `a eq "say \"hi\" (or not)"`},
+		{`a eq "back\\slash"`, `a eq "back\\slash"`},
+		{`a eq True`, `a eq true`},
+		{`a eq null`, `a eq <nil>`},
+		{`a ge 2.5`, `a ge 2.5`},
+		{"\ta pr\n", `a pr`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := parseScimFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseScimFilter(%q): %v", tt.filter, err)
+			}
+			if got := scimFilterString(f); got != tt.want {
+				t.Errorf("parseScimFilter(%q) = %s, want %s", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScimFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		err    string
+	}{
+		{"empty", ``, "ends early"},
+		{"blank", `   `, "ends early"},
+		{"unterminated string", `userName eq "ada`, "unterminated string"},
+		{"escaped closing quote", `userName eq "ada\"`, "unterminated string"},
+		{"unknown operator", `userName is "ada"`, `unknown operator "is"`},
+		{"missing operator", `userName`, `unknown operator ""`},
+		{"missing value", `userName eq`, "value is missing"},
+		{"invalid value", `userName eq ada`, "invalid value"},
+		{"value as attribute", `"ada" eq userName`, "expected an attribute"},
+		{"dangling and", `a pr and`, "ends early"},
+		{"dangling or", `a pr or`, "ends early"},
+		{"unclosed parenthesis", `(a pr or b pr`, `expected ")" at the end`},
+		{"extra parenthesis", `a pr)`, `unexpected ")"`},
+		{"empty parentheses", `()`, "expected an attribute"},
+		{"not without parentheses", `not a pr`, `expected "("`},
+		{"unclosed bracket", `emails[type eq "work"`, `expected "]" at the end`},
+		{"extra bracket", `emails[type eq "work"]]`, `unexpected "]"`},
+		{"bracket closed by parenthesis", `emails[type eq "work")`, `expected "]", got ")"`},
+		{"trailing tokens", `a pr b pr`, `unexpected "b"`},
+		{"trailing value", `a eq "x" "y"`, `unexpected "\"y\""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseScimFilter(tt.filter)
+			if err == nil {
+				t.Fatalf("parseScimFilter(%q) = %s, want an error", tt.filter, scimFilterString(f))
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseScimFilter(%q) failed with %q, want %q", tt.filter, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestScimFilterMatches(t *testing.T) {
+	res := map[string]interface{}{
+		"schemas":     []interface{}{scimUserSchema, scimEnterpriseSchema},
+		"userName":    "Ada@Example.com",
+		"displayName": `Ada "The Countess" L\`,
+		"name":        map[string]interface{}{"givenName": "Ada", "familyName": "Lovelace"},
+		"title":       "",
+		"active":      true,
+		"emails": []interface{}{
+			map[string]interface{}{"value": "ada@example.com", "type": "work", "primary": true},
+			map[string]interface{}{"value": "ada@home.example", "type": "home"},
+		},
+		scimEnterpriseSchema: map[string]interface{}{
+			"department": "Engineering",
+			"manager":    map[string]interface{}{"value": "5f0c", "displayName": "Charles"},
+		},
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "ada@example.com"`, true},
+		{`userName eq "grace@example.com"`, false},
+		{`USERNAME EQ "ada@example.com"`, true},
+		{`username Sw "ADA"`, true},
+		{`userName ew ".org"`, false},
+		{`userName co "example"`, true},
+		{`userName gt "a"`, true},
+		{`userName lt "a"`, false},
+		{`displayName eq "Ada \"The Countess\" L\\"`, true},
+		{`userName ne "a) or (b"`, true},
+
+		// Attribute paths
+		{`name.givenName eq "ada"`, true},
+		{`NAME.FAMILYNAME eq "lovelace"`, true},
+		{`name.middleName eq "ada"`, false},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:name.familyName eq "Lovelace"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "ada"`, true},
+		{scimEnterpriseSchema + `:manager.value eq "5f0c"`, true},
+		{strings.ToUpper(scimEnterpriseSchema) + `:MANAGER.VALUE eq "5F0C"`, true},
+		{scimEnterpriseSchema + `:manager.value eq "other"`, false},
+		{scimEnterpriseSchema + `:department co "gin"`, true},
+		{scimEnterpriseSchema + `:costCenter pr`, false},
+
+		// Multi-valued attributes and value paths
+		{`emails.value eq "ada@home.example"`, true},
+		{`emails eq "ada@home.example"`, true},
+		{`emails.type eq "other"`, false},
+		{`emails[type eq "work"]`, true},
+		{`emails[type eq "work" and value ew "example.com"]`, true},
+		{`emails[type eq "home" and primary eq true]`, false},
+		{`emails[type eq "home"] and emails[primary eq true]`, true},
+		{`emails[not (type eq "work")]`, true},
+
+		// pr
+		{`userName pr`, true},
+		{`title pr`, false},
+		{`nickName pr`, false},
+		{`name pr`, true},
+		{`emails pr`, true},
+
+		// Other types
+		{`active eq true`, true},
+		{`active eq FALSE`, false},
+		{`active ne false`, true},
+		{`nickName ne "x"`, true},
+		{`nickName eq null`, false},
+		{`userName eq 1`, false},
+
+		// and binds tighter than or
+		{`userName eq "nobody" and title pr or active eq true`, true},
+		{`active eq true or userName eq "nobody" and title pr`, true},
+		{`(active eq true or userName eq "nobody") and title pr`, false},
+		{`not (title pr)`, true},
+		{`not (active eq true) or userName eq "nobody"`, false},
+		{`not (active eq true and title pr)`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := parseScimFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseScimFilter(%q): %v", tt.filter, err)
+			}
+			if got := f.matches(res); got != tt.want {
+				t.Errorf("%s matches = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScimPatchPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		attr   string
+		filter string
+		sub    string
+	}{
+		{path: "userName", attr: "userName"},
+		{path: " name.givenName ", attr: "name.givenName"},
+		{path: scimEnterpriseSchema + ":manager.value", attr: scimEnterpriseSchema + ":manager.value"},
+		{path: `emails[type eq "work"]`, attr: "emails", filter: `type eq "work"`},
+		{path: `emails[type eq "work"].value`, attr: "emails", filter: `type eq "work"`, sub: "value"},
+		{path: `members[value eq "5f0c" or value eq "5f0d"]`, attr: "members", filter: `(value eq "5f0c" or value eq "5f0d")`},
+		{path: `emails[value eq "a[1]"].primary`, attr: "emails", filter: `value eq "a[1]"`, sub: "primary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := parseScimPatchPath(tt.path)
+			if err != nil {
+				t.Fatalf("parseScimPatchPath(%q): %v", tt.path, err)
+			}
+			var filter string
+			if p.filter != nil {
+				filter = scimFilterString(p.filter)
+			}
+			if p.attr != tt.attr || filter != tt.filter || p.sub != tt.sub {
+				t.Errorf("parseScimPatchPath(%q) = %q [%s] %q, want %q [%s] %q", tt.path, p.attr, filter, p.sub, tt.attr, tt.filter, tt.sub)
+			}
+		})
+	}
+
+	for _, path := range []string{
+		``,
+		`user name`,
+		`userName]`,
+		`emails[type eq "work"`,
+		`emails[type eq "work"]value`,
+		`emails[type eq "work"].`,
+		`emails[type is "work"]`,
+		`emails[]`,
+	} {
+		t.Run("invalid "+path, func(t *testing.T) {
+			if p, err := parseScimPatchPath(path); err == nil {
+				t.Errorf("parseScimPatchPath(%q) = %+v, want an error", path, p)
+			}
+		})
+	}
+}
@@ -0,0 +1,591 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"html"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// searchField is an employee field the search index covers. Matches in
+// fields with a higher weight rank higher.
+type searchField struct {
+	name   string
+	weight float64
+	value  func(e *Employee) string
+}
+
+var searchFields = []searchField{
+	{name: "first_name", weight: 3, value: func(e *Employee) string { return e.FirstName }},
+	{name: "last_name", weight: 3, value: func(e *Employee) string { return e.LastName }},
+	{name: "email", weight: 2, value: func(e *Employee) string { return e.Email }},
+	{name: "position", weight: 1.5, value: func(e *Employee) string { return e.Position }},
+	{name: "department", weight: 1, value: func(e *Employee) string { return e.Department }},
+}
+
+// allSearchFields is the field mask of every search field
+const allSearchFields = 1<<5 - 1
+
+// How much a match counts for by how close the word is to the query word
+const (
+	searchExactMatch  = 1.0
+	searchPrefixMatch = 0.7
+	searchOneTypo     = 0.6
+	searchTwoTypos    = 0.35
+)
+
+// maxSearchExpansions caps the index words one query word can match by
+// prefix or with typos
+const maxSearchExpansions = 200
+
+// searchToken is a word of a text, with where it is in the text
+type searchToken struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text into words of letters and digits, lowercased and
+// without accents
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			tokens = append(tokens, searchToken{term: foldTerm(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, searchToken{term: foldTerm(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// foldTerm lowercases a word and strips its accents, so "José" matches "jose"
+func foldTerm(word string) string {
+	ascii := true
+	for i := 0; i < len(word); i++ {
+		if word[i] >= 0x80 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return strings.ToLower(word)
+	}
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), word)
+	if err != nil {
+		folded = word
+	}
+	return strings.ToLower(folded)
+}
+
+// searchDoc is an indexed employee with the fields each of its words is in
+type searchDoc struct {
+	emp   *Employee
+	terms map[string]uint8
+}
+
+// searchIndex is an in-memory inverted index of employees: for each word,
+// the employees it appears in and in which fields.
+type searchIndex struct {
+	mu       sync.RWMutex
+	docs     map[primitive.ObjectID]*searchDoc
+	postings map[string]map[primitive.ObjectID]uint8
+	// Every indexed word, sorted, for prefix and typo lookups
+	vocabulary []string
+	loaded     bool
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		docs:     make(map[primitive.ObjectID]*searchDoc),
+		postings: make(map[string]map[primitive.ObjectID]uint8),
+	}
+}
+
+func newSearchDoc(emp *Employee) *searchDoc {
+	doc := &searchDoc{emp: emp, terms: make(map[string]uint8)}
+	for i, f := range searchFields {
+		for _, tok := range tokenize(f.value(emp)) {
+			doc.terms[tok.term] |= 1 << i
+		}
+	}
+	return doc
+}
+
+// load replaces everything in the index
+func (x *searchIndex) load(emps []*Employee) {
+	docs := make(map[primitive.ObjectID]*searchDoc, len(emps))
+	postings := make(map[string]map[primitive.ObjectID]uint8)
+	for _, emp := range emps {
+		doc := newSearchDoc(emp)
+		docs[emp.ID] = doc
+		for term, fields := range doc.terms {
+			if postings[term] == nil {
+				postings[term] = make(map[primitive.ObjectID]uint8)
+			}
+			postings[term][emp.ID] = fields
+		}
+	}
+	vocabulary := make([]string, 0, len(postings))
+	for term := range postings {
+		vocabulary = append(vocabulary, term)
+	}
+	sort.Strings(vocabulary)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.docs, x.postings, x.vocabulary, x.loaded = docs, postings, vocabulary, true
+}
+
+// put adds an employee or replaces the indexed copy
+func (x *searchIndex) put(emp *Employee) {
+	doc := newSearchDoc(emp)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeLocked(emp.ID)
+	x.docs[emp.ID] = doc
+	for term, fields := range doc.terms {
+		if x.postings[term] == nil {
+			x.postings[term] = make(map[primitive.ObjectID]uint8)
+			i := sort.SearchStrings(x.vocabulary, term)
+			x.vocabulary = append(x.vocabulary, "")
+			copy(x.vocabulary[i+1:], x.vocabulary[i:])
+			x.vocabulary[i] = term
+		}
+		x.postings[term][emp.ID] = fields
+	}
+}
+
+func (x *searchIndex) remove(id primitive.ObjectID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeLocked(id)
+}
+
+func (x *searchIndex) removeLocked(id primitive.ObjectID) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	delete(x.docs, id)
+	for term := range doc.terms {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+			if i := sort.SearchStrings(x.vocabulary, term); i < len(x.vocabulary) && x.vocabulary[i] == term {
+				x.vocabulary = append(x.vocabulary[:i], x.vocabulary[i+1:]...)
+			}
+		}
+	}
+}
+
+// searchMatch is an employee that matches a query, with the index words
+// that matched
+type searchMatch struct {
+	doc   *searchDoc
+	score float64
+	terms map[string]bool
+}
+
+// termMatch is an index word a query word matches, and how closely
+type termMatch struct {
+	term    string
+	quality float64
+}
+
+// search finds the employees that match every word of the query in one of
+// the given fields, best first
+func (x *searchIndex) search(query string, fields uint8, includeTerminated bool) ([]*searchMatch, error) {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if !x.loaded {
+		return nil, status.Errorf(codes.Unavailable, "The search index is still loading")
+	}
+
+	n := float64(len(x.docs))
+	var matches map[primitive.ObjectID]*searchMatch
+	for i, tok := range tokens {
+		// The last word may not be finished yet
+		last := i == len(tokens)-1
+
+		best := make(map[primitive.ObjectID]float64)
+		terms := make(map[primitive.ObjectID][]string)
+		for _, c := range x.expand(tok.term, last) {
+			posting := x.postings[c.term]
+			// Rare words count for more
+			idf := math.Log(1 + n/float64(len(posting)))
+			for id, in := range posting {
+				in &= fields
+				if in == 0 {
+					continue
+				}
+				score := fieldWeight(in) * c.quality * idf
+				if score > best[id] {
+					best[id] = score
+				}
+				terms[id] = append(terms[id], c.term)
+			}
+		}
+
+		if i == 0 {
+			matches = make(map[primitive.ObjectID]*searchMatch, len(best))
+			for id := range best {
+				matches[id] = &searchMatch{doc: x.docs[id], terms: make(map[string]bool)}
+			}
+		}
+		for id, m := range matches {
+			score, ok := best[id]
+			if !ok {
+				delete(matches, id)
+				continue
+			}
+			m.score += score
+			for _, term := range terms[id] {
+				m.terms[term] = true
+			}
+		}
+		if len(matches) == 0 {
+			return nil, nil
+		}
+	}
+
+	results := make([]*searchMatch, 0, len(matches))
+	for _, m := range matches {
+		if !includeTerminated && m.doc.emp.Status == statusTerminated {
+			continue
+		}
+		results = append(results, m)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.doc.emp.LastName != b.doc.emp.LastName {
+			return a.doc.emp.LastName < b.doc.emp.LastName
+		}
+		if a.doc.emp.FirstName != b.doc.emp.FirstName {
+			return a.doc.emp.FirstName < b.doc.emp.FirstName
+		}
+		return a.doc.emp.ID.Hex() < b.doc.emp.ID.Hex()
+	})
+	return results, nil
+}
+
+// expand finds the index words a query word matches: itself, words it is a
+// prefix of when prefix is set, and words a typo or two away. Short words
+// allow fewer typos.
+func (x *searchIndex) expand(word string, prefix bool) []termMatch {
+	var found []termMatch
+	seen := make(map[string]bool)
+	add := func(term string, quality float64) {
+		if !seen[term] && len(found) < maxSearchExpansions {
+			seen[term] = true
+			found = append(found, termMatch{term: term, quality: quality})
+		}
+	}
+
+	if _, ok := x.postings[word]; ok {
+		add(word, searchExactMatch)
+	}
+	if prefix {
+		for i := sort.SearchStrings(x.vocabulary, word); i < len(x.vocabulary) && strings.HasPrefix(x.vocabulary[i], word); i++ {
+			add(x.vocabulary[i], searchPrefixMatch)
+		}
+	}
+
+	query := []rune(word)
+	typos := maxTypos(len(query))
+	if typos == 0 {
+		return found
+	}
+	for _, term := range x.vocabulary {
+		if seen[term] {
+			continue
+		}
+		candidate := []rune(term)
+		if d := len(candidate) - len(query); d > typos || -d > typos {
+			continue
+		}
+		switch d := editDistance(query, candidate, typos); {
+		case d > typos:
+		case d == 1:
+			add(term, searchOneTypo)
+		case d == 2:
+			add(term, searchTwoTypos)
+		}
+	}
+	return found
+}
+
+// maxTypos is how many typos a word of n letters may have
+func maxTypos(n int) int {
+	switch {
+	case n < 3:
+		return 0
+	case n < 7:
+		return 1
+	}
+	return 2
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of
+// neighbouring letters that turn a into b, giving up past limit
+func editDistance(a, b []rune, limit int) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// fieldWeight is the weight of the best field in a field mask
+func fieldWeight(fields uint8) float64 {
+	var w float64
+	for i, f := range searchFields {
+		if fields&(1<<i) != 0 && f.weight > w {
+			w = f.weight
+		}
+	}
+	return w
+}
+
+// searchFieldMask turns field names into a field mask, all fields if none
+// are given
+func searchFieldMask(names []string) (uint8, error) {
+	var mask uint8
+	for _, entry := range names {
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			found := false
+			for i, f := range searchFields {
+				if f.name == name {
+					mask |= 1 << i
+					found = true
+				}
+			}
+			if !found {
+				return 0, status.Errorf(codes.InvalidArgument, "Unknown search field: %s", name)
+			}
+		}
+	}
+	if mask == 0 {
+		return allSearchFields, nil
+	}
+	return mask, nil
+}
+
+// highlight returns the searched fields of a match that contain a matching
+// word, HTML-escaped and with those words wrapped in <em> tags
+func (m *searchMatch) highlight(fields uint8) map[string]string {
+	highlights := make(map[string]string)
+	for i, f := range searchFields {
+		if fields&(1<<i) == 0 {
+			continue
+		}
+		text := f.value(m.doc.emp)
+		var b strings.Builder
+		pos, found := 0, false
+		for _, tok := range tokenize(text) {
+			if !m.terms[tok.term] {
+				continue
+			}
+			b.WriteString(html.EscapeString(text[pos:tok.start]))
+			b.WriteString("<em>")
+			b.WriteString(html.EscapeString(text[tok.start:tok.end]))
+			b.WriteString("</em>")
+			pos, found = tok.end, true
+		}
+		if found {
+			b.WriteString(html.EscapeString(text[pos:]))
+			highlights[f.name] = b.String()
+		}
+	}
+	return highlights
+}
+
+// SearchEmployees
+func (s *server) SearchEmployees(ctx context.Context, req *pb.SearchEmployeesRequest) (*pb.SearchEmployeesResponse, error) {
+	log.Println("SearchEmployees RPC called")
+
+	if strings.TrimSpace(req.GetQ()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "q is required")
+	}
+	fields, err := searchFieldMask(req.GetFields())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	offset := 0
+	if req.GetPageToken() != "" {
+		offset, err = strconv.Atoi(req.GetPageToken())
+		if err != nil || offset < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+	}
+
+	matches, err := s.search.search(req.GetQ(), fields, req.GetIncludeTerminated())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchEmployeesResponse{TotalSize: int32(len(matches))}
+	end := min(offset+pageSize, len(matches))
+	if end < len(matches) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	for _, m := range matches[min(offset, end):end] {
+		resp.Hits = append(resp.Hits, &pb.SearchHit{
+			Employee:   m.doc.emp.toProto(),
+			Score:      math.Round(m.score*1000) / 1000,
+			Highlights: m.highlight(fields),
+		})
+	}
+	return resp, nil
+}
+
+// employeeIndex is an in-memory view of the employees collection, kept in
+// sync by followEmployees
+type employeeIndex interface {
+	// load replaces everything in the index
+	load(emps []*Employee)
+	put(emp *Employee)
+	remove(id primitive.ObjectID)
+}
+
+// followEmployees keeps indexes in sync with the employees collection. It
+// loads every employee and then applies the changes from the collection's
+// change stream. If the stream breaks, it loads everything again after
+// interval. Without change streams, as on a standalone server, it reloads
+// every interval instead.
+func followEmployees(ctx context.Context, employees *mongo.Collection, interval time.Duration, indexes ...employeeIndex) {
+	for {
+		if err := syncEmployees(ctx, employees, indexes); err != nil && ctx.Err() == nil {
+			log.Printf("Employee indexes out of sync: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// syncEmployees loads the indexes and follows the change stream until it
+// fails. The stream is opened first, so no change made during the load is
+// missed.
+func syncEmployees(ctx context.Context, employees *mongo.Collection, indexes []employeeIndex) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}}},
+	}
+	cs, err := employees.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		if loadErr := loadEmployeeIndexes(ctx, employees, indexes...); loadErr != nil {
+			return loadErr
+		}
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == mongoChangeStreamNotSupported {
+			return nil
+		}
+		return err
+	}
+	defer cs.Close(ctx)
+
+	if err := loadEmployeeIndexes(ctx, employees, indexes...); err != nil {
+		return err
+	}
+	for cs.Next(ctx) {
+		var change changeEvent
+		if err := cs.Decode(&change); err != nil {
+			return err
+		}
+		for _, index := range indexes {
+			// A document updated and then deleted before the lookup has no
+			// full document
+			if change.OperationType == "delete" || change.FullDocument == nil {
+				index.remove(change.DocumentKey.ID)
+			} else {
+				index.put(change.FullDocument)
+			}
+		}
+	}
+	return cs.Err()
+}
+
+// loadEmployeeIndexes loads every employee into the indexes
+func loadEmployeeIndexes(ctx context.Context, employees *mongo.Collection, indexes ...employeeIndex) error {
+	cursor, err := employees.Find(ctx, bson.M{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	var emps []*Employee
+	if err := cursor.All(ctx, &emps); err != nil {
+		return status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+	for _, index := range indexes {
+		index.load(emps)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// searchTestIndex indexes a few employees with similar names
+func searchTestIndex() *searchIndex {
+	emps := []*Employee{
+		{FirstName: "John", LastName: "Smith", Email: "john.smith@example.com", Position: "Engineer", Department: "Engineering"},
+		{FirstName: "Jon", LastName: "Smyth", Email: "jon.smyth@example.com", Position: "Accountant", Department: "Finance"},
+		{FirstName: "Johanna", LastName: "Baker", Email: "jbaker@example.com", Position: "Designer", Department: "Engineering"},
+		{FirstName: "Mark", LastName: "Johnson", Email: "mark@example.com", Position: "Engineer", Department: "Sales"},
+		{FirstName: "José", LastName: "Álvarez", Email: "jose@example.com", Position: "Engineer", Department: "Sales"},
+		{FirstName: "John", LastName: "Smithers", Email: "old@example.com", Position: "Engineer", Department: "Sales", Status: statusTerminated},
+	}
+	for _, emp := range emps {
+		emp.ID = primitive.NewObjectID()
+	}
+	index := newSearchIndex()
+	index.load(emps)
+	return index
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []searchToken
+	}{
+		{"", nil},
+		{" -.@ ", nil},
+		{"John", []searchToken{{"john", 0, 4}}},
+		{"john.smith@example.com", []searchToken{{"john", 0, 4}, {"smith", 5, 10}, {"example", 11, 18}, {"com", 19, 22}}},
+		{"O'Neil-Day 2nd", []searchToken{{"o", 0, 1}, {"neil", 2, 6}, {"day", 7, 10}, {"2nd", 11, 14}}},
+		{"José ÁLVAREZ", []searchToken{{"jose", 0, 5}, {"alvarez", 6, 14}}},
+		// A combining accent stays part of its word
+		{"José", []searchToken{{"jose", 0, 6}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"", "", 2, 0},
+		{"abc", "", 3, 3},
+		{"", "abc", 3, 3},
+		{"smith", "smith", 2, 0},
+		{"smith", "smyth", 2, 1},
+		{"jon", "john", 2, 1},
+		{"john", "jhon", 2, 1},
+		{"kitten", "sitting", 3, 3},
+		// Past the limit it gives up with limit+1
+		{"kitten", "sitting", 2, 3},
+		{"abcdef", "uvwxyz", 2, 3},
+		{"josé", "jose", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance([]rune(tt.a), []rune(tt.b), tt.limit); got != tt.want {
+				t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxTypos(t *testing.T) {
+	for n, want := range map[int]int{0: 0, 2: 0, 3: 1, 6: 1, 7: 2, 20: 2} {
+		if got := maxTypos(n); got != want {
+			t.Errorf("maxTypos(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestSearchFieldMask(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  uint8
+		code  codes.Code
+	}{
+		{nil, allSearchFields, codes.OK},
+		{[]string{"", " , "}, allSearchFields, codes.OK},
+		{[]string{"first_name"}, 1 << 0, codes.OK},
+		{[]string{"Last_Name", " email "}, 1<<1 | 1<<2, codes.OK},
+		{[]string{"position,department"}, 1<<3 | 1<<4, codes.OK},
+		{[]string{"first_name", "salary"}, 0, codes.InvalidArgument},
+		{[]string{"first name"}, 0, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := searchFieldMask(tt.names)
+		if status.Code(err) != tt.code || got != tt.want {
+			t.Errorf("searchFieldMask(%q) = %05b, %v, want %05b, %v", tt.names, got, err, tt.want, tt.code)
+		}
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	index := searchTestIndex()
+	tests := []struct {
+		name       string
+		query      string
+		fields     []string
+		terminated bool
+		// Full names, best first
+		want []string
+	}{
+		{name: "empty", query: "", want: nil},
+		{name: "punctuation only", query: "-- @", want: nil},
+		{name: "exact before prefix before typo", query: "john", want: []string{"John Smith", "Mark Johnson", "Jon Smyth"}},
+		{name: "terminated included", query: "john", terminated: true, want: []string{"John Smith", "John Smithers", "Mark Johnson", "Jon Smyth"}},
+		{name: "typos in both words", query: "jon smyth", want: []string{"Jon Smyth", "John Smith"}},
+		{name: "every word must match", query: "john sales", want: nil},
+		{name: "every word must match a terminated employee", query: "john sales", terminated: true, want: []string{"John Smithers"}},
+		{name: "a typo still matches a word", query: "john finance", want: []string{"Jon Smyth"}},
+		{name: "earlier words are not prefixes", query: "jo smith", want: nil},
+		{name: "last word is a prefix", query: "smith jo", want: []string{"John Smith", "Jon Smyth"}},
+		{name: "accents are ignored", query: "jose alvarez", want: []string{"José Álvarez"}},
+		{name: "accents in the query are ignored", query: "ÁLVAREZ", want: []string{"José Álvarez"}},
+		// john is in two employees, so it counts for less; ties go by last name
+		{name: "rarer words first", query: "jo", want: []string{"Johanna Baker", "Mark Johnson", "Jon Smyth", "José Álvarez", "John Smith"}},
+		{name: "short words allow no typos", query: "jn", want: nil},
+		{name: "one typo in a short word", query: "jhn", want: []string{"Jon Smyth", "John Smith"}},
+		{name: "two typos in a long word", query: "engeneerign", want: []string{"Johanna Baker", "John Smith"}},
+		{name: "three typos are too many", query: "enginnerrs", want: nil},
+		// A position match outweighs a department one
+		{name: "heavier field first", query: "engineer", want: []string{"Mark Johnson", "John Smith", "José Álvarez", "Johanna Baker"}},
+		{name: "restricted to a field", query: "engineer", fields: []string{"department"}, want: []string{"Johanna Baker", "John Smith"}},
+		{name: "field without a match", query: "engineer", fields: []string{"first_name"}, want: nil},
+		{name: "email words", query: "jbaker", fields: []string{"email"}, want: []string{"Johanna Baker"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := searchFieldMask(tt.fields)
+			if err != nil {
+				t.Fatalf("searchFieldMask: %v", err)
+			}
+			matches, err := index.search(tt.query, fields, tt.terminated)
+			if err != nil {
+				t.Fatalf("search(%q): %v", tt.query, err)
+			}
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.doc.emp.FirstName+" "+m.doc.emp.LastName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+			for i := 1; i < len(matches); i++ {
+				if matches[i].score > matches[i-1].score {
+					t.Errorf("%s scores %v, more than %s before it", got[i], matches[i].score, got[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestSearchNotLoaded(t *testing.T) {
+	_, err := newSearchIndex().search("john", allSearchFields, false)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("search before loading = %v, want Unavailable", err)
+	}
+}
+
+func TestSearchHighlight(t *testing.T) {
+	emp := &Employee{ID: primitive.NewObjectID(), FirstName: "Ann-Marie", LastName: "<O'Neil>", Email: "ann@example.com", Department: "R&D"}
+	index := newSearchIndex()
+	index.load([]*Employee{emp})
+
+	tests := []struct {
+		query  string
+		fields uint8
+		want   map[string]string
+	}{
+		{"marie", allSearchFields, map[string]string{"first_name": "Ann-<em>Marie</em>"}},
+		{"ann", allSearchFields, map[string]string{"first_name": "<em>Ann</em>-Marie", "email": "<em>ann</em>@example.com"}},
+		{"ann", 1 << 2, map[string]string{"email": "<em>ann</em>@example.com"}},
+		{"marks", allSearchFields, nil},
+		{"oneil", allSearchFields, map[string]string{"last_name": "&lt;O&#39;<em>Neil</em>&gt;"}},
+		{"neil", allSearchFields, map[string]string{"last_name": "&lt;O&#39;<em>Neil</em>&gt;"}},
+		{"r d", allSearchFields, map[string]string{"department": "<em>R</em>&amp;<em>D</em>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches, err := index.search(tt.query, tt.fields, false)
+			if err != nil {
+				t.Fatalf("search(%q): %v", tt.query, err)
+			}
+			if tt.want == nil {
+				if len(matches) != 0 {
+					t.Errorf("search(%q) matched %v", tt.query, matches[0].terms)
+				}
+				return
+			}
+			if len(matches) != 1 {
+				t.Fatalf("search(%q) found %d employees, want 1", tt.query, len(matches))
+			}
+			if got := matches[0].highlight(tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("highlight = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
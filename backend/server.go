@@ -2,46 +2,175 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	pb "EMPLOYEE_APP/backend/pb"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MongoDB Employee model
 type Employee struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	FirstName  string             `bson:"first_name"`
-	LastName   string             `bson:"last_name"`
-	Email      string             `bson:"email"`
-	Position   string             `bson:"position"`
-	Department string             `bson:"department"`
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	FirstName    string             `bson:"first_name"`
+	LastName     string             `bson:"last_name"`
+	Email        string             `bson:"email"`
+	Position     string             `bson:"position"`
+	Department   string             `bson:"department"`
+	DepartmentID primitive.ObjectID `bson:"department_id,omitempty"`
+	ManagerID    primitive.ObjectID `bson:"manager_id,omitempty"`
+	PositionID   primitive.ObjectID `bson:"position_id,omitempty"`
+
+	// Lifecycle fields, only written by the employment RPCs
+	Status          string            `bson:"status,omitempty"`
+	HireDate        *time.Time        `bson:"hire_date,omitempty"`
+	TerminationDate *time.Time        `bson:"termination_date,omitempty"`
+	StatusHistory   []EmploymentEvent `bson:"status_history,omitempty"`
+
+	// Values of the defined custom fields by name: strings, float64
+	// numbers, booleans and dates
+	CustomFields bson.M `bson:"custom_fields,omitempty"`
+
+	// ID in another system, such as the identity provider
+	ExternalID string `bson:"external_id,omitempty"`
+}
+
+// toProto converts the stored model into its API representation
+func (e Employee) toProto() *pb.Employee {
+	emp := &pb.Employee{
+		Id:         e.ID.Hex(),
+		FirstName:  e.FirstName,
+		LastName:   e.LastName,
+		Email:      e.Email,
+		Position:   e.Position,
+		Department: e.Department,
+		ExternalId: e.ExternalID,
+	}
+	if !e.ManagerID.IsZero() {
+		emp.ManagerId = e.ManagerID.Hex()
+	}
+	if !e.DepartmentID.IsZero() {
+		emp.DepartmentId = e.DepartmentID.Hex()
+	}
+	if !e.PositionID.IsZero() {
+		emp.PositionId = e.PositionID.Hex()
+	}
+
+	emp.Status = statusToProto[e.Status]
+	if e.HireDate != nil {
+		emp.HireDate = timestamppb.New(*e.HireDate)
+	}
+	if e.TerminationDate != nil {
+		emp.TerminationDate = timestamppb.New(*e.TerminationDate)
+	}
+	for _, ev := range e.StatusHistory {
+		emp.StatusHistory = append(emp.StatusHistory, ev.toProto())
+	}
+	emp.CustomFields = customValuesToProto(e.CustomFields)
+	return emp
+}
+
+// employeeFromProto copies the writable fields of an API employee
+func employeeFromProto(req *pb.Employee) Employee {
+	return Employee{
+		FirstName:  req.GetFirstName(),
+		LastName:   req.GetLastName(),
+		Email:      req.GetEmail(),
+		Position:   req.GetPosition(),
+		Department: req.GetDepartment(),
+		ExternalID: strings.TrimSpace(req.GetExternalId()),
+	}
 }
 
 type server struct {
 	pb.UnimplementedEmployeeServiceServer
-	employeesCollection *mongo.Collection
+	employeesCollection   *mongo.Collection
+	departmentsCollection *mongo.Collection
+	positionsCollection   *mongo.Collection
+	versionsCollection    *mongo.Collection
+	revisionsCollection   *mongo.Collection
+	outboxCollection      *mongo.Collection
+	// Definitions the custom field values are checked against
+	customFieldsCollection *mongo.Collection
+	// Kept in sync with the employees collection by followEmployees
+	search  *searchIndex
+	suggest *suggestIndex
 }
 
-func NewServer(collection *mongo.Collection) pb.EmployeeServiceServer {
-	return &server{employeesCollection: collection}
+func NewServer(employees, departments, positions, versions, revisions, outbox, customFields *mongo.Collection) *server {
+	return &server{
+		employeesCollection:    employees,
+		departmentsCollection:  departments,
+		positionsCollection:    positions,
+		versionsCollection:     versions,
+		revisionsCollection:    revisions,
+		outboxCollection:       outbox,
+		customFieldsCollection: customFields,
+		search:                 newSearchIndex(),
+		suggest:                newSuggestIndex(),
+	}
+}
+
+// newDatabaseServer returns an employee server on the collections of db, as
+// named in every tenant's database
+func newDatabaseServer(db *mongo.Database) *server {
+	return NewServer(db.Collection("employees"), db.Collection("departments"), db.Collection("positions"),
+		db.Collection("employee_versions"), db.Collection("employee_revisions"), db.Collection("outbox"), db.Collection("custom_fields"))
 }
 
 // CreateEmployee
 func (s *server) CreateEmployee(ctx context.Context, req *pb.Employee) (*pb.Employee, error) {
 	log.Println("CreateEmployee RPC called")
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Employee, error) {
+		return s.createEmployee(ctx, req)
+	})
+}
 
-	emp := Employee{
-		FirstName:  req.GetFirstName(),
-		LastName:   req.GetLastName(),
-		Email:      req.GetEmail(),
-		Position:   req.GetPosition(),
-		Department: req.GetDepartment(),
+func (s *server) createEmployee(ctx context.Context, req *pb.Employee) (*pb.Employee, error) {
+	effective, err := effectiveDate(req.GetEffectiveDate())
+	if err != nil {
+		return nil, err
+	}
+	if effective.After(time.Now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "New employees cannot be future-dated, create them as PRE_BOARDING instead")
+	}
+
+	emp := employeeFromProto(req)
+
+	managerID, err := s.validateManager(ctx, primitive.NilObjectID, req.GetManagerId())
+	if err != nil {
+		return nil, err
+	}
+	emp.ManagerID = managerID
+
+	emp.DepartmentID, emp.Department, err = s.resolveDepartment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	emp.PositionID, emp.Position, err = s.resolvePosition(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	emp.CustomFields, err = s.customFieldValues(ctx, req.GetCustomFields())
+	if err != nil {
+		return nil, err
+	}
+
+	emp.Status, err = initialStatus(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	if emp.Status == statusActive {
+		emp.HireDate = &effective
 	}
 
 	res, err := s.employeesCollection.InsertOne(ctx, emp)
@@ -53,82 +182,252 @@ func (s *server) CreateEmployee(ctx context.Context, req *pb.Employee) (*pb.Empl
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "Failed to get inserted ID")
 	}
+	emp.ID = oid
+
+	doc, err := toDocument(emp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode employee: %v", err)
+	}
+	if err := s.insertVersion(ctx, oid, effective, nil, doc, true); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, oid, actionCreate, &effective, nil, doc); err != nil {
+		return nil, err
+	}
 
-	req.Id = oid.Hex()
-	return req, nil
+	created := emp.toProto()
+	created.EffectiveDate = timestamppb.New(effective)
+	return created, nil
+}
+
+// GetEmployee
+func (s *server) GetEmployee(ctx context.Context, req *pb.GetEmployeeRequest) (*pb.Employee, error) {
+	log.Println("GetEmployee RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	if req.GetAsOf() == nil {
+		var emp Employee
+		err = s.employeesCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&emp)
+		if err == mongo.ErrNoDocuments {
+			return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", req.GetId())
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+		}
+		return emp.toProto(), nil
+	}
+
+	if err := req.GetAsOf().CheckValid(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+	}
+	asOf := req.GetAsOf().AsTime()
+
+	v, err := s.versionAt(ctx, oid, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, status.Errorf(codes.NotFound, "Employee %s did not exist on %s", req.GetId(), asOf.Format(time.RFC3339))
+	}
+
+	emp, err := snapshotToEmployee(v.Employee)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+	emp.ID = oid
+
+	found := emp.toProto()
+	found.EffectiveDate = timestamppb.New(v.EffectiveFrom)
+	return found, nil
 }
 
 // GetEmployees (list all)
-func (s *server) GetEmployees(ctx context.Context, req *pb.Empty) (*pb.EmployeeList, error) {
+func (s *server) GetEmployees(ctx context.Context, req *pb.ListEmployeesRequest) (*pb.EmployeeList, error) {
 	log.Println("GetEmployees RPC called")
 
-	cursor, err := s.employeesCollection.Find(ctx, bson.M{})
+	filter, sort, err := s.listQuery(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var employees []*pb.Employee
-	for cursor.Next(ctx) {
-		var emp Employee
-		if err := cursor.Decode(&emp); err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid as_of: %v", err)
+		}
+		cursor, err := s.employeesAsOf(ctx, req.GetAsOf().AsTime(), filter, sort)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
 		}
+		return decodeEmployeeList(ctx, cursor)
+	}
 
-		employees = append(employees, &pb.Employee{
-			Id:         emp.ID.Hex(),
-			FirstName:  emp.FirstName,
-			LastName:   emp.LastName,
-			Email:      emp.Email,
-			Position:   emp.Position,
-			Department: emp.Department,
-		})
+	opts := options.Find()
+	if len(sort) > 0 {
+		opts.SetSort(sort)
+	}
+	cursor, err := s.employeesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "Cursor error: %v", err)
+	return decodeEmployeeList(ctx, cursor)
+}
+
+// listQuery builds the filter and sort order of a list request
+func (s *server) listQuery(ctx context.Context, req *pb.ListEmployeesRequest) (bson.M, bson.D, error) {
+	var conditions bson.A
+	if !req.GetIncludeTerminated() {
+		conditions = append(conditions, notTerminated)
+	}
+	var sort bson.D
+	if len(req.GetFilter()) > 0 || req.GetOrderBy() != "" {
+		fields, err := loadQueryFields(ctx, s.customFieldsCollection)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, entry := range req.GetFilter() {
+			m, err := fields.condition(parseListFilter(entry))
+			if err != nil {
+				return nil, nil, entryError(fmt.Sprintf("filter[%d]", i), err)
+			}
+			conditions = append(conditions, m)
+		}
+		if sort, err = fields.sortOrder(req.GetOrderBy()); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	return &pb.EmployeeList{Employees: employees}, nil
+	switch len(conditions) {
+	case 0:
+		return bson.M{}, sort, nil
+	case 1:
+		return conditions[0].(bson.M), sort, nil
+	}
+	return bson.M{"$and": conditions}, sort, nil
 }
 
-// UpdateEmployee
+// UpdateEmployee applies the new field values from the effective date
+// onwards. Future-dated updates become current when their date comes.
 func (s *server) UpdateEmployee(ctx context.Context, req *pb.Employee) (*pb.Employee, error) {
 	log.Println("UpdateEmployee RPC called")
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Employee, error) {
+		return s.updateEmployee(ctx, req, actionUpdate)
+	})
+}
 
-	oid, err := primitive.ObjectIDFromHex(req.GetId())
+// updateEmployee validates and records an update, under the given revision
+// action. It runs inside the caller's transaction.
+func (s *server) updateEmployee(ctx context.Context, req *pb.Employee, action string) (*pb.Employee, error) {
+	oid, err := s.requireEmployee(ctx, req.GetId())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+		return nil, err
 	}
 
-	update := bson.M{"$set": Employee{
-		FirstName:  req.GetFirstName(),
-		LastName:   req.GetLastName(),
-		Email:      req.GetEmail(),
-		Position:   req.GetPosition(),
-		Department: req.GetDepartment(),
-	}}
+	effective, err := effectiveDate(req.GetEffectiveDate())
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := s.employeesCollection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	managerID, err := s.validateManager(ctx, oid, req.GetManagerId())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update employee: %v", err)
+		return nil, err
 	}
-	if res.MatchedCount == 0 {
-		return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", req.GetId())
+
+	emp := employeeFromProto(req)
+	emp.ManagerID = managerID
+
+	emp.DepartmentID, emp.Department, err = s.resolveDepartment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	emp.PositionID, emp.Position, err = s.resolvePosition(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	emp.CustomFields, err = s.customFieldValues(ctx, req.GetCustomFields())
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := toDocument(emp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode employee: %v", err)
+	}
+	change := employeeChange{action: action, set: set}
+	if emp.ManagerID.IsZero() {
+		change.unset = append(change.unset, "manager_id")
+	}
+	if emp.DepartmentID.IsZero() {
+		change.unset = append(change.unset, "department_id")
+	}
+	if emp.PositionID.IsZero() {
+		change.unset = append(change.unset, "position_id")
+	}
+	if emp.CustomFields == nil {
+		change.unset = append(change.unset, "custom_fields")
+	}
+	if emp.ExternalID == "" {
+		change.unset = append(change.unset, "external_id")
+	}
+
+	snapshot, err := s.recordChange(ctx, oid, effective, change)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := snapshotToEmployee(snapshot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
 	}
+	updated.ID = oid
 
-	return req, nil
+	res := updated.toProto()
+	res.EffectiveDate = timestamppb.New(effective)
+	return res, nil
 }
 
 // DeleteEmployee
 func (s *server) DeleteEmployee(ctx context.Context, req *pb.EmployeeID) (*pb.Empty, error) {
 	log.Println("DeleteEmployee RPC called")
+	return inTransaction(ctx, s.mongoClient(), func(ctx context.Context) (*pb.Empty, error) {
+		return s.deleteEmployee(ctx, req)
+	})
+}
 
+func (s *server) deleteEmployee(ctx context.Context, req *pb.EmployeeID) (*pb.Empty, error) {
 	oid, err := primitive.ObjectIDFromHex(req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
 	}
 
+	// Reports must be moved to another manager first
+	reports, err := s.employeesCollection.CountDocuments(ctx, bson.M{"manager_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check direct reports: %v", err)
+	}
+	if reports > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Employee %s still has %d direct reports", req.GetId(), reports)
+	}
+
+	var before bson.M
+	err = s.employeesCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", req.GetId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+	}
+
+	// Past versions stay available to as-of queries
+	now := time.Now().UTC()
+	if err := s.endVersions(ctx, oid, now); err != nil {
+		return nil, err
+	}
+
 	res, err := s.employeesCollection.DeleteOne(ctx, bson.M{"_id": oid})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to delete employee: %v", err)
@@ -137,5 +436,42 @@ func (s *server) DeleteEmployee(ctx context.Context, req *pb.EmployeeID) (*pb.Em
 		return nil, status.Errorf(codes.NotFound, "Employee not found with ID: %s", req.GetId())
 	}
 
+	delete(before, "_id")
+	if err := s.recordRevision(ctx, oid, actionDelete, &now, before, nil); err != nil {
+		return nil, err
+	}
+
 	return &pb.Empty{}, nil
 }
+
+// effectiveDate returns when a change takes effect, now if not given
+func effectiveDate(ts *timestamppb.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Now().UTC(), nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "Invalid effective date: %v", err)
+	}
+	return ts.AsTime(), nil
+}
+
+// decodeEmployeeList drains a cursor of employee documents
+func decodeEmployeeList(ctx context.Context, cursor *mongo.Cursor) (*pb.EmployeeList, error) {
+	defer cursor.Close(ctx)
+
+	var employees []*pb.Employee
+	for cursor.Next(ctx) {
+		var emp Employee
+		if err := cursor.Decode(&emp); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+		}
+
+		employees = append(employees, emp.toProto())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "Cursor error: %v", err)
+	}
+
+	return &pb.EmployeeList{Employees: employees}, nil
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tiers of suggestion keys, best first. Within a tier, suggestions come in
+// the order of the key that matched.
+const (
+	// "first last" and "last first"
+	suggestFullName = iota
+	// Each word of the first and last name, for middle and double names
+	suggestNameWord
+	suggestEmail
+	suggestTiers
+)
+
+// suggestKey is a prefix-searchable key of an employee
+type suggestKey struct {
+	key string
+	id  primitive.ObjectID
+}
+
+func (k suggestKey) less(o suggestKey) bool {
+	if k.key != o.key {
+		return k.key < o.key
+	}
+	return bytes.Compare(k.id[:], o.id[:]) < 0
+}
+
+// suggestDoc is the compact copy of an employee the suggestions return
+type suggestDoc struct {
+	id          primitive.ObjectID
+	displayName string
+	email       string
+	department  string
+	terminated  bool
+	keys        [suggestTiers][]string
+}
+
+func newSuggestDoc(emp *Employee) *suggestDoc {
+	doc := &suggestDoc{
+		id:          emp.ID,
+		displayName: strings.TrimSpace(emp.FirstName + " " + emp.LastName),
+		email:       emp.Email,
+		department:  emp.Department,
+		terminated:  emp.Status == statusTerminated,
+	}
+	first, last := suggestTerms(emp.FirstName), suggestTerms(emp.LastName)
+	if name := strings.TrimSpace(first + " " + last); name != "" {
+		doc.keys[suggestFullName] = append(doc.keys[suggestFullName], name)
+		if first != "" && last != "" {
+			doc.keys[suggestFullName] = append(doc.keys[suggestFullName], last+" "+first)
+		}
+	}
+	for _, tok := range tokenize(emp.FirstName + " " + emp.LastName) {
+		doc.keys[suggestNameWord] = append(doc.keys[suggestNameWord], tok.term)
+	}
+	if email := strings.ToLower(strings.TrimSpace(emp.Email)); email != "" {
+		doc.keys[suggestEmail] = append(doc.keys[suggestEmail], email)
+	}
+	return doc
+}
+
+func (d *suggestDoc) toProto() *pb.EmployeeSuggestion {
+	return &pb.EmployeeSuggestion{
+		Id:          d.id.Hex(),
+		DisplayName: d.displayName,
+		Email:       d.email,
+		Department:  d.department,
+	}
+}
+
+// suggestTerms folds text into its words joined by single spaces, so
+// "  José  Smith" and "jose smi" compare as prefixes
+func suggestTerms(text string) string {
+	tokens := tokenize(text)
+	terms := make([]string, len(tokens))
+	for i, tok := range tokens {
+		terms[i] = tok.term
+	}
+	return strings.Join(terms, " ")
+}
+
+// suggestIndex is an in-memory prefix index of employees for type-ahead.
+// Each tier is a sorted list of keys, so a prefix is a binary search and a
+// page of suggestions a short scan.
+type suggestIndex struct {
+	mu     sync.RWMutex
+	docs   map[primitive.ObjectID]*suggestDoc
+	tiers  [suggestTiers][]suggestKey
+	loaded bool
+}
+
+func newSuggestIndex() *suggestIndex {
+	return &suggestIndex{docs: make(map[primitive.ObjectID]*suggestDoc)}
+}
+
+// load replaces everything in the index
+func (x *suggestIndex) load(emps []*Employee) {
+	docs := make(map[primitive.ObjectID]*suggestDoc, len(emps))
+	var tiers [suggestTiers][]suggestKey
+	for _, emp := range emps {
+		doc := newSuggestDoc(emp)
+		docs[emp.ID] = doc
+		for tier, keys := range doc.keys {
+			for _, key := range keys {
+				tiers[tier] = append(tiers[tier], suggestKey{key: key, id: emp.ID})
+			}
+		}
+	}
+	for _, keys := range tiers {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.docs, x.tiers, x.loaded = docs, tiers, true
+}
+
+// put adds an employee or replaces the indexed copy
+func (x *suggestIndex) put(emp *Employee) {
+	doc := newSuggestDoc(emp)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeLocked(emp.ID)
+	x.docs[emp.ID] = doc
+	for tier, keys := range doc.keys {
+		for _, key := range keys {
+			k := suggestKey{key: key, id: emp.ID}
+			list := x.tiers[tier]
+			i := sort.Search(len(list), func(i int) bool { return !list[i].less(k) })
+			list = append(list, suggestKey{})
+			copy(list[i+1:], list[i:])
+			list[i] = k
+			x.tiers[tier] = list
+		}
+	}
+}
+
+func (x *suggestIndex) remove(id primitive.ObjectID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeLocked(id)
+}
+
+func (x *suggestIndex) removeLocked(id primitive.ObjectID) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	delete(x.docs, id)
+	for tier, keys := range doc.keys {
+		for _, key := range keys {
+			k := suggestKey{key: key, id: id}
+			list := x.tiers[tier]
+			i := sort.Search(len(list), func(i int) bool { return !list[i].less(k) })
+			if i < len(list) && list[i] == k {
+				x.tiers[tier] = append(list[:i], list[i+1:]...)
+			}
+		}
+	}
+}
+
+// suggest returns up to limit employees with a key starting with prefix:
+// full names first, then name words, then emails
+func (x *suggestIndex) suggest(prefix string, limit int, includeTerminated bool) ([]*suggestDoc, error) {
+	name := suggestTerms(prefix)
+	email := strings.ToLower(strings.TrimSpace(prefix))
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if !x.loaded {
+		return nil, status.Errorf(codes.Unavailable, "The suggestion index is still loading")
+	}
+
+	var found []*suggestDoc
+	seen := make(map[primitive.ObjectID]bool, limit)
+	for tier, keys := range x.tiers {
+		p := name
+		if tier == suggestEmail {
+			p = email
+		}
+		if p == "" {
+			continue
+		}
+		for i := sort.Search(len(keys), func(i int) bool { return keys[i].key >= p }); i < len(keys) && strings.HasPrefix(keys[i].key, p); i++ {
+			id := keys[i].id
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			doc := x.docs[id]
+			if doc.terminated && !includeTerminated {
+				continue
+			}
+			found = append(found, doc)
+			if len(found) == limit {
+				return found, nil
+			}
+		}
+	}
+	return found, nil
+}
+
+// SuggestEmployees
+func (s *server) SuggestEmployees(ctx context.Context, req *pb.SuggestEmployeesRequest) (*pb.SuggestEmployeesResponse, error) {
+	log.Println("SuggestEmployees RPC called")
+
+	if strings.TrimSpace(req.GetPrefix()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "prefix is required")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	docs, err := s.suggest.suggest(req.GetPrefix(), limit, req.GetIncludeTerminated())
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SuggestEmployeesResponse{}
+	for _, doc := range docs {
+		resp.Suggestions = append(resp.Suggestions, doc.toProto())
+	}
+	return resp, nil
+}
+
+// benchSuggest measures how long the suggestion index takes to answer
+// prefixes of one to four letters of indexed names and emails, and fails
+// if the 99th percentile is over target. It indexes synthetic employees, or
+// the stored ones if synthetic is 0.
+func benchSuggest(ctx context.Context, employees *mongo.Collection, synthetic, queries, limit int, target time.Duration) error {
+	index := newSuggestIndex()
+	if synthetic > 0 {
+		index.load(syntheticEmployees(synthetic))
+	} else if err := loadEmployeeIndexes(ctx, employees, index); err != nil {
+		return err
+	}
+	if len(index.docs) == 0 {
+		return fmt.Errorf("no employees to suggest")
+	}
+
+	prefixes := benchPrefixes(index, queries)
+	latencies := make([]time.Duration, len(prefixes))
+	for i, prefix := range prefixes {
+		start := time.Now()
+		if _, err := index.suggest(prefix, limit, false); err != nil {
+			return err
+		}
+		latencies[i] = time.Since(start)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) time.Duration {
+		return latencies[int(p*float64(len(latencies)-1))]
+	}
+
+	p99 := percentile(0.99)
+	log.Printf("Suggested from %d employees for %d prefixes: p50 %v, p95 %v, p99 %v, max %v",
+		len(index.docs), len(prefixes), percentile(0.5), percentile(0.95), p99, latencies[len(latencies)-1])
+	if p99 > target {
+		return fmt.Errorf("p99 latency %v is over the %v target", p99, target)
+	}
+	return nil
+}
+
+// benchPrefixes picks n prefixes of one to four letters of the names and
+// emails in the index, the same ones on every run
+func benchPrefixes(index *suggestIndex, n int) []string {
+	docs := make([]*suggestDoc, 0, len(index.docs))
+	for _, doc := range index.docs {
+		docs = append(docs, doc)
+	}
+	// Map order is random, the prefixes should not be
+	sort.Slice(docs, func(i, j int) bool { return bytes.Compare(docs[i].id[:], docs[j].id[:]) < 0 })
+
+	rng := rand.New(rand.NewSource(1))
+	prefixes := make([]string, n)
+	for i := range prefixes {
+		doc := docs[rng.Intn(len(docs))]
+		text := doc.displayName
+		if rng.Intn(4) == 0 {
+			text = doc.email
+		}
+		if fields := strings.Fields(text); len(fields) > 1 && rng.Intn(2) == 0 {
+			text = fields[len(fields)-1]
+		}
+		prefixes[i] = string([]rune(text)[:min(1+rng.Intn(4), len([]rune(text)))])
+	}
+	return prefixes
+}
+
+// syntheticEmployees makes n employees with names drawn from short lists,
+// so that prefixes match many of them like in a real directory
+func syntheticEmployees(n int) []*Employee {
+	firstNames := []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "David", "Elizabeth", "José", "María", "Wei", "Aiko", "Olu", "Anne-Marie", "Jean", "Sofia", "Mateo", "Priya"}
+	lastNames := []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "García", "Miller", "Davis", "Rodriguez", "Martinez", "Nguyen", "Kim", "O'Brien", "van der Berg", "Müller", "Okafor", "Tanaka", "Singh", "Rossi", "Kowalski"}
+	departments := []string{"Engineering", "Sales", "Marketing", "Finance", "People", "Support", "Legal", "Operations"}
+
+	rng := rand.New(rand.NewSource(1))
+	emps := make([]*Employee, n)
+	for i := range emps {
+		first := firstNames[rng.Intn(len(firstNames))]
+		last := lastNames[rng.Intn(len(lastNames))]
+		emps[i] = &Employee{
+			ID:         primitive.NewObjectID(),
+			FirstName:  first,
+			LastName:   last,
+			Email:      fmt.Sprintf("%s.%s%d@example.com", strings.ReplaceAll(suggestTerms(first), " ", ""), strings.ReplaceAll(suggestTerms(last), " ", ""), i),
+			Department: departments[rng.Intn(len(departments))],
+		}
+		if rng.Intn(10) == 0 {
+			emps[i].Status = statusTerminated
+		}
+	}
+	return emps
+}
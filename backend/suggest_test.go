@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkSuggest measures suggestions from an in-memory index of
+// synthetic employees, like the bench-suggest command does against a
+// server's data
+func BenchmarkSuggest(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			index := newSuggestIndex()
+			index.load(syntheticEmployees(n))
+			prefixes := benchPrefixes(index, 1000)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := index.suggest(prefixes[i%len(prefixes)], 10, false); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
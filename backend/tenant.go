@@ -0,0 +1,932 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tenantHeader picks the tenant of a request. REST callers send it as the
+// X-Tenant-ID HTTP header.
+const tenantHeader = "x-tenant-id"
+
+// defaultTenantID is the tenant of requests that do not name one. Its data
+// is in the database the server is configured with.
+const defaultTenantID = "default"
+
+// Tenant IDs become part of database names, which are limited to 64 bytes
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// MongoDB Tenant model, stored in the tenants collection of the default
+// tenant's database
+type Tenant struct {
+	ID        string       `bson:"_id"`
+	Name      string       `bson:"name"`
+	Database  string       `bson:"database"`
+	Config    TenantConfig `bson:"config"`
+	CreatedAt time.Time    `bson:"created_at"`
+}
+
+// TenantConfig overrides server settings for one tenant. Zero values keep
+// the server's setting.
+type TenantConfig struct {
+	EventSinks         string `bson:"event_sinks,omitempty"`
+	EventSource        string `bson:"event_source,omitempty"`
+	WebhookMaxAttempts int    `bson:"webhook_max_attempts,omitempty"`
+	JobWorkers         int    `bson:"job_workers,omitempty"`
+}
+
+func (t Tenant) toProto() *pb.Tenant {
+	tenant := &pb.Tenant{
+		Id:       t.ID,
+		Name:     t.Name,
+		Database: t.Database,
+		Config: &pb.TenantConfig{
+			EventSinks:         t.Config.EventSinks,
+			EventSource:        t.Config.EventSource,
+			WebhookMaxAttempts: int32(t.Config.WebhookMaxAttempts),
+			JobWorkers:         int32(t.Config.JobWorkers),
+		},
+	}
+	if !t.CreatedAt.IsZero() {
+		tenant.CreateTime = timestamppb.New(t.CreatedAt)
+	}
+	return tenant
+}
+
+// tenantConfigFromProto validates the settings of a tenant. Tenants may only
+// send events to http(s) URLs that webhooks would be allowed to reach.
+func tenantConfigFromProto(ctx context.Context, cfg Config, req *pb.TenantConfig) (TenantConfig, error) {
+	c := TenantConfig{
+		EventSinks:         strings.TrimSpace(req.GetEventSinks()),
+		EventSource:        strings.TrimSpace(req.GetEventSource()),
+		WebhookMaxAttempts: int(req.GetWebhookMaxAttempts()),
+		JobWorkers:         int(req.GetJobWorkers()),
+	}
+	if c.WebhookMaxAttempts < 0 || c.JobWorkers < 0 {
+		return c, status.Errorf(codes.InvalidArgument, "webhook_max_attempts and job_workers must not be negative")
+	}
+	if c.JobWorkers > 32 {
+		return c, status.Errorf(codes.InvalidArgument, "job_workers must be at most 32")
+	}
+	urls, err := tenantSinkURLs(c.EventSinks)
+	if err != nil {
+		return c, status.Errorf(codes.InvalidArgument, "Invalid event_sinks: %v", err)
+	}
+	guard, err := newWebhookGuard(cfg.WebhookAllowedHosts)
+	if err != nil {
+		return c, status.Errorf(codes.Internal, "Invalid webhook allowlist: %v", err)
+	}
+	for _, u := range urls {
+		if err := guard.checkURL(ctx, u); err != nil {
+			return c, status.Errorf(codes.InvalidArgument, "Event sink is not allowed: %v", err)
+		}
+	}
+	return c, nil
+}
+
+// tenantSinkURLs splits the event sinks of a tenant. Unlike the server's,
+// they cannot be files, which would let a tenant write anywhere the server
+// can.
+func tenantSinkURLs(spec string) ([]string, error) {
+	var urls []string
+	for _, target := range strings.Split(spec, ",") {
+		target = strings.TrimSpace(target)
+		switch {
+		case target == "":
+		case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+			if _, err := url.Parse(target); err != nil {
+				return nil, err
+			}
+			urls = append(urls, target)
+		default:
+			return nil, fmt.Errorf("tenants can only send events to http(s) URLs, not %q", target)
+		}
+	}
+	return urls, nil
+}
+
+// forTenant is the server config with the tenant's overrides. Events of
+// other tenants than the default one have a source of their own unless
+// they set one.
+func (c Config) forTenant(t Tenant) Config {
+	if t.Config.EventSinks != "" {
+		c.EventSinks = t.Config.EventSinks
+	}
+	if t.Config.EventSource != "" {
+		c.EventSource = t.Config.EventSource
+	} else if t.ID != defaultTenantID {
+		c.EventSource = strings.TrimSuffix(c.EventSource, "/") + "/tenants/" + t.ID
+	}
+	if t.Config.WebhookMaxAttempts > 0 {
+		c.WebhookMaxAttempts = t.Config.WebhookMaxAttempts
+	}
+	if t.Config.JobWorkers > 0 {
+		c.JobWorkers = t.Config.JobWorkers
+	}
+	return c
+}
+
+// tenantDatabase names the database of a tenant
+func tenantDatabase(cfg Config, id string) string {
+	if id == defaultTenantID {
+		return cfg.DatabaseName
+	}
+	return cfg.DatabaseName + "_" + strings.ReplaceAll(id, "-", "_")
+}
+
+// tenantRuntime is everything serving one tenant: the servers bound to its
+// database and its background work
+type tenantRuntime struct {
+	tenant       Tenant
+	audit        *auditLog
+	employees    *server
+	departments  pb.DepartmentServiceServer
+	positions    pb.PositionServiceServer
+	audits       pb.AuditServiceServer
+	webhooks     pb.WebhookServiceServer
+	analytics    pb.AnalyticsServiceServer
+	customFields pb.CustomFieldServiceServer
+	jobs         pb.JobServiceServer
+	operations   longrunningpb.OperationsServer
+	scim         pb.ScimServiceServer
+	stop         context.CancelFunc
+}
+
+// startTenant connects the servers to the tenant's database, creates its
+// indexes and starts its background work. A tenant whose indexes cannot be
+// created is not started; its requests try again.
+func startTenant(cfg Config, client *mongo.Client, t Tenant) (*tenantRuntime, error) {
+	cfg = cfg.forTenant(t)
+	db := client.Database(t.Database)
+
+	employeesCollection := db.Collection("employees")
+	departmentsCollection := db.Collection("departments")
+	positionsCollection := db.Collection("positions")
+	versionsCollection := db.Collection("employee_versions")
+	revisionsCollection := db.Collection("employee_revisions")
+	auditCollection := db.Collection("audit_log")
+	outboxCollection := db.Collection("outbox")
+	webhooksCollection := db.Collection("webhooks")
+	deliveriesCollection := db.Collection("webhook_deliveries")
+	jobsCollection := db.Collection("operations")
+	customFieldsCollection := db.Collection("custom_fields")
+
+	guard, err := newWebhookGuard(cfg.WebhookAllowedHosts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook allowlist: %v", err)
+	}
+	// Sinks the tenant set itself go through the webhook guard
+	sinkClient := &http.Client{Timeout: cfg.WebhookTimeout}
+	if t.Config.EventSinks != "" {
+		if _, err := tenantSinkURLs(t.Config.EventSinks); err != nil {
+			return nil, fmt.Errorf("invalid event sinks: %v", err)
+		}
+		sinkClient = guard.client(cfg.WebhookTimeout)
+	}
+	sinks, err := newEventSinks(cfg.EventSinks, cfg.EventFormat, sinkClient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event sinks: %v", err)
+	}
+
+	indexCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := ensureIndexes(indexCtx, employeesCollection, departmentsCollection, positionsCollection, versionsCollection, revisionsCollection, auditCollection, outboxCollection, deliveriesCollection, jobsCollection, customFieldsCollection); err != nil {
+		// The audit log's unique sequence index keeps concurrent appends from
+		// forking the hash chain, so the tenant is not served without it
+		return nil, fmt.Errorf("failed to create indexes: %v", err)
+	}
+
+	employeeServer := NewServer(employeesCollection, departmentsCollection, positionsCollection, versionsCollection, revisionsCollection, outboxCollection, customFieldsCollection)
+	audit := newAuditLog(auditCollection, cfg.AuditHMACKey)
+	jobs := newJobRunner(jobsCollection, employeeServer, cfg.JobLease, cfg.JobMaxAttempts)
+	ctx, stop := context.WithCancel(context.Background())
+	departments := NewDepartmentServer(departmentsCollection, employeeServer)
+	rt := &tenantRuntime{
+		tenant:       t,
+		audit:        audit,
+		employees:    employeeServer,
+		departments:  departments,
+		positions:    NewPositionServer(positionsCollection, employeeServer),
+		audits:       NewAuditServer(audit),
+		webhooks:     NewWebhookServer(webhooksCollection, deliveriesCollection, guard),
+		analytics:    NewAnalyticsServer(employeesCollection, versionsCollection, customFieldsCollection),
+		customFields: NewCustomFieldServer(customFieldsCollection, employeesCollection),
+		jobs:         NewJobServer(jobs),
+		operations:   NewOperationsServer(jobsCollection, jobs),
+		scim:         NewScimServer(employeeServer, departments),
+		stop:         stop,
+	}
+
+	// Future-dated employee changes become current in the background
+	go employeeServer.runVersionScheduler(ctx, cfg.SchedulerInterval)
+
+	// Employee events go out to the event sinks and webhooks from the outbox
+	dispatcher := newOutboxDispatcher(outboxCollection, webhooksCollection, deliveriesCollection, sinks, guard, cfg.EventSource, cfg.WebhookTimeout, cfg.WebhookMaxAttempts)
+	go dispatcher.run(ctx, cfg.WebhookInterval)
+
+	// The search and suggestion indexes follow employee changes
+	go followEmployees(ctx, employeesCollection, cfg.SearchResyncInterval, employeeServer.search, employeeServer.suggest)
+
+	// Imports, exports and reindexes started through JobService
+	go jobs.run(ctx, cfg.JobWorkers, cfg.JobInterval)
+
+	log.Printf("Serving tenant %s from database %s", t.ID, t.Database)
+	return rt, nil
+}
+
+// close stops the background work of the tenant. Calls in progress finish.
+func (rt *tenantRuntime) close() {
+	rt.stop()
+}
+
+// tenantRegistry resolves the tenant of each request and keeps a runtime
+// running for every tenant
+type tenantRegistry struct {
+	cfg               Config
+	client            *mongo.Client
+	tenantsCollection *mongo.Collection
+	// Verifies bearer tokens, nil when tokens are not configured
+	keys    jwt.Keyfunc
+	methods []string
+
+	mu       sync.RWMutex
+	runtimes map[string]*tenantRuntime
+	// Services whose calls run on the request's tenant
+	scoped map[string]bool
+}
+
+func newTenantRegistry(cfg Config, client *mongo.Client) (*tenantRegistry, error) {
+	r := &tenantRegistry{
+		cfg:               cfg,
+		client:            client,
+		tenantsCollection: client.Database(cfg.DatabaseName).Collection("tenants"),
+		runtimes:          map[string]*tenantRuntime{},
+		scoped:            map[string]bool{},
+	}
+
+	switch {
+	case cfg.TenantJWTPublicKey != "":
+		pem, err := os.ReadFile(cfg.TenantJWTPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+			r.keys = func(*jwt.Token) (interface{}, error) { return key, nil }
+			r.methods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+		} else if key, err := jwt.ParseECPublicKeyFromPEM(pem); err == nil {
+			r.keys = func(*jwt.Token) (interface{}, error) { return key, nil }
+			r.methods = []string{"ES256", "ES384", "ES512"}
+		} else {
+			return nil, fmt.Errorf("%s is not an RSA or ECDSA public key", cfg.TenantJWTPublicKey)
+		}
+	case cfg.TenantJWTSecret != "":
+		secret := []byte(cfg.TenantJWTSecret)
+		r.keys = func(*jwt.Token) (interface{}, error) { return secret, nil }
+		r.methods = []string{"HS256", "HS384", "HS512"}
+	case cfg.TenantRequired:
+		return nil, fmt.Errorf("TENANT_REQUIRED needs TENANT_JWT_SECRET or TENANT_JWT_PUBLIC_KEY")
+	case cfg.TenantInsecureHeader:
+		log.Printf("TENANT_INSECURE_HEADER is set: the X-Tenant-ID header is trusted and anyone can manage tenants")
+	}
+	return r, nil
+}
+
+// defaultTenant is the default tenant, with its stored settings if any
+func (r *tenantRegistry) defaultTenant(ctx context.Context) (Tenant, error) {
+	t := Tenant{ID: defaultTenantID, Name: "Default", Database: r.cfg.DatabaseName}
+	err := r.tenantsCollection.FindOne(ctx, bson.M{"_id": defaultTenantID}).Decode(&t)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return t, status.Errorf(codes.Internal, "Failed to retrieve tenant: %v", err)
+	}
+	t.Database = r.cfg.DatabaseName
+	return t, nil
+}
+
+// findTenant loads a tenant, nil if there is none
+func (r *tenantRegistry) findTenant(ctx context.Context, id string) (*Tenant, error) {
+	if id == defaultTenantID {
+		t, err := r.defaultTenant(ctx)
+		return &t, err
+	}
+	var t Tenant
+	err := r.tenantsCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve tenant: %v", err)
+	}
+	return &t, nil
+}
+
+// listTenants returns every tenant, the default one first
+func (r *tenantRegistry) listTenants(ctx context.Context) ([]Tenant, error) {
+	def, err := r.defaultTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.tenantsCollection.Find(ctx, bson.M{"_id": bson.M{"$ne": defaultTenantID}}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve tenants: %v", err)
+	}
+	var tenants []Tenant
+	if err := cursor.All(ctx, &tenants); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode tenant: %v", err)
+	}
+	return append([]Tenant{def}, tenants...), nil
+}
+
+// runtime returns the runtime of a tenant, starting it if the tenant was
+// created on another server since the last refresh
+func (r *tenantRegistry) runtime(ctx context.Context, id string) (*tenantRuntime, error) {
+	r.mu.RLock()
+	rt := r.runtimes[id]
+	r.mu.RUnlock()
+	if rt != nil {
+		return rt, nil
+	}
+
+	t, err := r.findTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, status.Errorf(codes.NotFound, "Tenant not found: %s", id)
+	}
+	return r.start(*t)
+}
+
+// start runs a tenant unless it is running already
+func (r *tenantRegistry) start(t Tenant) (*tenantRuntime, error) {
+	rt, err := startTenant(r.cfg, r.client, t)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to start tenant %s: %v", t.ID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if running := r.runtimes[t.ID]; running != nil {
+		rt.close()
+		return running, nil
+	}
+	r.runtimes[t.ID] = rt
+	return rt, nil
+}
+
+// restart runs a tenant with its new settings and stops the old runtime
+func (r *tenantRegistry) restart(t Tenant) error {
+	rt, err := startTenant(r.cfg, r.client, t)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to start tenant %s: %v", t.ID, err)
+	}
+
+	r.mu.Lock()
+	old := r.runtimes[t.ID]
+	r.runtimes[t.ID] = rt
+	r.mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+// stop stops serving a tenant
+func (r *tenantRegistry) stop(id string) {
+	r.mu.Lock()
+	rt := r.runtimes[id]
+	delete(r.runtimes, id)
+	r.mu.Unlock()
+	if rt != nil {
+		rt.close()
+		log.Printf("Stopped serving tenant %s", id)
+	}
+}
+
+// refresh starts the tenants created on other servers, applies changed
+// settings and stops the deleted tenants
+func (r *tenantRegistry) refresh(ctx context.Context) error {
+	tenants, err := r.listTenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored := map[string]bool{}
+	for _, t := range tenants {
+		stored[t.ID] = true
+		r.mu.RLock()
+		rt := r.runtimes[t.ID]
+		r.mu.RUnlock()
+		switch {
+		case rt == nil:
+			_, err = r.start(t)
+		case rt.tenant.Config != t.Config:
+			err = r.restart(t)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.RLock()
+	var gone []string
+	for id := range r.runtimes {
+		if !stored[id] {
+			gone = append(gone, id)
+		}
+	}
+	r.mu.RUnlock()
+	for _, id := range gone {
+		r.stop(id)
+	}
+	return nil
+}
+
+// run refreshes the tenants every interval
+func (r *tenantRegistry) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.refresh(ctx); err != nil {
+			log.Printf("Failed to refresh tenants: %v", err)
+		}
+	}
+}
+
+// claims verifies the bearer token of a request. It returns nil if there is
+// no token.
+func (r *tenantRegistry) claims(ctx context.Context) (jwt.MapClaims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	for _, v := range md.Get("authorization") {
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			token = strings.TrimSpace(v[7:])
+		}
+	}
+	if token == "" {
+		return nil, nil
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, r.keys, jwt.WithValidMethods(r.methods)); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid bearer token: %v", err)
+	}
+	return claims, nil
+}
+
+// tenantID works out the tenant of a request. With tokens configured, the
+// tenant comes from the token's claim and a tenant header must agree with
+// it. Without them every request is served from the default tenant, unless
+// TENANT_INSECURE_HEADER asks for the header to be trusted like X-Actor.
+func (r *tenantRegistry) tenantID(ctx context.Context) (string, error) {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(tenantHeader); len(v) > 0 {
+			header = strings.TrimSpace(v[0])
+		}
+	}
+
+	if r.keys == nil {
+		if header == "" || header == defaultTenantID {
+			return defaultTenantID, nil
+		}
+		if !r.cfg.TenantInsecureHeader {
+			return "", status.Errorf(codes.PermissionDenied, "Tenants other than the default one need tenant tokens to be configured")
+		}
+		return header, nil
+	}
+
+	claims, err := r.claims(ctx)
+	if err != nil {
+		return "", err
+	}
+	if claims == nil {
+		if header != "" || r.cfg.TenantRequired {
+			return "", status.Errorf(codes.Unauthenticated, "A bearer token is required")
+		}
+		return defaultTenantID, nil
+	}
+	id, _ := claims[r.cfg.TenantClaim].(string)
+	if id == "" {
+		return "", status.Errorf(codes.PermissionDenied, "The token has no %s claim", r.cfg.TenantClaim)
+	}
+	if header != "" && header != id {
+		return "", status.Errorf(codes.PermissionDenied, "The token is not for tenant %s", header)
+	}
+	return id, nil
+}
+
+type tenantKey struct{}
+
+// tenantFromContext returns the runtime of the request's tenant, nil for
+// services that are not tenant scoped
+func tenantFromContext(ctx context.Context) *tenantRuntime {
+	rt, _ := ctx.Value(tenantKey{}).(*tenantRuntime)
+	return rt
+}
+
+// scope adds the request's tenant to ctx, unless it is there already
+func (r *tenantRegistry) scope(ctx context.Context) (context.Context, *tenantRuntime, error) {
+	if rt := tenantFromContext(ctx); rt != nil {
+		return ctx, rt, nil
+	}
+	id, err := r.tenantID(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	rt, err := r.runtime(ctx, id)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return context.WithValue(ctx, tenantKey{}, rt), rt, nil
+}
+
+// registrar registers a service with server so that each call runs on the
+// implementation impl picks from the request's tenant. Pass it to the
+// generated Register function with a nil implementation.
+func (r *tenantRegistry) registrar(server *grpc.Server, impl func(*tenantRuntime) interface{}) grpc.ServiceRegistrar {
+	return tenantRegistrar{registry: r, server: server, impl: impl}
+}
+
+type tenantRegistrar struct {
+	registry *tenantRegistry
+	server   *grpc.Server
+	impl     func(*tenantRuntime) interface{}
+}
+
+func (t tenantRegistrar) RegisterService(desc *grpc.ServiceDesc, _ interface{}) {
+	r := t.registry
+	r.scoped[desc.ServiceName] = true
+
+	scoped := *desc
+	scoped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, m := range desc.Methods {
+		handler := m.Handler
+		scoped.Methods[i] = grpc.MethodDesc{
+			MethodName: m.MethodName,
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				ctx, rt, err := r.scope(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return handler(t.impl(rt), ctx, dec, interceptor)
+			},
+		}
+	}
+	scoped.Streams = make([]grpc.StreamDesc, len(desc.Streams))
+	for i, s := range desc.Streams {
+		handler := s.Handler
+		scoped.Streams[i] = s
+		scoped.Streams[i].Handler = func(_ interface{}, stream grpc.ServerStream) error {
+			ctx, rt, err := r.scope(stream.Context())
+			if err != nil {
+				return err
+			}
+			return handler(t.impl(rt), &tenantStream{ServerStream: stream, ctx: ctx})
+		}
+	}
+	t.server.RegisterService(&scoped, nil)
+}
+
+// tenantStream is a server stream whose context carries the tenant
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
+
+// tenantOperations serves google.longrunning.Operations from the jobs of
+// the request's tenant. Its generated Register function only takes a
+// *grpc.Server, so it cannot go through registrar.
+type tenantOperations struct {
+	longrunningpb.UnimplementedOperationsServer
+	registry *tenantRegistry
+}
+
+// newTenantOperations marks Operations as tenant scoped, so that its calls
+// are audited on the request's tenant like those of registrar's services
+func newTenantOperations(registry *tenantRegistry) tenantOperations {
+	registry.scoped["google.longrunning.Operations"] = true
+	return tenantOperations{registry: registry}
+}
+
+func (o tenantOperations) operations(ctx context.Context) (context.Context, longrunningpb.OperationsServer, error) {
+	ctx, rt, err := o.registry.scope(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return ctx, rt.operations, nil
+}
+
+func (o tenantOperations) ListOperations(ctx context.Context, req *longrunningpb.ListOperationsRequest) (*longrunningpb.ListOperationsResponse, error) {
+	ctx, ops, err := o.operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ops.ListOperations(ctx, req)
+}
+
+func (o tenantOperations) GetOperation(ctx context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	ctx, ops, err := o.operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ops.GetOperation(ctx, req)
+}
+
+func (o tenantOperations) DeleteOperation(ctx context.Context, req *longrunningpb.DeleteOperationRequest) (*emptypb.Empty, error) {
+	ctx, ops, err := o.operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ops.DeleteOperation(ctx, req)
+}
+
+func (o tenantOperations) CancelOperation(ctx context.Context, req *longrunningpb.CancelOperationRequest) (*emptypb.Empty, error) {
+	ctx, ops, err := o.operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ops.CancelOperation(ctx, req)
+}
+
+func (o tenantOperations) WaitOperation(ctx context.Context, req *longrunningpb.WaitOperationRequest) (*longrunningpb.Operation, error) {
+	ctx, ops, err := o.operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ops.WaitOperation(ctx, req)
+}
+
+// serviceOf returns the service part of a full method name
+func serviceOf(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service
+}
+
+// UnaryInterceptor adds the actor to calls and writes them to the audit log
+// of their tenant. Handlers of services registered through registrar add
+// the tenant before interceptors run.
+func (r *tenantRegistry) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = r.withActor(ctx)
+	rt := tenantFromContext(ctx)
+	if rt == nil {
+		var err error
+		if ctx, rt, err = r.auditTenant(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+	}
+	return rt.audit.UnaryInterceptor(ctx, req, info, handler)
+}
+
+// StreamInterceptor adds the tenant and actor to streams, whose
+// interceptors run before the handler, and writes them to the audit log of
+// their tenant
+func (r *tenantRegistry) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, rt, err := r.auditTenant(r.withActor(ss.Context()), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	ss = &tenantStream{ServerStream: ss, ctx: ctx}
+	return rt.audit.StreamInterceptor(srv, ss, info, handler)
+}
+
+// withActor makes the subject of the request's bearer token its actor when
+// tokens are configured, so that X-Actor cannot name someone else. Callers
+// without a valid token are anonymous.
+func (r *tenantRegistry) withActor(ctx context.Context) context.Context {
+	if r.keys == nil {
+		return ctx
+	}
+	var subject string
+	if claims, err := r.claims(ctx); err == nil && claims != nil {
+		subject, _ = claims.GetSubject()
+	}
+	return context.WithValue(ctx, actorKey{}, subject)
+}
+
+// auditTenant resolves the tenant whose audit log a call is written to.
+// Calls of tenant scoped services run on it and fail without one. Other
+// services, such as TenantService, go to the caller's tenant when it can be
+// worked out and to the default tenant otherwise.
+func (r *tenantRegistry) auditTenant(ctx context.Context, method string) (context.Context, *tenantRuntime, error) {
+	if r.scoped[serviceOf(method)] {
+		return r.scope(ctx)
+	}
+	if id, err := r.tenantID(ctx); err == nil {
+		if rt, err := r.runtime(ctx, id); err == nil {
+			return ctx, rt, nil
+		}
+	}
+	rt, err := r.runtime(ctx, defaultTenantID)
+	return ctx, rt, err
+}
+
+// requireAdmin lets TenantService calls through only with a token that has
+// the admin claim. Without tokens it refuses them, unless
+// TENANT_INSECURE_HEADER is set.
+func (r *tenantRegistry) requireAdmin(ctx context.Context) error {
+	if r.keys == nil {
+		if r.cfg.TenantInsecureHeader {
+			return nil
+		}
+		return status.Errorf(codes.PermissionDenied, "Managing tenants needs tenant tokens to be configured")
+	}
+	claims, err := r.claims(ctx)
+	if err != nil {
+		return err
+	}
+	if claims == nil {
+		return status.Errorf(codes.Unauthenticated, "A bearer token is required")
+	}
+	if admin, _ := claims[r.cfg.TenantAdminClaim].(bool); !admin {
+		return status.Errorf(codes.PermissionDenied, "Managing tenants needs the %s claim", r.cfg.TenantAdminClaim)
+	}
+	return nil
+}
+
+type tenantServer struct {
+	pb.UnimplementedTenantServiceServer
+	registry *tenantRegistry
+}
+
+func NewTenantServer(registry *tenantRegistry) pb.TenantServiceServer {
+	return &tenantServer{registry: registry}
+}
+
+// ListTenants
+func (s *tenantServer) ListTenants(ctx context.Context, req *pb.Empty) (*pb.TenantList, error) {
+	log.Println("ListTenants RPC called")
+
+	if err := s.registry.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	tenants, err := s.registry.listTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := &pb.TenantList{}
+	for _, t := range tenants {
+		list.Tenants = append(list.Tenants, t.toProto())
+	}
+	return list, nil
+}
+
+// GetTenant
+func (s *tenantServer) GetTenant(ctx context.Context, req *pb.TenantID) (*pb.Tenant, error) {
+	log.Println("GetTenant RPC called")
+
+	if err := s.registry.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	t, err := s.registry.findTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, status.Errorf(codes.NotFound, "Tenant not found: %s", req.GetId())
+	}
+	return t.toProto(), nil
+}
+
+// CreateTenant
+func (s *tenantServer) CreateTenant(ctx context.Context, req *pb.Tenant) (*pb.Tenant, error) {
+	log.Println("CreateTenant RPC called")
+
+	if err := s.registry.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	id := strings.TrimSpace(req.GetId())
+	if !tenantIDPattern.MatchString(id) {
+		return nil, status.Errorf(codes.InvalidArgument, "Tenant IDs are up to 32 lower case letters, digits and dashes")
+	}
+	if id == defaultTenantID {
+		return nil, status.Errorf(codes.AlreadyExists, "Tenant %s already exists", id)
+	}
+	config, err := tenantConfigFromProto(ctx, s.registry.cfg, req.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	t := Tenant{
+		ID:        id,
+		Name:      strings.TrimSpace(req.GetName()),
+		Database:  tenantDatabase(s.registry.cfg, id),
+		Config:    config,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if t.Name == "" {
+		t.Name = id
+	}
+
+	// A database left behind by a tenant deleted with keep_data is taken
+	// over as it is
+	_, err = s.registry.tenantsCollection.InsertOne(ctx, t)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.AlreadyExists, "Tenant %s already exists", id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create tenant: %v", err)
+	}
+	if _, err := s.registry.start(t); err != nil {
+		if _, delErr := s.registry.tenantsCollection.DeleteOne(ctx, bson.M{"_id": id}); delErr != nil {
+			log.Printf("Failed to remove tenant %s that did not start: %v", id, delErr)
+		}
+		return nil, err
+	}
+	return t.toProto(), nil
+}
+
+// UpdateTenant
+func (s *tenantServer) UpdateTenant(ctx context.Context, req *pb.Tenant) (*pb.Tenant, error) {
+	log.Println("UpdateTenant RPC called")
+
+	if err := s.registry.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	t, err := s.registry.findTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, status.Errorf(codes.NotFound, "Tenant not found: %s", req.GetId())
+	}
+	config, err := tenantConfigFromProto(ctx, s.registry.cfg, req.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimSpace(req.GetName()); name != "" {
+		t.Name = name
+	}
+	t.Config = config
+
+	// The default tenant is only stored once it has settings
+	_, err = s.registry.tenantsCollection.ReplaceOne(ctx, bson.M{"_id": t.ID}, t, options.Replace().SetUpsert(t.ID == defaultTenantID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update tenant: %v", err)
+	}
+	if err := s.registry.restart(*t); err != nil {
+		return nil, err
+	}
+	return t.toProto(), nil
+}
+
+// DeleteTenant
+func (s *tenantServer) DeleteTenant(ctx context.Context, req *pb.DeleteTenantRequest) (*pb.Empty, error) {
+	log.Println("DeleteTenant RPC called")
+
+	if err := s.registry.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetId() == defaultTenantID {
+		return nil, status.Errorf(codes.FailedPrecondition, "The default tenant cannot be deleted")
+	}
+	t, err := s.registry.findTenant(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, status.Errorf(codes.NotFound, "Tenant not found: %s", req.GetId())
+	}
+
+	// Other servers stop serving the tenant on their next refresh
+	if _, err := s.registry.tenantsCollection.DeleteOne(ctx, bson.M{"_id": t.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete tenant: %v", err)
+	}
+	s.registry.stop(t.ID)
+
+	if !req.GetKeepData() {
+		if err := s.registry.client.Database(t.Database).Drop(ctx); err != nil {
+			return nil, status.Errorf(codes.Internal, "Tenant %s was deleted but its database %s was not dropped: %v", t.ID, t.Database, err)
+		}
+	}
+	return &pb.Empty{}, nil
+}
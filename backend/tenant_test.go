@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantConfigEventSinks(t *testing.T) {
+	cfg := Config{EventFormat: eventFormatJSON, WebhookTimeout: time.Second}
+	tests := []struct {
+		sinks string
+		want  codes.Code
+	}{
+		{"", codes.OK},
+		{"https://203.0.113.10/events", codes.OK},
+		{"file:/tmp/x", codes.InvalidArgument},
+		{"/tmp/x", codes.InvalidArgument},
+		{"http://127.0.0.1", codes.InvalidArgument},
+		{"http://127.0.0.1:8080/events", codes.InvalidArgument},
+		{"http://169.254.169.254/latest/meta-data", codes.InvalidArgument},
+		{"http://[::1]/", codes.InvalidArgument},
+		{"http://10.0.0.5/", codes.InvalidArgument},
+		{"https://203.0.113.10/events,file:/tmp/x", codes.InvalidArgument},
+		{"https://203.0.113.10/events, http://127.0.0.1", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sinks, func(t *testing.T) {
+			_, err := tenantConfigFromProto(context.Background(), cfg, &pb.TenantConfig{EventSinks: tt.sinks})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("tenantConfigFromProto(%q) = %v (%v), want %v", tt.sinks, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditTenant(t *testing.T) {
+	secret := []byte("secret")
+	def := &tenantRuntime{tenant: Tenant{ID: defaultTenantID}}
+	acme := &tenantRuntime{tenant: Tenant{ID: "acme"}}
+	r := &tenantRegistry{
+		cfg:      Config{TenantClaim: "tenant"},
+		keys:     func(*jwt.Token) (interface{}, error) { return secret, nil },
+		methods:  []string{"HS256"},
+		runtimes: map[string]*tenantRuntime{defaultTenantID: def, "acme": acme},
+		scoped:   map[string]bool{"employee.EmployeeService": true},
+	}
+	token := func(claims jwt.MapClaims) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return "Bearer " + s
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		md     []string
+		want   *tenantRuntime
+		code   codes.Code
+	}{
+		{"scoped without a token", "/employee.EmployeeService/ListEmployees", nil, def, codes.OK},
+		{"scoped with a token", "/employee.EmployeeService/ListEmployees", []string{"authorization", token(jwt.MapClaims{"tenant": "acme"})}, acme, codes.OK},
+		{"scoped with another tenant's header", "/employee.EmployeeService/ListEmployees", []string{"authorization", token(jwt.MapClaims{"tenant": "acme"}), tenantHeader, "other"}, nil, codes.PermissionDenied},
+		{"tenant service with a token", "/employee.TenantService/ListTenants", []string{"authorization", token(jwt.MapClaims{"tenant": "acme", "admin": true})}, acme, codes.OK},
+		{"tenant service without a token", "/employee.TenantService/ListTenants", nil, def, codes.OK},
+		{"tenant service with an invalid token", "/employee.TenantService/ListTenants", []string{"authorization", "Bearer nope"}, def, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tt.md...))
+			_, rt, err := r.auditTenant(ctx, tt.method)
+			if status.Code(err) != tt.code {
+				t.Fatalf("auditTenant = %v, want %v", err, tt.code)
+			}
+			if rt != tt.want {
+				t.Errorf("auditTenant = %+v, want %+v", rt, tt.want)
+			}
+		})
+	}
+}
+
+func TestActorFromToken(t *testing.T) {
+	secret := []byte("secret")
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "ada", "tenant": "acme"}).SignedString(secret)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	withKeys := &tenantRegistry{
+		keys:    func(*jwt.Token) (interface{}, error) { return secret, nil },
+		methods: []string{"HS256"},
+	}
+	tests := []struct {
+		name     string
+		registry *tenantRegistry
+		md       []string
+		want     string
+	}{
+		{"token subject", withKeys, []string{"authorization", "Bearer " + signed}, "ada"},
+		{"header ignored with a token", withKeys, []string{"authorization", "Bearer " + signed, actorHeader, "grace"}, "ada"},
+		{"header ignored without a token", withKeys, []string{actorHeader, "grace"}, "anonymous"},
+		{"header ignored with an invalid token", withKeys, []string{"authorization", "Bearer nope", actorHeader, "grace"}, "anonymous"},
+		{"header without tokens configured", &tenantRegistry{}, []string{actorHeader, "grace"}, "grace"},
+		{"nothing without tokens configured", &tenantRegistry{}, nil, "anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tt.md...))
+			if got := actorFromContext(tt.registry.withActor(ctx)); got != tt.want {
+				t.Errorf("actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EmployeeVersion is a full snapshot of an employee valid from EffectiveFrom
+// up to (not including) EffectiveTo. Versions of one employee never overlap.
+// The employees collection always holds the snapshot of the version that is
+// effective now.
+type EmployeeVersion struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	EmployeeID    primitive.ObjectID `bson:"employee_id"`
+	EffectiveFrom time.Time          `bson:"effective_from"`
+	EffectiveTo   *time.Time         `bson:"effective_to,omitempty"`
+	Employee      bson.M             `bson:"employee"`
+	// Applied is set once the version has been copied to employees
+	Applied    bool      `bson:"applied"`
+	RecordedAt time.Time `bson:"recorded_at"`
+}
+
+// coveringFilter matches the versions in effect at t
+func coveringFilter(t time.Time) bson.M {
+	return bson.M{
+		"effective_from": bson.M{"$lte": t},
+		"$or": bson.A{
+			bson.M{"effective_to": bson.M{"$exists": false}},
+			bson.M{"effective_to": bson.M{"$gt": t}},
+		},
+	}
+}
+
+func toDocument(v interface{}) (bson.M, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	err = bson.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// sortedDocuments returns doc with its subdocuments, such as custom_fields,
+// as documents with sorted keys. MongoDB compares subdocuments field by
+// field in order, so a stored snapshot can then be matched by value.
+func sortedDocuments(doc bson.M) bson.M {
+	sorted := make(bson.M, len(doc))
+	for k, v := range doc {
+		sorted[k] = sortedValue(v)
+	}
+	return sorted
+}
+
+func sortedValue(v interface{}) interface{} {
+	m, ok := v.(primitive.M)
+	if !ok {
+		return v
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	d := make(bson.D, len(keys))
+	for i, k := range keys {
+		d[i] = bson.E{Key: k, Value: m[k]}
+	}
+	return d
+}
+
+func snapshotToEmployee(doc bson.M) (Employee, error) {
+	var emp Employee
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return emp, err
+	}
+	err = bson.Unmarshal(data, &emp)
+	return emp, err
+}
+
+// versionAt returns the version of id in effect at t, or nil. Employees that
+// predate versioning get an initial version the first time they are asked for.
+func (s *server) versionAt(ctx context.Context, id primitive.ObjectID, t time.Time) (*EmployeeVersion, error) {
+	if err := s.ensureInitialVersion(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filter := coveringFilter(t)
+	filter["employee_id"] = id
+
+	var v EmployeeVersion
+	err := s.versionsCollection.FindOne(ctx, filter,
+		options.FindOne().SetSort(bson.M{"effective_from": -1}),
+	).Decode(&v)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve employee version: %v", err)
+	}
+	return &v, nil
+}
+
+// ensureInitialVersion backfills a first version for an employee that was
+// created before versioning, effective from its creation time.
+func (s *server) ensureInitialVersion(ctx context.Context, id primitive.ObjectID) error {
+	n, err := s.versionsCollection.CountDocuments(ctx, bson.M{"employee_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to retrieve employee versions: %v", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	var doc bson.M
+	err = s.employeesCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to retrieve employee: %v", err)
+	}
+
+	return s.insertVersion(ctx, id, id.Timestamp().UTC(), nil, doc, true)
+}
+
+func (s *server) insertVersion(ctx context.Context, id primitive.ObjectID, from time.Time, to *time.Time, doc bson.M, applied bool) error {
+	_, err := s.versionsCollection.InsertOne(ctx, EmployeeVersion{
+		EmployeeID:    id,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		Employee:      sortedDocuments(doc),
+		Applied:       applied,
+		RecordedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to record employee version: %v", err)
+	}
+	return nil
+}
+
+// employeeChange is one edit to an employee, applied from a given date
+type employeeChange struct {
+	// Revision action the change is recorded under
+	action string
+	set    bson.M
+	unset  []string
+	push   *EmploymentEvent
+	// expect holds the values the change was validated against, e.g. the
+	// status a transition starts from. A blank value expects the field to be
+	// missing.
+	expect bson.M
+}
+
+// recordChange applies change to the employee from effective onwards,
+// records it as a revision and returns the resulting snapshot. The version in
+// effect at that time is split in two. Later versions pick the change up too,
+// except for fields they already changed themselves, so a backdated edit is
+// not lost when a newer version exists. The change fails with Aborted if the
+// version it is based on was rewritten meanwhile or no longer holds the
+// values in change.expect.
+func (s *server) recordChange(ctx context.Context, id primitive.ObjectID, effective time.Time, change employeeChange) (bson.M, error) {
+	base, err := s.versionAt(ctx, id, effective)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Employee %s did not exist on %s", id.Hex(), effective.Format(time.RFC3339))
+	}
+
+	snapshot := bson.M{}
+	for k, v := range base.Employee {
+		snapshot[k] = v
+	}
+	for k, v := range change.set {
+		snapshot[k] = v
+	}
+	for _, k := range change.unset {
+		delete(snapshot, k)
+	}
+	if change.push != nil {
+		history, _ := snapshot["status_history"].(bson.A)
+		snapshot["status_history"] = append(append(bson.A{}, history...), change.push)
+	}
+
+	// Only apply if nobody changed the base version in the meantime
+	guard := bson.M{"_id": base.ID, "recorded_at": base.RecordedAt}
+	if base.EffectiveTo != nil {
+		guard["effective_to"] = *base.EffectiveTo
+	} else {
+		guard["effective_to"] = bson.M{"$exists": false}
+	}
+	for k, v := range change.expect {
+		if v == "" {
+			guard["employee."+k] = bson.M{"$exists": false}
+		} else {
+			guard["employee."+k] = sortedValue(v)
+		}
+	}
+
+	now := time.Now().UTC()
+	applied := !effective.After(now)
+	var res *mongo.UpdateResult
+	if base.EffectiveFrom.Equal(effective) {
+		// Same start date, the new snapshot replaces the old one
+		res, err = s.versionsCollection.UpdateOne(ctx, guard, bson.M{"$set": bson.M{
+			"employee":    sortedDocuments(snapshot),
+			"applied":     applied,
+			"recorded_at": now,
+		}})
+	} else {
+		res, err = s.versionsCollection.UpdateOne(ctx, guard, bson.M{"$set": bson.M{"effective_to": effective}})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to record employee version: %v", err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, status.Errorf(codes.Aborted, "Employee %s was changed concurrently, retry", id.Hex())
+	}
+	if !base.EffectiveFrom.Equal(effective) {
+		if err := s.insertVersion(ctx, id, effective, base.EffectiveTo, snapshot, applied); err != nil {
+			return nil, err
+		}
+	}
+
+	// Carry the change forward into later versions that still had the old value
+	later := bson.M{"employee_id": id, "effective_from": bson.M{"$gt": effective}}
+	for k, v := range change.set {
+		filter := bson.M{"employee_id": id, "effective_from": bson.M{"$gt": effective}}
+		if old, ok := base.Employee[k]; ok {
+			filter["employee."+k] = sortedValue(old)
+		} else {
+			filter["employee."+k] = bson.M{"$exists": false}
+		}
+		if _, err := s.versionsCollection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"employee." + k: sortedValue(v)}}); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to update later versions: %v", err)
+		}
+	}
+	for _, k := range change.unset {
+		old, ok := base.Employee[k]
+		if !ok {
+			continue
+		}
+		filter := bson.M{"employee_id": id, "effective_from": bson.M{"$gt": effective}, "employee." + k: sortedValue(old)}
+		if _, err := s.versionsCollection.UpdateMany(ctx, filter, bson.M{"$unset": bson.M{"employee." + k: ""}}); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to update later versions: %v", err)
+		}
+	}
+	if change.push != nil {
+		if _, err := s.versionsCollection.UpdateMany(ctx, later, bson.M{"$push": bson.M{"employee.status_history": change.push}}); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to update later versions: %v", err)
+		}
+	}
+
+	if err := s.syncCurrent(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, id, change.action, &effective, base.Employee, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// setEmployeeFields sets fields on the employees that match filter, such as
+// the denormalized name of a renamed department. The versions in effect now
+// or later that match get them as well, so syncCurrent does not bring the
+// old values back, and every employee that changed gets an update revision
+// and its event. filter may only name _id and top-level employee fields. It
+// runs in the caller's transaction and returns the number of employees
+// changed.
+func (s *server) setEmployeeFields(ctx context.Context, filter, set bson.M) (int64, error) {
+	now := time.Now().UTC()
+
+	versionFilter := bson.M{"$or": bson.A{
+		bson.M{"effective_to": bson.M{"$exists": false}},
+		bson.M{"effective_to": bson.M{"$gt": now}},
+	}}
+	for k, v := range filter {
+		if k == "_id" {
+			versionFilter["employee_id"] = v
+		} else {
+			versionFilter["employee."+k] = v
+		}
+	}
+	versionSet := bson.M{}
+	for k, v := range set {
+		versionSet["employee."+k] = sortedValue(v)
+	}
+	if _, err := s.versionsCollection.UpdateMany(ctx, versionFilter, bson.M{"$set": versionSet}); err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to update employee versions: %v", err)
+	}
+
+	cursor, err := s.employeesCollection.Find(ctx, filter)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to retrieve employees: %v", err)
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return 0, status.Errorf(codes.Internal, "Failed to decode employee: %v", err)
+	}
+
+	var changed int64
+	for _, before := range docs {
+		id, _ := before["_id"].(primitive.ObjectID)
+		delete(before, "_id")
+		after := bson.M{}
+		for k, v := range before {
+			after[k] = v
+		}
+		for k, v := range set {
+			after[k] = v
+		}
+		if len(diffFields(before, after)) == 0 {
+			continue
+		}
+
+		if _, err := s.employeesCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set}); err != nil {
+			return 0, status.Errorf(codes.Internal, "Failed to update employee: %v", err)
+		}
+		if err := s.recordRevision(ctx, id, actionUpdate, &now, before, after); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// syncCurrent copies the version in effect now into the employees collection
+func (s *server) syncCurrent(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now().UTC()
+	current, err := s.versionAt(ctx, id, now)
+	if err != nil || current == nil {
+		return err
+	}
+
+	doc := bson.M{}
+	for k, v := range current.Employee {
+		doc[k] = v
+	}
+	doc["_id"] = id
+
+	if _, err := s.employeesCollection.ReplaceOne(ctx, bson.M{"_id": id}, doc); err != nil {
+		return status.Errorf(codes.Internal, "Failed to update employee: %v", err)
+	}
+
+	_, err = s.versionsCollection.UpdateMany(ctx,
+		bson.M{"employee_id": id, "applied": false, "effective_from": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"applied": true}},
+	)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to update employee versions: %v", err)
+	}
+	return nil
+}
+
+// endVersions closes the history of a deleted employee at t and drops any
+// changes scheduled after it.
+func (s *server) endVersions(ctx context.Context, id primitive.ObjectID, t time.Time) error {
+	if err := s.ensureInitialVersion(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := s.versionsCollection.DeleteMany(ctx, bson.M{"employee_id": id, "effective_from": bson.M{"$gt": t}}); err != nil {
+		return status.Errorf(codes.Internal, "Failed to remove scheduled versions: %v", err)
+	}
+
+	filter := coveringFilter(t)
+	filter["employee_id"] = id
+	if _, err := s.versionsCollection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"effective_to": t}}); err != nil {
+		return status.Errorf(codes.Internal, "Failed to close employee version: %v", err)
+	}
+	return nil
+}
+
+// employeesAsOf lists the employee snapshots in effect at t that match
+// filter, sorted by sort if it is given
+func (s *server) employeesAsOf(ctx context.Context, t time.Time, filter bson.M, sort bson.D) (*mongo.Cursor, error) {
+	pipeline := asOfStages(t)
+	if len(filter) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
+	}
+	if len(sort) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}})
+	}
+	return s.versionsCollection.Aggregate(ctx, pipeline)
+}
+
+// asOfStages turns the versions collection into the employee documents in
+// effect at t
+func asOfStages(t time.Time) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: coveringFilter(t)}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": bson.M{
+			"$mergeObjects": bson.A{"$employee", bson.M{"_id": "$employee_id"}},
+		}}}},
+	}
+}
+
+// applyDueVersions promotes scheduled versions whose date has come
+func (s *server) applyDueVersions(ctx context.Context) error {
+	ids, err := s.versionsCollection.Distinct(ctx, "employee_id", bson.M{
+		"applied":        false,
+		"effective_from": bson.M{"$lte": time.Now().UTC()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find due versions: %w", err)
+	}
+
+	for _, v := range ids {
+		id, ok := v.(primitive.ObjectID)
+		if !ok {
+			continue
+		}
+		if err := s.syncCurrent(ctx, id); err != nil {
+			return err
+		}
+		log.Printf("Applied scheduled changes for employee %s", id.Hex())
+	}
+	return nil
+}
+
+// backfillVersions gives every employee without history an initial version,
+// so that as-of listings include them.
+func (s *server) backfillVersions(ctx context.Context) error {
+	cursor, err := s.employeesCollection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return fmt.Errorf("failed to list employees: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return fmt.Errorf("failed to decode employee: %w", err)
+		}
+		if err := s.ensureInitialVersion(ctx, row.ID); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// runVersionScheduler backfills missing versions once, then applies due
+// future-dated changes every interval until ctx is done.
+func (s *server) runVersionScheduler(ctx context.Context, interval time.Duration) {
+	if err := s.backfillVersions(ctx); err != nil {
+		log.Printf("Failed to backfill employee versions: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.applyDueVersions(ctx); err != nil {
+			log.Printf("Failed to apply scheduled employee changes: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
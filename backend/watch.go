@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// lastEventIDHeader is sent by SSE clients when they reconnect
+const lastEventIDHeader = "last-event-id"
+
+// MongoDB error codes the watch maps onto gRPC statuses
+const (
+	mongoInvalidResumeToken       = 260
+	mongoChangeStreamHistoryLost  = 286
+	mongoChangeStreamNotSupported = 40573
+)
+
+// changeEvent is the part of a change stream document the watch uses
+type changeEvent struct {
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *Employee `bson:"fullDocument"`
+}
+
+var changeTypes = map[string]pb.ChangeType{
+	"insert":  pb.ChangeType_CREATED,
+	"update":  pb.ChangeType_UPDATED,
+	"replace": pb.ChangeType_UPDATED,
+	"delete":  pb.ChangeType_DELETED,
+}
+
+// WatchEmployees
+func (s *server) WatchEmployees(req *pb.WatchEmployeesRequest, stream pb.EmployeeService_WatchEmployeesServer) error {
+	log.Println("WatchEmployees RPC called")
+	ctx := stream.Context()
+
+	token := req.GetResumeToken()
+	if token == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(lastEventIDHeader); len(v) > 0 {
+				token = v[0]
+			}
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}}},
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token != "" {
+		opts.SetStartAfter(bson.M{"_data": token})
+	}
+
+	cs, err := s.employeesCollection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return watchError(err)
+	}
+	defer cs.Close(ctx)
+
+	for cs.Next(ctx) {
+		var change changeEvent
+		if err := cs.Decode(&change); err != nil {
+			return status.Errorf(codes.Internal, "Failed to decode change: %v", err)
+		}
+
+		event := &pb.EmployeeEvent{
+			Type:        changeTypes[change.OperationType],
+			EmployeeId:  change.DocumentKey.ID.Hex(),
+			ResumeToken: cs.ResumeToken().Lookup("_data").StringValue(),
+			Timestamp:   timestamppb.New(time.Unix(int64(change.ClusterTime.T), 0)),
+		}
+		// Updates carry the document as it is now, which may be gone already
+		if change.FullDocument != nil && event.Type != pb.ChangeType_DELETED {
+			event.Employee = change.FullDocument.toProto()
+		}
+
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	// The client going away ends the watch normally
+	if ctx.Err() != nil {
+		return nil
+	}
+	return watchError(cs.Err())
+}
+
+func watchError(err error) error {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		switch cmdErr.Code {
+		case mongoInvalidResumeToken:
+			return status.Errorf(codes.InvalidArgument, "Invalid resume token: %v", err)
+		case mongoChangeStreamHistoryLost:
+			return status.Errorf(codes.OutOfRange, "Resume token is too old, reload employees and watch again: %v", err)
+		case mongoChangeStreamNotSupported:
+			return status.Errorf(codes.FailedPrecondition, "Watching needs MongoDB to run as a replica set: %v", err)
+		}
+	}
+	return status.Errorf(codes.Internal, "Failed to watch employees: %v", err)
+}
+
+// streamJSON marshals like the gateway's default marshaler
+func streamJSON() runtime.Marshaler {
+	return &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+// ndjsonMarshaler labels the gateway's newline-delimited stream output as
+// application/x-ndjson
+type ndjsonMarshaler struct {
+	runtime.Marshaler
+}
+
+func (ndjsonMarshaler) ContentType(interface{}) string { return "application/x-ndjson" }
+
+func (ndjsonMarshaler) Delimiter() []byte { return []byte("\n") }
+
+// eventStreamMarshaler writes streams as Server-Sent Events. Each event is
+// sent unwrapped with its resume token as the SSE id, so EventSource hands
+// it back in Last-Event-ID on reconnect. Errors arrive as "error" events.
+type eventStreamMarshaler struct {
+	runtime.Marshaler
+}
+
+func (eventStreamMarshaler) ContentType(interface{}) string { return "text/event-stream" }
+
+func (eventStreamMarshaler) Delimiter() []byte { return []byte("\n\n") }
+
+func (m eventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	switch chunk := v.(type) {
+	case map[string]interface{}:
+		if event, ok := chunk["result"].(*pb.EmployeeEvent); ok {
+			buf.WriteString("id: " + event.GetResumeToken() + "\n")
+			v = event
+		}
+	case map[string]proto.Message:
+		if st, ok := chunk["error"]; ok {
+			buf.WriteString("event: error\n")
+			v = st
+		}
+	}
+
+	data, err := m.Marshaler.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString("data: ")
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
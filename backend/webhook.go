@@ -0,0 +1,590 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Delivery statuses
+const (
+	deliveryPending    = "pending"
+	deliveryDelivered  = "delivered"
+	deliveryDeadLetter = "dead_letter"
+)
+
+var deliveryStatusToProto = map[string]pb.DeliveryStatus{
+	deliveryPending:    pb.DeliveryStatus_PENDING,
+	deliveryDelivered:  pb.DeliveryStatus_DELIVERED,
+	deliveryDeadLetter: pb.DeliveryStatus_DEAD_LETTER,
+}
+
+var deliveryStatusFromProto = map[pb.DeliveryStatus]string{
+	pb.DeliveryStatus_PENDING:     deliveryPending,
+	pb.DeliveryStatus_DELIVERED:   deliveryDelivered,
+	pb.DeliveryStatus_DEAD_LETTER: deliveryDeadLetter,
+}
+
+// Event types a webhook can subscribe to
+var webhookEventTypes = map[string]bool{
+	eventEmployeeCreated:       true,
+	eventEmployeeUpdated:       true,
+	eventEmployeeStatusChanged: true,
+	eventEmployeeTerminated:    true,
+	eventEmployeeDeleted:       true,
+	eventEmployeeRestored:      true,
+	eventEmployeeMerged:        true,
+}
+
+// Retry delays double from webhookBackoffBase up to webhookBackoffMax
+const (
+	webhookBackoffBase = 10 * time.Second
+	webhookBackoffMax  = time.Hour
+)
+
+// MongoDB Webhook model
+type Webhook struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	URL         string             `bson:"url"`
+	EventTypes  []string           `bson:"event_types"`
+	Secret      string             `bson:"secret"`
+	Disabled    bool               `bson:"disabled"`
+	Description string             `bson:"description"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// toProto leaves the secret out, it is only shown once on creation
+func (w Webhook) toProto() *pb.Webhook {
+	return &pb.Webhook{
+		Id:          w.ID.Hex(),
+		Url:         w.URL,
+		EventTypes:  w.EventTypes,
+		Disabled:    w.Disabled,
+		Description: w.Description,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+	}
+}
+
+func (w Webhook) wants(eventType string) bool {
+	if w.Disabled {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event on its way to one webhook
+type WebhookDelivery struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	WebhookID primitive.ObjectID `bson:"webhook_id"`
+	EventID   primitive.ObjectID `bson:"event_id"`
+	EventType string             `bson:"event_type"`
+	Payload   string             `bson:"payload"`
+	Status    string             `bson:"status"`
+	Attempts  []DeliveryAttempt  `bson:"attempts"`
+	// Failures counts failed attempts since the delivery was last queued
+	Failures      int        `bson:"failures"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	DeliveredAt   *time.Time `bson:"delivered_at,omitempty"`
+}
+
+type DeliveryAttempt struct {
+	Time       time.Time `bson:"time"`
+	StatusCode int       `bson:"status_code"`
+	Error      string    `bson:"error"`
+	DurationMS int64     `bson:"duration_ms"`
+}
+
+func (d WebhookDelivery) toProto() *pb.WebhookDelivery {
+	delivery := &pb.WebhookDelivery{
+		Id:            d.ID.Hex(),
+		WebhookId:     d.WebhookID.Hex(),
+		EventId:       d.EventID.Hex(),
+		EventType:     d.EventType,
+		Status:        deliveryStatusToProto[d.Status],
+		NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		Payload:       d.Payload,
+	}
+	if d.DeliveredAt != nil {
+		delivery.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	for _, a := range d.Attempts {
+		delivery.Attempts = append(delivery.Attempts, &pb.DeliveryAttempt{
+			Time:       timestamppb.New(a.Time),
+			StatusCode: int32(a.StatusCode),
+			Error:      a.Error,
+			DurationMs: a.DurationMS,
+		})
+	}
+	return delivery
+}
+
+type webhookServer struct {
+	pb.UnimplementedWebhookServiceServer
+	webhooksCollection   *mongo.Collection
+	deliveriesCollection *mongo.Collection
+	guard                *webhookGuard
+}
+
+func NewWebhookServer(webhooks, deliveries *mongo.Collection, guard *webhookGuard) pb.WebhookServiceServer {
+	return &webhookServer{webhooksCollection: webhooks, deliveriesCollection: deliveries, guard: guard}
+}
+
+// ListWebhooks
+func (s *webhookServer) ListWebhooks(ctx context.Context, req *pb.Empty) (*pb.WebhookList, error) {
+	log.Println("ListWebhooks RPC called")
+
+	cursor, err := s.webhooksCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve webhooks: %v", err)
+	}
+
+	var webhooks []Webhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode webhook: %v", err)
+	}
+
+	list := &pb.WebhookList{}
+	for _, w := range webhooks {
+		list.Webhooks = append(list.Webhooks, w.toProto())
+	}
+	return list, nil
+}
+
+// GetWebhook
+func (s *webhookServer) GetWebhook(ctx context.Context, req *pb.WebhookID) (*pb.Webhook, error) {
+	log.Println("GetWebhook RPC called")
+
+	w, err := s.findWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return w.toProto(), nil
+}
+
+// CreateWebhook
+func (s *webhookServer) CreateWebhook(ctx context.Context, req *pb.Webhook) (*pb.Webhook, error) {
+	log.Println("CreateWebhook RPC called")
+
+	w, err := webhookFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.checkURL(ctx, w.URL); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Webhook URL is not allowed: %v", err)
+	}
+	if w.Secret == "" {
+		if w.Secret, err = newWebhookSecret(); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to generate secret: %v", err)
+		}
+	}
+	w.CreatedAt = time.Now().UTC()
+
+	res, err := s.webhooksCollection.InsertOne(ctx, w)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create webhook: %v", err)
+	}
+	w.ID = res.InsertedID.(primitive.ObjectID)
+
+	created := w.toProto()
+	created.Secret = w.Secret
+	return created, nil
+}
+
+// UpdateWebhook
+func (s *webhookServer) UpdateWebhook(ctx context.Context, req *pb.Webhook) (*pb.Webhook, error) {
+	log.Println("UpdateWebhook RPC called")
+
+	existing, err := s.findWebhook(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := webhookFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.checkURL(ctx, w.URL); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Webhook URL is not allowed: %v", err)
+	}
+	if w.Secret == "" {
+		w.Secret = existing.Secret
+	}
+	w.ID = existing.ID
+	w.CreatedAt = existing.CreatedAt
+
+	if _, err := s.webhooksCollection.ReplaceOne(ctx, bson.M{"_id": w.ID}, w); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update webhook: %v", err)
+	}
+	return w.toProto(), nil
+}
+
+// DeleteWebhook
+func (s *webhookServer) DeleteWebhook(ctx context.Context, req *pb.WebhookID) (*pb.Empty, error) {
+	log.Println("DeleteWebhook RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	res, err := s.webhooksCollection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete webhook: %v", err)
+	}
+	if res.DeletedCount == 0 {
+		return nil, status.Errorf(codes.NotFound, "Webhook not found with ID: %s", req.GetId())
+	}
+
+	// Deliveries have nowhere to go any more
+	if _, err := s.deliveriesCollection.DeleteMany(ctx, bson.M{"webhook_id": oid}); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete webhook deliveries: %v", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// ListWebhookDeliveries
+func (s *webhookServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.WebhookDeliveryList, error) {
+	log.Println("ListWebhookDeliveries RPC called")
+
+	oid, err := primitive.ObjectIDFromHex(req.GetWebhookId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	filter := bson.M{"webhook_id": oid}
+	if req.GetStatus() != pb.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED {
+		st, ok := deliveryStatusFromProto[req.GetStatus()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown delivery status: %v", req.GetStatus())
+		}
+		filter["status"] = st
+	}
+	if req.GetPageToken() != "" {
+		before, err := primitive.ObjectIDFromHex(req.GetPageToken())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+		filter["_id"] = bson.M{"$lt": before}
+	}
+
+	pageSize := int64(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if pageSize > 1000 {
+		pageSize = 1000
+	}
+
+	cursor, err := s.deliveriesCollection.Find(ctx, filter,
+		options.Find().SetSort(bson.M{"_id": -1}).SetLimit(pageSize+1),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve deliveries: %v", err)
+	}
+
+	var deliveries []WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode delivery: %v", err)
+	}
+
+	list := &pb.WebhookDeliveryList{}
+	if int64(len(deliveries)) > pageSize {
+		deliveries = deliveries[:pageSize]
+		list.NextPageToken = deliveries[len(deliveries)-1].ID.Hex()
+	}
+	for _, d := range deliveries {
+		list.Deliveries = append(list.Deliveries, d.toProto())
+	}
+	return list, nil
+}
+
+// GetWebhookDelivery
+func (s *webhookServer) GetWebhookDelivery(ctx context.Context, req *pb.WebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	log.Println("GetWebhookDelivery RPC called")
+
+	filter, err := deliveryFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var d WebhookDelivery
+	err = s.deliveriesCollection.FindOne(ctx, filter).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Delivery not found with ID: %s", req.GetDeliveryId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve delivery: %v", err)
+	}
+	return d.toProto(), nil
+}
+
+// RetryWebhookDelivery
+func (s *webhookServer) RetryWebhookDelivery(ctx context.Context, req *pb.WebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	log.Println("RetryWebhookDelivery RPC called")
+
+	filter, err := deliveryFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var d WebhookDelivery
+	err = s.deliveriesCollection.FindOne(ctx, filter).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Delivery not found with ID: %s", req.GetDeliveryId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve delivery: %v", err)
+	}
+	if d.Status != deliveryDeadLetter {
+		return nil, status.Errorf(codes.FailedPrecondition, "Only dead-lettered deliveries can be retried, this one is %s", d.Status)
+	}
+
+	filter["status"] = deliveryDeadLetter
+	err = s.deliveriesCollection.FindOneAndUpdate(ctx, filter,
+		bson.M{"$set": bson.M{"status": deliveryPending, "failures": 0, "next_attempt_at": time.Now().UTC()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.Aborted, "Delivery %s changed while retrying it", req.GetDeliveryId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to queue delivery: %v", err)
+	}
+	return d.toProto(), nil
+}
+
+func (s *webhookServer) findWebhook(ctx context.Context, id string) (*Webhook, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+
+	var w Webhook
+	err = s.webhooksCollection.FindOne(ctx, bson.M{"_id": oid}).Decode(&w)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "Webhook not found with ID: %s", id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve webhook: %v", err)
+	}
+	return &w, nil
+}
+
+func deliveryFilter(req *pb.WebhookDeliveryRequest) (bson.M, error) {
+	webhookID, err := primitive.ObjectIDFromHex(req.GetWebhookId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid ID format: %v", err)
+	}
+	deliveryID, err := primitive.ObjectIDFromHex(req.GetDeliveryId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid delivery ID format: %v", err)
+	}
+	return bson.M{"_id": deliveryID, "webhook_id": webhookID}, nil
+}
+
+// webhookFromProto validates req and converts it to the stored model
+func webhookFromProto(req *pb.Webhook) (Webhook, error) {
+	w := Webhook{
+		URL:         strings.TrimSpace(req.GetUrl()),
+		EventTypes:  []string{},
+		Secret:      req.GetSecret(),
+		Disabled:    req.GetDisabled(),
+		Description: strings.TrimSpace(req.GetDescription()),
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return w, status.Errorf(codes.InvalidArgument, "Webhook URL must be an absolute http(s) URL: %q", w.URL)
+	}
+
+	for _, t := range req.GetEventTypes() {
+		if !webhookEventTypes[t] {
+			return w, status.Errorf(codes.InvalidArgument, "Unknown event type: %s", t)
+		}
+		w.EventTypes = append(w.EventTypes, t)
+	}
+	return w, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// signWebhook returns the X-Webhook-Signature value for a body sent at ts
+func signWebhook(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff is the delay before the next attempt after failures failed
+// ones, doubling each time with jitter so retries spread out
+func webhookBackoff(failures int) time.Duration {
+	d := webhookBackoffMax
+	if failures < 20 {
+		d = min(webhookBackoffBase<<(failures-1), webhookBackoffMax)
+	}
+	return d/2 + mathrand.N(d/2)
+}
+
+// queueDeliveries queues a delivery of event to each webhook subscribed to it
+func (d *outboxDispatcher) queueDeliveries(ctx context.Context, event OutboxEvent, webhooks []Webhook) error {
+	for _, w := range webhooks {
+		if !w.wants(event.Type) {
+			continue
+		}
+		_, err := d.deliveriesCollection.InsertOne(ctx, WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       event.Payload,
+			Status:        deliveryPending,
+			Attempts:      []DeliveryAttempt{},
+			NextAttemptAt: event.CreatedAt,
+			CreatedAt:     time.Now().UTC(),
+		})
+		// Another dispatcher got there first
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to queue delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// deliverDue makes one attempt at each delivery that is due
+func (d *outboxDispatcher) deliverDue(ctx context.Context) error {
+	for i := 0; i < 500; i++ {
+		// Claim the delivery by pushing its next attempt past the time the
+		// attempt can take, so no other dispatcher picks it up meanwhile
+		now := time.Now().UTC()
+		var delivery WebhookDelivery
+		err := d.deliveriesCollection.FindOneAndUpdate(ctx,
+			bson.M{"status": deliveryPending, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"next_attempt_at": now.Add(2 * d.client.Timeout)}},
+			options.FindOneAndUpdate().SetSort(bson.M{"next_attempt_at": 1}),
+		).Decode(&delivery)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim delivery: %w", err)
+		}
+
+		if err := d.attempt(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attempt posts a delivery to its webhook and records the outcome
+func (d *outboxDispatcher) attempt(ctx context.Context, delivery WebhookDelivery) error {
+	var w Webhook
+	err := d.webhooksCollection.FindOne(ctx, bson.M{"_id": delivery.WebhookID}).Decode(&w)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	// Deliveries to a deleted or disabled webhook fail without retries
+	start := time.Now().UTC()
+	result := DeliveryAttempt{Time: start}
+	permanent := true
+	switch {
+	case err == mongo.ErrNoDocuments:
+		result.Error = "webhook was deleted"
+	case w.Disabled:
+		result.Error = "webhook is disabled"
+	default:
+		permanent = false
+		result.StatusCode, err = d.post(ctx, w, delivery)
+		if err != nil {
+			result.Error = err.Error()
+		} else if result.StatusCode < 200 || result.StatusCode >= 300 {
+			result.Error = fmt.Sprintf("endpoint returned %d", result.StatusCode)
+		}
+	}
+	result.DurationMS = time.Since(start).Milliseconds()
+
+	update := bson.M{"$push": bson.M{"attempts": result}}
+	failures := delivery.Failures + 1
+	switch {
+	case result.Error == "":
+		update["$set"] = bson.M{"status": deliveryDelivered, "delivered_at": start}
+	case permanent || failures >= d.maxAttempts:
+		update["$set"] = bson.M{"status": deliveryDeadLetter, "failures": failures}
+		log.Printf("Webhook delivery %s dead-lettered: %s", delivery.ID.Hex(), result.Error)
+	default:
+		update["$set"] = bson.M{
+			"failures":        failures,
+			"next_attempt_at": time.Now().UTC().Add(webhookBackoff(failures)),
+		}
+	}
+
+	if _, err := d.deliveriesCollection.UpdateOne(ctx, bson.M{"_id": delivery.ID}, update); err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// post sends the signed payload and returns the HTTP status code
+func (d *outboxDispatcher) post(ctx context.Context, w Webhook, delivery WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "employee-app-webhooks")
+	req.Header.Set("X-Webhook-Id", delivery.ID.Hex())
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", ts)
+	req.Header.Set("X-Webhook-Signature", signWebhook(w.Secret, ts, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
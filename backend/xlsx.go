@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "EMPLOYEE_APP/backend/pb"
+
+	"github.com/xuri/excelize/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// xlsxEncoder writes an export as an Excel workbook: a bold, frozen and
+// filterable header row, then one row per employee with timestamps as date
+// cells. Rows go through excelize's stream writer, which moves them to a
+// temporary file once they get large; the workbook is written out on Close.
+type xlsxEncoder struct {
+	w      io.Writer
+	file   *excelize.File
+	fields []exportField
+
+	headerStyle int
+	dateStyle   int
+
+	sheet  string
+	stream *excelize.StreamWriter
+	row    int
+	// Sheet names in use, lower-cased as Excel compares them that way
+	sheets map[string]bool
+}
+
+func newXLSXEncoder(w io.Writer, fields []exportField) (*xlsxEncoder, error) {
+	f := excelize.NewFile()
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"305496"}},
+		Border: []excelize.Border{
+			{Type: "bottom", Color: "1F3864", Style: 2},
+		},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create header style: %v", err)
+	}
+	dateFormat := "yyyy-mm-dd"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create date style: %v", err)
+	}
+
+	return &xlsxEncoder{
+		w:           w,
+		file:        f,
+		fields:      fields,
+		headerStyle: headerStyle,
+		dateStyle:   dateStyle,
+		sheets:      map[string]bool{},
+	}, nil
+}
+
+// SetSheet starts a new sheet; the rows written after go on it. Names are
+// made valid and unique first.
+func (e *xlsxEncoder) SetSheet(name string) error {
+	if err := e.finishSheet(); err != nil {
+		return err
+	}
+
+	name = e.sheetName(name)
+	var err error
+	if e.sheet == "" {
+		// A new workbook comes with an empty Sheet1
+		err = e.file.SetSheetName(e.file.GetSheetList()[0], name)
+	} else {
+		_, err = e.file.NewSheet(name)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to add sheet %s: %v", name, err)
+	}
+	e.sheet = name
+
+	e.stream, err = e.file.NewStreamWriter(name)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to write sheet %s: %v", name, err)
+	}
+	for i, f := range e.fields {
+		width := 18.0
+		switch {
+		case f.timestamp:
+			width = 12
+		case strings.HasSuffix(f.name, "id"):
+			width = 26
+		case f.name == "email":
+			width = 30
+		}
+		if err := e.stream.SetColWidth(i+1, i+1, width); err != nil {
+			return status.Errorf(codes.Internal, "Failed to write sheet %s: %v", name, err)
+		}
+	}
+	if err := e.stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return status.Errorf(codes.Internal, "Failed to write sheet %s: %v", name, err)
+	}
+
+	header := make([]interface{}, len(e.fields))
+	for i, f := range e.fields {
+		header[i] = excelize.Cell{StyleID: e.headerStyle, Value: f.name}
+	}
+	e.row = 1
+	if err := e.stream.SetRow("A1", header, excelize.RowOpts{Height: 20}); err != nil {
+		return status.Errorf(codes.Internal, "Failed to write sheet %s: %v", name, err)
+	}
+	return nil
+}
+
+func (e *xlsxEncoder) WriteRow(row []interface{}) error {
+	if e.stream == nil {
+		if err := e.SetSheet("Employees"); err != nil {
+			return err
+		}
+	}
+
+	cells := make([]interface{}, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case string:
+			if v != "" {
+				cells[i] = v
+			}
+		case *time.Time:
+			if v != nil {
+				cells[i] = excelize.Cell{StyleID: e.dateStyle, Value: v.UTC()}
+			}
+		}
+	}
+
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err == nil {
+		err = e.stream.SetRow(cell, cells)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to write row %d: %v", e.row, err)
+	}
+	return nil
+}
+
+// Close writes the workbook, with at least a header row if nothing matched
+func (e *xlsxEncoder) Close() error {
+	defer e.file.Close()
+	if e.stream == nil {
+		if err := e.SetSheet("Employees"); err != nil {
+			return err
+		}
+	}
+	if err := e.finishSheet(); err != nil {
+		return err
+	}
+	return e.file.Write(e.w)
+}
+
+// finishSheet flushes the current sheet and turns on filtering for it
+func (e *xlsxEncoder) finishSheet() error {
+	if e.stream == nil {
+		return nil
+	}
+	if err := e.stream.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "Failed to write sheet %s: %v", e.sheet, err)
+	}
+	e.stream = nil
+
+	last, err := excelize.CoordinatesToCellName(len(e.fields), e.row)
+	if err == nil {
+		err = e.file.AutoFilter(e.sheet, "A1:"+last, nil)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to add filter to sheet %s: %v", e.sheet, err)
+	}
+	return nil
+}
+
+// sheetName turns a department name into a unique sheet name. Sheet names
+// are at most 31 characters and cannot contain : \ / ? * [ or ].
+func (e *xlsxEncoder) sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return ' '
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), "'")
+	if name == "" {
+		name = "No department"
+	}
+
+	base := []rune(name)
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			suffix := []rune(fmt.Sprintf(" (%d)", n))
+			if len(candidate)+len(suffix) > 31 {
+				candidate = candidate[:31-len(suffix)]
+			}
+			candidate = append(append([]rune{}, candidate...), suffix...)
+		} else if len(candidate) > 31 {
+			candidate = candidate[:31]
+		}
+		name = string(candidate)
+		if !e.sheets[strings.ToLower(name)] {
+			e.sheets[strings.ToLower(name)] = true
+			return name
+		}
+	}
+}
+
+// xlsxImportErrorsHeader heads the column import errors are written to
+const xlsxImportErrorsHeader = "Import errors"
+
+// maxWorkbookSize limits uploaded workbooks, which are read into memory
+const maxWorkbookSize = 32 << 20
+
+// xlsxImport reads the rows of one sheet of an uploaded workbook, and can
+// write the result of each row back into a copy of it
+type xlsxImport struct {
+	file  *excelize.File
+	sheet string
+	rows  *excelize.Rows
+	// Row number of the last row read
+	line int32
+	// Widest row seen, in cells
+	width int
+	// Column index of an existing errors column, -1 if there is none
+	errorColumn int
+	headerLine  int32
+	// Cells of these columns hold dates, which come as serial numbers
+	dateColumns map[int]bool
+	date1904    bool
+}
+
+func openXLSXImport(r io.Reader, sheet string) (*xlsxImport, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxWorkbookSize+1))
+	if err != nil {
+		return nil, importReadError(err)
+	}
+	if len(data) > maxWorkbookSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Workbook is larger than %d MB", maxWorkbookSize>>20)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to open workbook: %v", err)
+	}
+
+	if sheet == "" {
+		sheet = f.GetSheetList()[0]
+	} else if index, _ := f.GetSheetIndex(sheet); index < 0 {
+		f.Close()
+		return nil, status.Errorf(codes.InvalidArgument, "Workbook has no sheet named %q", sheet)
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		f.Close()
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to read sheet %s: %v", sheet, err)
+	}
+
+	imp := &xlsxImport{file: f, sheet: sheet, rows: rows, errorColumn: -1, dateColumns: map[int]bool{}}
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		imp.date1904 = *props.Date1904
+	}
+	return imp, nil
+}
+
+// Next returns the next row that is not blank, and its row number
+func (x *xlsxImport) Next() (int32, []string, error) {
+	for x.rows.Next() {
+		x.line++
+		cells, err := x.rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return 0, nil, status.Errorf(codes.InvalidArgument, "Failed to read row %d of sheet %s: %v", x.line, x.sheet, err)
+		}
+		if strings.TrimSpace(strings.Join(cells, "")) == "" {
+			continue
+		}
+		if x.headerLine == 0 {
+			x.headerLine = x.line
+		}
+		if len(cells) > x.width {
+			x.width = len(cells)
+		}
+
+		for i := range cells {
+			if x.dateColumns[i] {
+				cells[i] = x.dateCell(cells[i])
+			}
+		}
+		return x.line, cells, nil
+	}
+	if err := x.rows.Error(); err != nil {
+		return 0, nil, status.Errorf(codes.InvalidArgument, "Failed to read sheet %s: %v", x.sheet, err)
+	}
+	return 0, nil, io.EOF
+}
+
+// setColumns tells the reader which field each column fills, so the cells
+// of the date fields can be converted, and finds a previous errors column
+// to reuse
+func (x *xlsxImport) setColumns(header, columns []string, dates map[string]bool) {
+	for i, field := range columns {
+		if dates[field] {
+			x.dateColumns[i] = true
+		}
+	}
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), xlsxImportErrorsHeader) {
+			x.errorColumn = i
+		}
+	}
+}
+
+// dateCell turns a date serial number into YYYY-MM-DD, or RFC 3339 when it
+// has a time of day. Text is left as it is.
+func (x *xlsxImport) dateCell(v string) string {
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	t, err := excelize.ExcelDateToTime(serial, x.date1904)
+	if err != nil {
+		return v
+	}
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// rowCount is the number of rows the sheet says it has, blank rows and the
+// header included
+func (x *xlsxImport) rowCount() int64 {
+	dim, err := x.file.GetSheetDimension(x.sheet)
+	if err != nil {
+		return 0
+	}
+	_, row, err := excelize.CellNameToCoordinates(dim[strings.LastIndex(dim, ":")+1:])
+	if err != nil {
+		return 0
+	}
+	return int64(row)
+}
+
+// annotate writes each row's errors into the errors column and returns the
+// workbook. Rows without results have no errors.
+func (x *xlsxImport) annotate(results []*pb.ImportRowResult) ([]byte, error) {
+	x.rows.Close()
+
+	col := x.errorColumn + 1
+	if x.errorColumn < 0 {
+		col = x.width + 1
+	}
+	name, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+	}
+
+	headerStyle, err := x.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Color: "C00000"}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+	}
+	errorStyle, err := x.file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Color: "C00000"},
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+	}
+
+	set := func(line int32, value string, style int) error {
+		cell := name + strconv.Itoa(int(line))
+		if err := x.file.SetCellStr(x.sheet, cell, value); err != nil {
+			return err
+		}
+		return x.file.SetCellStyle(x.sheet, cell, cell, style)
+	}
+	if err := set(x.headerLine, xlsxImportErrorsHeader, headerStyle); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+	}
+	// Errors left from an earlier check of the file
+	if x.errorColumn >= 0 {
+		for line := x.headerLine + 1; line <= x.line; line++ {
+			if err := x.file.SetCellValue(x.sheet, name+strconv.Itoa(int(line)), nil); err != nil {
+				return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+			}
+		}
+	}
+	for _, row := range results {
+		if err := set(row.GetLine(), strings.Join(row.GetErrors(), "\n"), errorStyle); err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+		}
+	}
+	if err := x.file.SetColWidth(x.sheet, name, name, 60); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to annotate workbook: %v", err)
+	}
+
+	buf, err := x.file.WriteToBuffer()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to write workbook: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (x *xlsxImport) Close() error {
+	x.rows.Close()
+	return x.file.Close()
+}